@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logwriter // import "go.opentelemetry.io/otel/bridge/logwriter"
+
+import (
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// config contains configuration options for a Writer.
+type config struct {
+	provider  log.LoggerProvider
+	version   string
+	schemaURL string
+
+	severity   log.Severity
+	regexp     *regexp.Regexp
+	json       bool
+	timeLayout string
+}
+
+func newConfig(options []Option) config {
+	var c config
+	for _, opt := range options {
+		c = opt.apply(c)
+	}
+
+	if c.provider == nil {
+		c.provider = global.GetLoggerProvider()
+	}
+	if c.severity == log.SeverityUndefined {
+		c.severity = log.SeverityInfo
+	}
+	if c.timeLayout == "" {
+		c.timeLayout = time.RFC3339Nano
+	}
+
+	return c
+}
+
+func (c config) logger(name string) log.Logger {
+	var opts []log.LoggerOption
+	if c.version != "" {
+		opts = append(opts, log.WithInstrumentationVersion(c.version))
+	}
+	if c.schemaURL != "" {
+		opts = append(opts, log.WithSchemaURL(c.schemaURL))
+	}
+	return c.provider.Logger(name, opts...)
+}
+
+// Option configures a Writer.
+type Option interface {
+	apply(config) config
+}
+
+type optFunc func(config) config
+
+func (f optFunc) apply(c config) config { return f(c) }
+
+// WithLoggerProvider returns an [Option] that configures the
+// [log.LoggerProvider] used by a [Writer] to create its [log.Logger].
+//
+// By default if this Option is not provided, the Writer will use the global
+// LoggerProvider.
+func WithLoggerProvider(provider log.LoggerProvider) Option {
+	return optFunc(func(c config) config {
+		c.provider = provider
+		return c
+	})
+}
+
+// WithVersion returns an [Option] that configures the version of the
+// [log.Logger] used by a [Writer]. The version should be the version of the
+// package that is being bridged.
+func WithVersion(version string) Option {
+	return optFunc(func(c config) config {
+		c.version = version
+		return c
+	})
+}
+
+// WithSchemaURL returns an [Option] that configures the semantic convention
+// schema URL of the [log.Logger] used by a [Writer]. The schemaURL should be
+// the schema URL for the semantic conventions used in log records.
+func WithSchemaURL(schemaURL string) Option {
+	return optFunc(func(c config) config {
+		c.schemaURL = schemaURL
+		return c
+	})
+}
+
+// WithSeverity returns an [Option] that configures the [log.Severity] of the
+// log records emitted for lines whose severity cannot be inferred.
+//
+// By default if this Option is not provided, [log.SeverityInfo] is used.
+func WithSeverity(severity log.Severity) Option {
+	return optFunc(func(c config) config {
+		c.severity = severity
+		return c
+	})
+}
+
+// WithRegexp returns an [Option] that configures a [Writer] to parse each
+// line with re. The named capture groups of re are mapped to the fields of
+// the emitted log record:
+//
+//   - "body", "message" or "msg" is used as the body.
+//   - "severity" or "level" is used as the severity text and to infer the
+//     severity.
+//   - "timestamp", "time" or "ts" is parsed as the timestamp using the
+//     layout configured with [WithTimeLayout].
+//   - All other named capture groups are added as string attributes.
+//
+// If re has no body capture group, the whole line is used as the body. Lines
+// not matching re are emitted as if this option was not used.
+func WithRegexp(re *regexp.Regexp) Option {
+	return optFunc(func(c config) config {
+		c.regexp = re
+		return c
+	})
+}
+
+// WithJSON returns an [Option] that configures a [Writer] to parse each line
+// as a JSON object. The members of the object are mapped to the fields of the
+// emitted log record:
+//
+//   - "body", "message" or "msg" is used as the body.
+//   - "severity" or "level" is used as the severity text and to infer the
+//     severity.
+//   - "timestamp", "time" or "ts" is parsed as the timestamp using the
+//     layout configured with [WithTimeLayout].
+//   - All other members are added as attributes.
+//
+// Lines that are not a JSON object are emitted as if this option was not
+// used. This option takes precedence over [WithRegexp].
+func WithJSON() Option {
+	return optFunc(func(c config) config {
+		c.json = true
+		return c
+	})
+}
+
+// WithTimeLayout returns an [Option] that configures the layout used to
+// parse the timestamps of lines parsed with [WithRegexp] or [WithJSON]. See
+// [time.Parse] for the format of layout.
+//
+// By default if this Option is not provided, [time.RFC3339Nano] is used.
+func WithTimeLayout(layout string) Option {
+	return optFunc(func(c config) config {
+		c.timeLayout = layout
+		return c
+	})
+}
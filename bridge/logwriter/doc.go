@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logwriter provides a bridge between [io.Writer] log sources, like
+// the standard library [log.Logger], and OpenTelemetry logging.
+//
+// Each line written to a [Writer] is parsed into a
+// [go.opentelemetry.io/otel/log.Record] and emitted with a
+// [go.opentelemetry.io/otel/log.Logger]. By default, the line is used as the
+// record body and its severity is inferred from any level keyword it
+// contains (e.g. "ERROR" or "warn"). Lines can also be parsed with a regular
+// expression (see [WithRegexp]) or as JSON objects (see [WithJSON]).
+//
+// Use [NewStdLogger] to create a [log.Logger] from the standard library
+// whose output is emitted as OpenTelemetry log records.
+//
+// [log.Logger]: https://pkg.go.dev/log#Logger
+package logwriter // import "go.opentelemetry.io/otel/bridge/logwriter"
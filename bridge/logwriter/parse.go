@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logwriter // import "go.opentelemetry.io/otel/bridge/logwriter"
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// severityRe matches the first level keyword of a line.
+var severityRe = regexp.MustCompile(`(?i)\b(trace|debug|info|notice|warn|warning|error|err|fatal|panic|critical|crit)\b`)
+
+// severities maps lower case level keywords to their severity.
+var severities = map[string]log.Severity{
+	"trace":    log.SeverityTrace,
+	"debug":    log.SeverityDebug,
+	"info":     log.SeverityInfo,
+	"notice":   log.SeverityInfo2,
+	"warn":     log.SeverityWarn,
+	"warning":  log.SeverityWarn,
+	"error":    log.SeverityError,
+	"err":      log.SeverityError,
+	"critical": log.SeverityFatal,
+	"crit":     log.SeverityFatal,
+	"fatal":    log.SeverityFatal,
+	"panic":    log.SeverityFatal,
+}
+
+// parseSeverity returns the severity for the level keyword s and true, or
+// false if s is not a known level keyword.
+func parseSeverity(s string) (log.Severity, bool) {
+	sev, ok := severities[strings.ToLower(strings.TrimSpace(s))]
+	return sev, ok
+}
+
+// inferSeverity returns the severity and severity text of the first level
+// keyword found in line. If none is found, false is returned.
+func inferSeverity(line string) (log.Severity, string, bool) {
+	m := severityRe.FindString(line)
+	if m == "" {
+		return 0, "", false
+	}
+	sev, ok := parseSeverity(m)
+	return sev, m, ok
+}
+
+// parser parses lines into log records.
+type parser struct {
+	severity   log.Severity
+	regexp     *regexp.Regexp
+	json       bool
+	timeLayout string
+}
+
+func newParser(c config) parser {
+	return parser{
+		severity:   c.severity,
+		regexp:     c.regexp,
+		json:       c.json,
+		timeLayout: c.timeLayout,
+	}
+}
+
+// parse returns the log record for line.
+func (p parser) parse(line string) log.Record {
+	if p.json {
+		if r, ok := p.parseJSON(line); ok {
+			return r
+		}
+	} else if p.regexp != nil {
+		if r, ok := p.parseRegexp(line); ok {
+			return r
+		}
+	}
+	return p.parseText(line)
+}
+
+// parseText returns a record with line as its body and a severity inferred
+// from line.
+func (p parser) parseText(line string) log.Record {
+	var r log.Record
+	r.SetBody(log.StringValue(line))
+	if sev, txt, ok := inferSeverity(line); ok {
+		r.SetSeverity(sev)
+		r.SetSeverityText(strings.ToUpper(txt))
+	} else {
+		r.SetSeverity(p.severity)
+	}
+	return r
+}
+
+// setSeverity sets the severity text and severity of r from txt. If txt is
+// not a known level keyword, the default severity of p is used.
+func (p parser) setSeverity(r *log.Record, txt string) {
+	r.SetSeverityText(txt)
+	if sev, ok := parseSeverity(txt); ok {
+		r.SetSeverity(sev)
+	} else {
+		r.SetSeverity(p.severity)
+	}
+}
+
+// setTimestamp sets the timestamp of r if txt is a valid timestamp.
+func (p parser) setTimestamp(r *log.Record, txt string) bool {
+	ts, err := time.Parse(p.timeLayout, txt)
+	if err != nil {
+		return false
+	}
+	r.SetTimestamp(ts)
+	return true
+}
+
+func (p parser) parseRegexp(line string) (log.Record, bool) {
+	m := p.regexp.FindStringSubmatch(line)
+	if m == nil {
+		return log.Record{}, false
+	}
+
+	var (
+		r           log.Record
+		hasBody     bool
+		hasSeverity bool
+		attrs       []log.KeyValue
+	)
+	for i, name := range p.regexp.SubexpNames() {
+		if name == "" || i >= len(m) {
+			continue
+		}
+		switch name {
+		case "body", "message", "msg":
+			r.SetBody(log.StringValue(m[i]))
+			hasBody = true
+		case "severity", "level":
+			p.setSeverity(&r, m[i])
+			hasSeverity = true
+		case "timestamp", "time", "ts":
+			if !p.setTimestamp(&r, m[i]) {
+				attrs = append(attrs, log.String(name, m[i]))
+			}
+		default:
+			attrs = append(attrs, log.String(name, m[i]))
+		}
+	}
+
+	if !hasBody {
+		r.SetBody(log.StringValue(line))
+	}
+	if !hasSeverity {
+		if sev, txt, ok := inferSeverity(line); ok {
+			r.SetSeverity(sev)
+			r.SetSeverityText(strings.ToUpper(txt))
+		} else {
+			r.SetSeverity(p.severity)
+		}
+	}
+	r.AddAttributes(attrs...)
+	return r, true
+}
+
+func (p parser) parseJSON(line string) (log.Record, bool) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil || obj == nil {
+		return log.Record{}, false
+	}
+
+	var (
+		r           log.Record
+		hasBody     bool
+		hasSeverity bool
+		attrs       []log.KeyValue
+	)
+	for _, key := range sortedKeys(obj) {
+		v := obj[key]
+		switch key {
+		case "body", "message", "msg":
+			if !hasBody {
+				r.SetBody(convert(v))
+				hasBody = true
+				continue
+			}
+		case "severity", "level":
+			if s, ok := v.(string); ok && !hasSeverity {
+				p.setSeverity(&r, s)
+				hasSeverity = true
+				continue
+			}
+		case "timestamp", "time", "ts":
+			if s, ok := v.(string); ok && p.setTimestamp(&r, s) {
+				continue
+			}
+		}
+		attrs = append(attrs, log.KeyValue{Key: key, Value: convert(v)})
+	}
+
+	if !hasBody {
+		r.SetBody(log.StringValue(line))
+	}
+	if !hasSeverity {
+		r.SetSeverity(p.severity)
+	}
+	r.AddAttributes(attrs...)
+	return r, true
+}
+
+// sortedKeys returns the keys of m in lexicographic order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	// Sort for a deterministic attribute order.
+	slices.Sort(keys)
+	return keys
+}
+
+// convert returns the log.Value of the decoded JSON value v.
+func convert(v any) log.Value {
+	switch val := v.(type) {
+	case string:
+		return log.StringValue(val)
+	case bool:
+		return log.BoolValue(val)
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return log.Int64Value(i)
+		}
+		if f, err := val.Float64(); err == nil {
+			return log.Float64Value(f)
+		}
+		return log.StringValue(val.String())
+	case []any:
+		vals := make([]log.Value, 0, len(val))
+		for _, e := range val {
+			vals = append(vals, convert(e))
+		}
+		return log.SliceValue(vals...)
+	case map[string]any:
+		kvs := make([]log.KeyValue, 0, len(val))
+		for _, k := range sortedKeys(val) {
+			kvs = append(kvs, log.KeyValue{Key: k, Value: convert(val[k])})
+		}
+		return log.MapValue(kvs...)
+	default:
+		// JSON null.
+		return log.Value{}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logwriter // import "go.opentelemetry.io/otel/bridge/logwriter"
+
+import (
+	"bytes"
+	"context"
+	"io"
+	stdlog "log"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// Writer is an [io.Writer] that emits each line written to it as a log
+// record.
+//
+// Writes do not need to be aligned with lines. Any trailing data not
+// terminated by a newline is buffered until the rest of the line is written
+// or the Writer is flushed.
+//
+// Writer must be created with [NewWriter]. It is safe to use concurrently.
+type Writer struct {
+	logger log.Logger
+	parser parser
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+var _ io.WriteCloser = (*Writer)(nil)
+
+// NewWriter returns a new [Writer] to be used as an [io.Writer] log source.
+// The name is used as the name of the [log.Logger] emitting the log records
+// and should be the package import path of the log source.
+func NewWriter(name string, options ...Option) *Writer {
+	cfg := newConfig(options)
+	return &Writer{
+		logger: cfg.logger(name),
+		parser: newParser(cfg),
+	}
+}
+
+// NewStdLogger returns a new standard library [stdlog.Logger] whose output
+// is emitted as log records by a [Writer] configured with name and
+// options.
+//
+// The returned Logger does not add any prefix or flags to its output. The
+// timestamp of the emitted log records is the time they are observed.
+func NewStdLogger(name string, options ...Option) *stdlog.Logger {
+	return stdlog.New(NewWriter(name, options...), "", 0)
+}
+
+// Write emits a log record for each complete line in p. It always returns
+// len(p) and a nil error.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := len(p)
+	if len(w.buf) > 0 {
+		// Complete the buffered partial line.
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.buf = append(w.buf, p...)
+			return n, nil
+		}
+		w.buf = append(w.buf, p[:i]...)
+		w.emit(w.buf)
+		w.buf = w.buf[:0]
+		p = p[i+1:]
+	}
+
+	for {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(p[:i])
+		p = p[i+1:]
+	}
+	w.buf = append(w.buf, p...)
+	return n, nil
+}
+
+// Flush emits any buffered partial line as a log record.
+func (w *Writer) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.emit(w.buf)
+		w.buf = w.buf[:0]
+	}
+}
+
+// Close flushes w. It always returns nil.
+func (w *Writer) Close() error {
+	w.Flush()
+	return nil
+}
+
+// emit emits line as a log record. The mu lock needs to be held when calling
+// this method.
+func (w *Writer) emit(line []byte) {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	r := w.parser.parse(string(line))
+	if !w.logger.Enabled(ctx, r) {
+		return
+	}
+	w.logger.Emit(ctx, r)
+}
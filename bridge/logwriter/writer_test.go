@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logwriter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+const loggerName = "go.opentelemetry.io/otel/bridge/logwriter/test"
+
+// records returns all the records rec recorded for the loggerName scope.
+func records(t *testing.T, rec *logtest.Recorder) []log.Record {
+	t.Helper()
+
+	var out []log.Record
+	for _, s := range rec.Result() {
+		if s.Name != loggerName {
+			continue
+		}
+		for _, r := range s.Records {
+			out = append(out, r.Record)
+		}
+	}
+	return out
+}
+
+func attrs(r log.Record) []log.KeyValue {
+	var kvs []log.KeyValue
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		kvs = append(kvs, kv)
+		return true
+	})
+	return kvs
+}
+
+func TestNewWriterConfiguration(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		rec := logtest.NewRecorder()
+		prev := global.GetLoggerProvider()
+		t.Cleanup(func() { global.SetLoggerProvider(prev) })
+		global.SetLoggerProvider(rec)
+
+		w := NewWriter(loggerName)
+		_, err := w.Write([]byte("message\n"))
+		require.NoError(t, err)
+
+		require.Len(t, rec.Result(), 1)
+		s := rec.Result()[0]
+		assert.Equal(t, loggerName, s.Name)
+		assert.Equal(t, "", s.Version)
+		assert.Equal(t, "", s.SchemaURL)
+		assert.Len(t, s.Records, 1)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		rec := logtest.NewRecorder()
+		w := NewWriter(
+			loggerName,
+			WithLoggerProvider(rec),
+			WithVersion("v1.2.3"),
+			WithSchemaURL("https://example.com/schema"),
+		)
+		_, err := w.Write([]byte("message\n"))
+		require.NoError(t, err)
+
+		require.Len(t, rec.Result(), 1)
+		s := rec.Result()[0]
+		assert.Equal(t, loggerName, s.Name)
+		assert.Equal(t, "v1.2.3", s.Version)
+		assert.Equal(t, "https://example.com/schema", s.SchemaURL)
+	})
+}
+
+func TestWriterLines(t *testing.T) {
+	rec := logtest.NewRecorder()
+	w := NewWriter(loggerName, WithLoggerProvider(rec))
+
+	n, err := w.Write([]byte("first\nsec"))
+	require.NoError(t, err)
+	assert.Equal(t, 9, n)
+	require.Len(t, records(t, rec), 1, "partial line emitted")
+
+	_, err = w.Write([]byte("ond\r\n\n  \nthi"))
+	require.NoError(t, err)
+	require.Len(t, records(t, rec), 2, "blank lines emitted")
+
+	_, err = w.Write([]byte("rd"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	got := records(t, rec)
+	require.Len(t, got, 3, "partial line not flushed")
+	assert.Equal(t, log.StringValue("first"), got[0].Body())
+	assert.Equal(t, log.StringValue("second"), got[1].Body())
+	assert.Equal(t, log.StringValue("third"), got[2].Body())
+
+	w.Flush()
+	assert.Len(t, records(t, rec), 3, "empty buffer flushed")
+}
+
+func TestWriterSeverityInference(t *testing.T) {
+	testcases := []struct {
+		line    string
+		options []Option
+		sev     log.Severity
+		sevText string
+	}{
+		{line: "no level here", sev: log.SeverityInfo},
+		{line: "no level here", options: []Option{WithSeverity(log.SeverityDebug)}, sev: log.SeverityDebug},
+		{line: "[ERROR] failed", sev: log.SeverityError, sevText: "ERROR"},
+		{line: "level=warn msg=slow", sev: log.SeverityWarn, sevText: "WARN"},
+		{line: "2024/07/01 WARNING: disk", sev: log.SeverityWarn, sevText: "WARNING"},
+		{line: "debug: value", sev: log.SeverityDebug, sevText: "DEBUG"},
+		{line: "panic: runtime error", sev: log.SeverityFatal, sevText: "PANIC"},
+		{line: "information is not a level", sev: log.SeverityInfo},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.line, func(t *testing.T) {
+			rec := logtest.NewRecorder()
+			w := NewWriter(loggerName, append(tc.options, WithLoggerProvider(rec))...)
+			_, err := fmt.Fprintln(w, tc.line)
+			require.NoError(t, err)
+
+			got := records(t, rec)
+			require.Len(t, got, 1)
+			assert.Equal(t, log.StringValue(tc.line), got[0].Body())
+			assert.Equal(t, tc.sev, got[0].Severity())
+			assert.Equal(t, tc.sevText, got[0].SeverityText())
+		})
+	}
+}
+
+func TestWriterRegexp(t *testing.T) {
+	re := regexp.MustCompile(`^(?P<time>\S+) (?P<level>\w+) \[(?P<component>\w+)\] (?P<msg>.*)$`)
+	rec := logtest.NewRecorder()
+	w := NewWriter(loggerName, WithLoggerProvider(rec), WithRegexp(re))
+
+	_, err := fmt.Fprintln(w, "2024-07-01T12:00:00Z error [db] connection lost")
+	require.NoError(t, err)
+	_, err = fmt.Fprintln(w, "unstructured warn line")
+	require.NoError(t, err)
+
+	got := records(t, rec)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, log.StringValue("connection lost"), got[0].Body())
+	assert.Equal(t, log.SeverityError, got[0].Severity())
+	assert.Equal(t, "error", got[0].SeverityText())
+	assert.Equal(t, time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), got[0].Timestamp())
+	assert.Equal(t, []log.KeyValue{log.String("component", "db")}, attrs(got[0]))
+
+	// Not matching lines fallback to the default parsing.
+	assert.Equal(t, log.StringValue("unstructured warn line"), got[1].Body())
+	assert.Equal(t, log.SeverityWarn, got[1].Severity())
+	assert.True(t, got[1].Timestamp().IsZero())
+	assert.Empty(t, attrs(got[1]))
+}
+
+func TestWriterJSON(t *testing.T) {
+	rec := logtest.NewRecorder()
+	w := NewWriter(loggerName, WithLoggerProvider(rec), WithJSON(), WithTimeLayout(time.DateTime))
+
+	_, err := fmt.Fprintln(w, `{"time":"2024-07-01 12:00:00","level":"WARN","msg":"slow query","ms":120,"ratio":0.5,"ok":false,"tags":["a","b"],"db":{"name":"users"},"none":null}`)
+	require.NoError(t, err)
+	_, err = fmt.Fprintln(w, `not json`)
+	require.NoError(t, err)
+
+	got := records(t, rec)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, log.StringValue("slow query"), got[0].Body())
+	assert.Equal(t, log.SeverityWarn, got[0].Severity())
+	assert.Equal(t, "WARN", got[0].SeverityText())
+	assert.Equal(t, time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), got[0].Timestamp())
+	want := []log.KeyValue{
+		log.Map("db", log.String("name", "users")),
+		log.Int64("ms", 120),
+		{Key: "none"},
+		log.Bool("ok", false),
+		log.Float64("ratio", 0.5),
+		log.Slice("tags", log.StringValue("a"), log.StringValue("b")),
+	}
+	gotAttrs := attrs(got[0])
+	require.Len(t, gotAttrs, len(want))
+	for i := range want {
+		assert.Truef(t, want[i].Equal(gotAttrs[i]), "%s != %s", want[i], gotAttrs[i])
+	}
+
+	assert.Equal(t, log.StringValue("not json"), got[1].Body())
+	assert.Equal(t, log.SeverityInfo, got[1].Severity())
+}
+
+func TestWriterEnabled(t *testing.T) {
+	rec := logtest.NewRecorder(logtest.WithEnabledFunc(func(_ context.Context, r log.Record) bool {
+		return r.Severity() >= log.SeverityWarn
+	}))
+	w := NewWriter(loggerName, WithLoggerProvider(rec))
+
+	_, err := fmt.Fprint(w, "info message\nerror message\n")
+	require.NoError(t, err)
+
+	got := records(t, rec)
+	require.Len(t, got, 1)
+	assert.Equal(t, log.StringValue("error message"), got[0].Body())
+}
+
+func TestNewStdLogger(t *testing.T) {
+	rec := logtest.NewRecorder()
+	l := NewStdLogger(loggerName, WithLoggerProvider(rec))
+
+	l.Print("ERROR: something failed")
+	l.Printf("processed %d items", 3)
+
+	got := records(t, rec)
+	require.Len(t, got, 2)
+	assert.Equal(t, log.StringValue("ERROR: something failed"), got[0].Body())
+	assert.Equal(t, log.SeverityError, got[0].Severity())
+	assert.Equal(t, log.StringValue("processed 3 items"), got[1].Body())
+	assert.Equal(t, log.SeverityInfo, got[1].Severity())
+}
+
+func TestWriterConcurrentSafe(t *testing.T) {
+	const goroutines = 10
+
+	rec := logtest.NewRecorder()
+	w := NewWriter(loggerName, WithLoggerProvider(rec))
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			_, _ = fmt.Fprintf(w, "line %d\n", i)
+			w.Flush()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, records(t, rec), goroutines)
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpauth // import "go.opentelemetry.io/otel/exporters/otlp/otlpauth"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxResponseSize is the maximum number of bytes read from a token response.
+const maxResponseSize = 1 << 20
+
+// ClientCredentials authenticates requests with an access token obtained from
+// an authorization server using the OAuth 2.0 client credentials grant, as
+// defined in RFC 6749 section 4.4. The token is sent as a bearer token in the
+// "Authorization" header.
+//
+// The token is cached and reused until it expires. A new token is requested
+// when the cached one is about to expire, see [WithExpiryDelta]. Concurrent
+// requests needing a new token wait for a single token request to complete.
+//
+// ClientCredentials is safe for concurrent use.
+type ClientCredentials struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	cfg          config
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+
+	mu    sync.Mutex
+	token *token
+}
+
+// token is an access token and its expiry.
+type token struct {
+	// value is the value of the Authorization header, e.g. "Bearer abc".
+	value string
+	// expiry is the time the token expires. It is the zero time if the token
+	// does not expire.
+	expiry time.Time
+}
+
+// NewClientCredentials returns a ClientCredentials requesting access tokens
+// from the token endpoint at tokenURL with the client credentials clientID
+// and clientSecret.
+//
+// No token is requested until the first request is authenticated.
+func NewClientCredentials(tokenURL, clientID, clientSecret string, opts ...Option) *ClientCredentials {
+	return &ClientCredentials{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		cfg:          newConfig(opts),
+		now:          time.Now,
+	}
+}
+
+// Headers returns the "Authorization" header holding a valid access token. A
+// new token is requested from the token endpoint if the cached token expired
+// or if no token was requested yet.
+func (c *ClientCredentials) Headers(ctx context.Context) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.valid(c.token) {
+		t, err := c.requestToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.token = t
+	}
+	return map[string]string{"Authorization": c.token.value}, nil
+}
+
+// valid returns if t can be used to authenticate a request.
+func (c *ClientCredentials) valid(t *token) bool {
+	if t == nil {
+		return false
+	}
+	if t.expiry.IsZero() {
+		return true
+	}
+	return c.now().Add(c.cfg.expiryDelta).Before(t.expiry)
+}
+
+// tokenResponse is the successful response of a token endpoint, as defined in
+// RFC 6749 section 5.1.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn is a json.Number as some authorization servers send it as a
+	// string.
+	ExpiresIn json.Number `json:"expires_in"`
+}
+
+// errorResponse is the error response of a token endpoint, as defined in RFC
+// 6749 section 5.2.
+type errorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// requestToken requests a new access token from the token endpoint.
+func (c *ClientCredentials) requestToken(ctx context.Context) (*token, error) {
+	params := url.Values{}
+	for k, v := range c.cfg.endpointParams {
+		params[k] = v
+	}
+	params.Set("grant_type", "client_credentials")
+	if len(c.cfg.scopes) > 0 {
+		params.Set("scope", strings.Join(c.cfg.scopes, " "))
+	}
+	if c.cfg.authInParams {
+		params.Set("client_id", c.clientID)
+		params.Set("client_secret", c.clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if !c.cfg.authInParams {
+		// RFC 6749 section 2.3.1 requires the credentials to be encoded.
+		req.SetBasicAuth(url.QueryEscape(c.clientID), url.QueryEscape(c.clientSecret))
+	}
+
+	// Measure the validity of the token from the time it is requested so it
+	// does not outlive its expiry.
+	requested := c.now()
+	resp, err := c.cfg.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, responseError(resp, body)
+	}
+
+	var tr tokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+	if tr.AccessToken == "" {
+		return nil, errors.New("invalid token response: missing access_token")
+	}
+
+	t := &token{value: tokenType(tr.TokenType) + " " + tr.AccessToken}
+	if tr.ExpiresIn != "" {
+		secs, err := tr.ExpiresIn.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid token response: invalid expires_in: %w", err)
+		}
+		if secs > 0 {
+			t.expiry = requested.Add(time.Duration(secs) * time.Second)
+		}
+	}
+	return t, nil
+}
+
+// tokenType returns the authentication scheme of a token of type typ.
+func tokenType(typ string) string {
+	// The token type is case insensitive, but some servers only accept the
+	// canonical "Bearer" scheme.
+	if typ == "" || strings.EqualFold(typ, "bearer") {
+		return "Bearer"
+	}
+	return typ
+}
+
+// responseError returns the error of an unsuccessful token response.
+func responseError(resp *http.Response, body []byte) error {
+	var er errorResponse
+	if err := json.Unmarshal(body, &er); err == nil && er.Error != "" {
+		if er.Description != "" {
+			return fmt.Errorf("token request failed: %s: %s: %s", resp.Status, er.Error, er.Description)
+		}
+		return fmt.Errorf("token request failed: %s: %s", resp.Status, er.Error)
+	}
+	if msg := strings.TrimSpace(string(body)); msg != "" {
+		return fmt.Errorf("token request failed: %s: %s", resp.Status, msg)
+	}
+	return fmt.Errorf("token request failed: %s", resp.Status)
+}
@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer is a mock OAuth 2.0 token endpoint.
+type tokenServer struct {
+	*httptest.Server
+
+	// handle responds to the token requests. It is called with the parsed
+	// form of the request.
+	handle func(w http.ResponseWriter, r *http.Request)
+
+	mu       sync.Mutex
+	requests []url.Values
+	count    atomic.Int64
+}
+
+func newTokenServer(t *testing.T, handle func(http.ResponseWriter, *http.Request)) *tokenServer {
+	t.Helper()
+	ts := &tokenServer{handle: handle}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodPost, r.Method) ||
+			!assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type")) ||
+			!assert.NoError(t, r.ParseForm()) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		n := ts.count.Add(1)
+
+		ts.mu.Lock()
+		ts.requests = append(ts.requests, r.PostForm)
+		ts.mu.Unlock()
+
+		if ts.handle != nil {
+			ts.handle(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestClientCredentialsRequest(t *testing.T) {
+	ts := newTokenServer(t, nil)
+	ts.handle = func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok, "basic auth")
+		assert.Equal(t, "client%2Fid", id, "URL encoded client ID")
+		assert.Equal(t, "s%C3%A9cret", secret, "URL encoded client secret")
+		writeJSON(w, http.StatusOK, map[string]any{"access_token": "abc", "token_type": "bearer"})
+	}
+
+	c := NewClientCredentials(
+		ts.URL, "client/id", "sécret",
+		WithScopes("trace.write", "metric.write"),
+		WithEndpointParams(url.Values{
+			"audience":   {"otlp"},
+			"grant_type": {"password"},
+		}),
+	)
+	h, err := c.Headers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc"}, h)
+
+	require.Len(t, ts.requests, 1)
+	assert.Equal(t, url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {"trace.write metric.write"},
+		"audience":   {"otlp"},
+	}, ts.requests[0])
+}
+
+func TestClientCredentialsInParams(t *testing.T) {
+	ts := newTokenServer(t, nil)
+	ts.handle = func(w http.ResponseWriter, r *http.Request) {
+		_, _, ok := r.BasicAuth()
+		assert.False(t, ok, "basic auth")
+		writeJSON(w, http.StatusOK, map[string]any{"access_token": "abc"})
+	}
+
+	c := NewClientCredentials(ts.URL, "id", "secret", WithClientCredentialsInParams())
+	h, err := c.Headers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc"}, h, "default token type")
+
+	require.Len(t, ts.requests, 1)
+	assert.Equal(t, url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {"id"},
+		"client_secret": {"secret"},
+	}, ts.requests[0])
+}
+
+func TestClientCredentialsCache(t *testing.T) {
+	ts := newTokenServer(t, nil)
+	c := NewClientCredentials(ts.URL, "id", "secret")
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		h, err := c.Headers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", h["Authorization"])
+	}
+	assert.Equal(t, int64(1), ts.count.Load(), "token is cached")
+
+	// Within the default expiry delta of the expiry.
+	now = now.Add(time.Hour - 5*time.Second)
+	h, err := c.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", h["Authorization"], "token is refreshed")
+
+	now = now.Add(30 * time.Minute)
+	h, err = c.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", h["Authorization"])
+	assert.Equal(t, int64(2), ts.count.Load())
+}
+
+func TestClientCredentialsExpiry(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn any
+		opts      []Option
+		elapsed   time.Duration
+		refreshed bool
+	}{
+		{name: "NoExpiry", expiresIn: nil, elapsed: 24 * time.Hour, refreshed: false},
+		{name: "ZeroExpiry", expiresIn: 0, elapsed: 24 * time.Hour, refreshed: false},
+		{name: "Valid", expiresIn: 60, elapsed: 49 * time.Second, refreshed: false},
+		{name: "Expired", expiresIn: 60, elapsed: 51 * time.Second, refreshed: true},
+		{name: "StringExpiry", expiresIn: "60", elapsed: 51 * time.Second, refreshed: true},
+		{name: "ZeroDelta", expiresIn: 60, opts: []Option{WithExpiryDelta(0)}, elapsed: 59 * time.Second, refreshed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTokenServer(t, nil)
+			ts.handle = func(w http.ResponseWriter, _ *http.Request) {
+				resp := map[string]any{"access_token": "abc"}
+				if tt.expiresIn != nil {
+					resp["expires_in"] = tt.expiresIn
+				}
+				writeJSON(w, http.StatusOK, resp)
+			}
+
+			c := NewClientCredentials(ts.URL, "id", "secret", tt.opts...)
+			now := time.Now()
+			c.now = func() time.Time { return now }
+
+			ctx := context.Background()
+			_, err := c.Headers(ctx)
+			require.NoError(t, err)
+			now = now.Add(tt.elapsed)
+			_, err = c.Headers(ctx)
+			require.NoError(t, err)
+
+			want := int64(1)
+			if tt.refreshed {
+				want = 2
+			}
+			assert.Equal(t, want, ts.count.Load())
+		})
+	}
+}
+
+func TestClientCredentialsConcurrentSafe(t *testing.T) {
+	ts := newTokenServer(t, nil)
+	c := NewClientCredentials(ts.URL, "id", "secret")
+
+	const goroutines = 10
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h, err := c.Headers(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "Bearer token-1", h["Authorization"])
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1), ts.count.Load(), "single token request")
+}
+
+func TestClientCredentialsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		handle func(http.ResponseWriter, *http.Request)
+		want   string
+	}{
+		{
+			name: "ErrorResponse",
+			handle: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusUnauthorized, map[string]any{
+					"error":             "invalid_client",
+					"error_description": "unknown client",
+				})
+			},
+			want: "token request failed: 401 Unauthorized: invalid_client: unknown client",
+		},
+		{
+			name: "ErrorResponseWithoutDescription",
+			handle: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_scope"})
+			},
+			want: "token request failed: 400 Bad Request: invalid_scope",
+		},
+		{
+			name: "PlainTextError",
+			handle: func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			},
+			want: "token request failed: 503 Service Unavailable: unavailable",
+		},
+		{
+			name: "InvalidJSON",
+			handle: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{"))
+			},
+			want: "invalid token response",
+		},
+		{
+			name: "MissingAccessToken",
+			handle: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusOK, map[string]any{"token_type": "bearer"})
+			},
+			want: "invalid token response: missing access_token",
+		},
+		{
+			name: "InvalidExpiresIn",
+			handle: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusOK, map[string]any{"access_token": "abc", "expires_in": 1.5})
+			},
+			want: "invalid token response: invalid expires_in",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTokenServer(t, tt.handle)
+			c := NewClientCredentials(ts.URL, "id", "secret")
+			_, err := c.Headers(context.Background())
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestClientCredentialsRetriesAfterError(t *testing.T) {
+	ts := newTokenServer(t, nil)
+	ts.handle = func(w http.ResponseWriter, _ *http.Request) {
+		if ts.count.Load() == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"access_token": "abc"})
+	}
+
+	c := NewClientCredentials(ts.URL, "id", "secret")
+	ctx := context.Background()
+	_, err := c.Headers(ctx)
+	require.Error(t, err)
+
+	h, err := c.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer abc", h["Authorization"])
+}
+
+func TestClientCredentialsContext(t *testing.T) {
+	ts := newTokenServer(t, nil)
+	c := NewClientCredentials(ts.URL, "id", "secret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.Headers(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int64(0), ts.count.Load())
+}
+
+func TestClientCredentialsHTTPClient(t *testing.T) {
+	ts := newTokenServer(t, nil)
+
+	var used atomic.Bool
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		used.Store(true)
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	c := NewClientCredentials(ts.URL, "id", "secret", WithHTTPClient(client))
+	_, err := c.Headers(context.Background())
+	require.NoError(t, err)
+	assert.True(t, used.Load())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
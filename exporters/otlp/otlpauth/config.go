@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpauth // import "go.opentelemetry.io/otel/exporters/otlp/otlpauth"
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// defaultExpiryDelta is the default duration before their expiry tokens are
+// refreshed.
+const defaultExpiryDelta = 10 * time.Second
+
+// Option applies an option to a ClientCredentials.
+type Option interface {
+	applyClientCredentialsOption(config) config
+}
+
+type fnOpt func(config) config
+
+func (f fnOpt) applyClientCredentialsOption(c config) config { return f(c) }
+
+type config struct {
+	scopes         []string
+	endpointParams url.Values
+	client         *http.Client
+	expiryDelta    time.Duration
+	authInParams   bool
+}
+
+func newConfig(options []Option) config {
+	c := config{
+		client:      http.DefaultClient,
+		expiryDelta: defaultExpiryDelta,
+	}
+	for _, opt := range options {
+		c = opt.applyClientCredentialsOption(c)
+	}
+	return c
+}
+
+// WithScopes sets the scopes of the requested access tokens.
+//
+// By default, if this option is not passed, no scope is requested.
+func WithScopes(scopes ...string) Option {
+	return fnOpt(func(c config) config {
+		c.scopes = append([]string(nil), scopes...)
+		return c
+	})
+}
+
+// WithEndpointParams sets additional parameters sent in token requests, e.g.
+// the "audience" parameter required by some authorization servers. The
+// parameters cannot override the grant type, the scopes, or the client
+// credentials.
+//
+// By default, if this option is not passed, no additional parameter is sent.
+func WithEndpointParams(params url.Values) Option {
+	return fnOpt(func(c config) config {
+		c.endpointParams = params
+		return c
+	})
+}
+
+// WithHTTPClient sets the client used to send token requests. It can be used
+// to configure the TLS settings, proxy, or timeout of the requests.
+//
+// By default, if this option is not passed, or if client is nil,
+// http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return fnOpt(func(c config) config {
+		if client != nil {
+			c.client = client
+		}
+		return c
+	})
+}
+
+// WithExpiryDelta sets the duration before their expiry access tokens are
+// refreshed. It prevents tokens from expiring while a request using them is
+// in flight.
+//
+// By default, if this option is not passed, tokens are refreshed 10 seconds
+// before they expire.
+func WithExpiryDelta(d time.Duration) Option {
+	return fnOpt(func(c config) config {
+		c.expiryDelta = d
+		return c
+	})
+}
+
+// WithClientCredentialsInParams sends the client ID and secret as the
+// client_id and client_secret parameters of token requests, instead of using
+// HTTP Basic authentication. It is required by authorization servers not
+// supporting HTTP Basic authentication.
+//
+// By default, if this option is not passed, HTTP Basic authentication is
+// used, as recommended by RFC 6749.
+func WithClientCredentialsInParams() Option {
+	return fnOpt(func(c config) config {
+		c.authInParams = true
+		return c
+	})
+}
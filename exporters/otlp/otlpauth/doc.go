@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlpauth provides authenticators for the OTLP exporters.
+
+An authenticator provides the headers authenticating every request an OTLP
+exporter sends. It is passed to an exporter with its WithAuthenticator option,
+e.g. [go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp.WithAuthenticator].
+Unlike the static headers passed with the WithHeaders options, authenticators
+are called for every request. This allows credentials that expire to be
+refreshed without creating a new exporter.
+
+[ClientCredentials] authenticates requests with a bearer token obtained using
+the OAuth 2.0 client credentials grant. [HeadersFunc] can be used to
+authenticate requests with any other scheme.
+*/
+package otlpauth // import "go.opentelemetry.io/otel/exporters/otlp/otlpauth"
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpauth_test
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpauth"
+)
+
+func ExampleNewClientCredentials() {
+	auth := otlpauth.NewClientCredentials(
+		"https://auth.example.com/oauth2/token",
+		os.Getenv("OTLP_CLIENT_ID"),
+		os.Getenv("OTLP_CLIENT_SECRET"),
+		otlpauth.WithScopes("otlp.write"),
+	)
+
+	// Pass auth to the WithAuthenticator option of an OTLP exporter, e.g.:
+	//
+	//	exp, err := otlptracehttp.New(ctx, otlptracehttp.WithAuthenticator(auth))
+	_ = auth
+}
+
+func ExampleHeadersFunc() {
+	auth := otlpauth.HeadersFunc(func(ctx context.Context) (map[string]string, error) {
+		// Read the API key on every request so it can be rotated.
+		key, err := os.ReadFile("/etc/otel/api-key")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"X-Api-Key": string(key)}, nil
+	})
+
+	// Pass auth to the WithAuthenticator option of an OTLP exporter, e.g.:
+	//
+	//	exp, err := otlploggrpc.New(ctx, otlploggrpc.WithAuthenticator(auth))
+	_ = auth
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpauth // import "go.opentelemetry.io/otel/exporters/otlp/otlpauth"
+
+import "context"
+
+// HeadersFunc is an authenticator returning the headers of a function. It can
+// be used to authenticate requests with credentials that are not supported by
+// this package.
+//
+// The function is called before every request sent by the exporter. It needs
+// to be safe to call concurrently.
+type HeadersFunc func(ctx context.Context) (map[string]string, error)
+
+// Headers returns the headers authenticating a request.
+func (f HeadersFunc) Headers(ctx context.Context) (map[string]string, error) {
+	return f(ctx)
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadersFunc(t *testing.T) {
+	f := HeadersFunc(func(context.Context) (map[string]string, error) {
+		return map[string]string{"X-Api-Key": "key"}, nil
+	})
+	h, err := f.Headers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"X-Api-Key": "key"}, h)
+}
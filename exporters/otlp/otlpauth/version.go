@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpauth // import "go.opentelemetry.io/otel/exporters/otlp/otlpauth"
+
+// Version is the current release version of the OpenTelemetry OTLP exporter authentication in use.
+func Version() string {
+	return "0.50.0"
+}
@@ -0,0 +1,331 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpconn // import "go.opentelemetry.io/otel/exporters/otlp/otlpconn"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpconn/internal/certreload"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpconn/internal/compress"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpconn/internal/envconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpconn/internal/retry"
+	"go.opentelemetry.io/otel/internal/global"
+)
+
+const defaultEndpoint = "localhost:4317"
+
+// envReader reads the OTEL_EXPORTER_OTLP_* environment variables.
+var envReader = envconfig.EnvOptionsReader{
+	GetEnv:    os.Getenv,
+	ReadFile:  os.ReadFile,
+	Namespace: "OTEL_EXPORTER_OTLP",
+}
+
+// Option applies an option to a Conn.
+type Option interface {
+	applyOption(config) config
+}
+
+type fnOpt func(config) config
+
+func (f fnOpt) applyOption(c config) config { return f(c) }
+
+type config struct {
+	endpoint string
+	insecure bool
+	tlsCreds credentials.TransportCredentials
+	// reloadingTLSCfg returns the TLS configuration loaded from the
+	// environment for an endpoint. It is used if tlsCreds is not set.
+	reloadingTLSCfg    func(endpoint string) *tls.Config
+	compression        string
+	reconnectionPeriod time.Duration
+	serviceConfig      string
+	dialOptions        []grpc.DialOption
+
+	// headers, timeout, and retry are passed to the exporters created from
+	// the Conn. They are nil if not set so the exporters use their own
+	// environment variables and defaults.
+	headers map[string]string
+	timeout *time.Duration
+	retry   *retry.Config
+}
+
+func newConfig(options []Option) config {
+	c := loadEnv(config{endpoint: defaultEndpoint}, &envReader)
+	for _, opt := range options {
+		c = opt.applyOption(c)
+	}
+	return c
+}
+
+// loadEnv returns c updated with the connection settings of the
+// OTEL_EXPORTER_OTLP_* environment variables read by e. The signal-specific
+// variables are not read, the connection is shared by all signals.
+func loadEnv(c config, e *envconfig.EnvOptionsReader) config {
+	var files certreload.Files
+	e.Apply(
+		envconfig.WithURL("ENDPOINT", func(u *url.URL) {
+			c.endpoint = path.Join(u.Host, u.Path)
+			c.insecure = insecureScheme(u)
+		}),
+		envconfig.WithBool("INSECURE", func(b bool) { c.insecure = b }),
+		envconfig.WithString("COMPRESSION", func(s string) { c.compression = s }),
+		func(e *envconfig.EnvOptionsReader) {
+			envconfig.WithCertPool("CERTIFICATE", func(*x509.CertPool) {
+				files.CA, _ = e.GetEnvValue("CERTIFICATE")
+			})(e)
+			envconfig.WithClientCert("CLIENT_CERTIFICATE", "CLIENT_KEY", func(tls.Certificate) {
+				files.Cert, _ = e.GetEnvValue("CLIENT_CERTIFICATE")
+				files.Key, _ = e.GetEnvValue("CLIENT_KEY")
+			})(e)
+		},
+	)
+
+	if files.CA != "" || files.Cert != "" {
+		r, err := certreload.New(files, e.ReadFile)
+		if err != nil {
+			global.Error(err, "load tls certificates")
+		} else {
+			c.reloadingTLSCfg = r.TLSConfig
+		}
+	}
+	return c
+}
+
+func insecureScheme(u *url.URL) bool {
+	switch strings.ToLower(u.Scheme) {
+	case "http", "unix":
+		return true
+	default:
+		return false
+	}
+}
+
+// grpcDialOptions returns the options the connection is created with.
+func (c config) grpcDialOptions() []grpc.DialOption {
+	userAgent := "OTel Go OTLP over gRPC shared connection/" + Version()
+	opts := []grpc.DialOption{grpc.WithUserAgent(userAgent)}
+	opts = append(opts, c.dialOptions...)
+
+	if c.serviceConfig != "" {
+		opts = append(opts, grpc.WithDefaultServiceConfig(c.serviceConfig))
+	}
+	// Prioritize TLS credentials over Insecure (passing both is an error).
+	if c.tlsCreds != nil {
+		opts = append(opts, grpc.WithTransportCredentials(c.tlsCreds))
+	} else if c.reloadingTLSCfg != nil {
+		creds := credentials.NewTLS(c.reloadingTLSCfg(c.endpoint))
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else if c.insecure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		// Default to using the host's root CA.
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(nil)))
+	}
+
+	switch c.compression {
+	case "", "none":
+	case "gzip":
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	case compress.ZstdName:
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(compress.ZstdName)))
+	case compress.SnappyName:
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(compress.SnappyName)))
+	default:
+		otel.Handle(fmt.Errorf("invalid compression type: '%s', using no compression as default", c.compression))
+	}
+
+	if c.reconnectionPeriod != 0 {
+		p := grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: c.reconnectionPeriod,
+		}
+		opts = append(opts, grpc.WithConnectParams(p))
+	}
+	return opts
+}
+
+// RetryConfig defines configuration for retrying the export of telemetry
+// that failed to be received by the target endpoint.
+//
+// This configuration does not define any network retry strategy. That is
+// entirely handled by the gRPC ClientConn.
+type RetryConfig retry.Config
+
+// WithInsecure disables client transport security for the connection.
+//
+// If the OTEL_EXPORTER_OTLP_ENDPOINT environment variable is set with the
+// "http" scheme, or the OTEL_EXPORTER_OTLP_INSECURE environment variable is
+// set to true, and this option is not passed, client transport security is
+// disabled.
+//
+// By default, client security is required.
+func WithInsecure() Option {
+	return fnOpt(func(c config) config {
+		c.insecure = true
+		return c
+	})
+}
+
+// WithEndpoint sets the target endpoint (host and port) of the connection.
+// The provided endpoint should resemble "example.com:4317" (no scheme or
+// path).
+//
+// If the OTEL_EXPORTER_OTLP_ENDPOINT environment variable is set, and this
+// option is not passed, that variable value will be used. The
+// signal-specific environment variables, e.g.
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, are not used.
+//
+// If both this option and WithEndpointURL are used, the last used option will
+// take precedence.
+//
+// By default, if the environment variable is not set, and this option is not
+// passed, "localhost:4317" will be used.
+func WithEndpoint(endpoint string) Option {
+	return fnOpt(func(c config) config {
+		c.endpoint = endpoint
+		return c
+	})
+}
+
+// WithEndpointURL sets the target endpoint URL (scheme, host, port, path) of
+// the connection. The provided endpoint URL should resemble
+// "https://example.com:4317". Client transport security is disabled if the
+// scheme is "http".
+//
+// If the OTEL_EXPORTER_OTLP_ENDPOINT environment variable is set, and this
+// option is not passed, that variable value will be used.
+//
+// If both this option and WithEndpoint are used, the last used option will
+// take precedence.
+//
+// If an invalid URL is provided, the default value will be kept.
+//
+// By default, if the environment variable is not set, and this option is not
+// passed, "https://localhost:4317" will be used.
+func WithEndpointURL(rawURL string) Option {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		global.Error(err, "otlpconn: parse endpoint url", "url", rawURL)
+		return fnOpt(func(c config) config { return c })
+	}
+	return fnOpt(func(c config) config {
+		c.endpoint = path.Join(u.Host, u.Path)
+		c.insecure = insecureScheme(u)
+		return c
+	})
+}
+
+// WithTLSCredentials sets the TLS credentials of the connection.
+//
+// If the OTEL_EXPORTER_OTLP_CERTIFICATE, OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE,
+// or OTEL_EXPORTER_OTLP_CLIENT_KEY environment variables are set, and this
+// option is not passed, the credentials are loaded from those files. They are
+// reloaded when the files change.
+//
+// By default, the host's root CA is used.
+func WithTLSCredentials(creds credentials.TransportCredentials) Option {
+	return fnOpt(func(c config) config {
+		c.tlsCreds = creds
+		return c
+	})
+}
+
+// WithCompressor sets the compressor used for all the requests sent on the
+// connection. Supported compressor values: "gzip", "zstd", "snappy".
+//
+// If the OTEL_EXPORTER_OTLP_COMPRESSION environment variable is set, and this
+// option is not passed, that variable value will be used.
+//
+// By default, requests are not compressed.
+func WithCompressor(compressor string) Option {
+	return fnOpt(func(c config) config {
+		c.compression = compressor
+		return c
+	})
+}
+
+// WithReconnectionPeriod set the minimum amount of time between connection
+// attempts to the target endpoint.
+func WithReconnectionPeriod(rp time.Duration) Option {
+	return fnOpt(func(c config) config {
+		c.reconnectionPeriod = rp
+		return c
+	})
+}
+
+// WithServiceConfig defines the default gRPC service config used.
+func WithServiceConfig(serviceConfig string) Option {
+	return fnOpt(func(c config) config {
+		c.serviceConfig = serviceConfig
+		return c
+	})
+}
+
+// WithDialOption sets explicit grpc.DialOptions to use when making the
+// connection. The options here are appended to the internal grpc.DialOptions
+// used so they will take precedence over any other internal grpc.DialOptions
+// they might conflict with.
+func WithDialOption(opts ...grpc.DialOption) Option {
+	return fnOpt(func(c config) config {
+		c.dialOptions = opts
+		return c
+	})
+}
+
+// WithHeaders sets the headers sent with each request of the exporters
+// created from the Conn.
+//
+// By default, if this option is not passed, each exporter uses the headers of
+// the OTEL_EXPORTER_OTLP_HEADERS environment variable or its signal-specific
+// variant.
+func WithHeaders(headers map[string]string) Option {
+	return fnOpt(func(c config) config {
+		c.headers = headers
+		return c
+	})
+}
+
+// WithTimeout sets the max amount of time the exporters created from the
+// Conn will attempt to export a batch of telemetry. This takes precedence
+// over any retry settings defined with WithRetry.
+//
+// By default, if this option is not passed, each exporter uses the timeout of
+// the OTEL_EXPORTER_OTLP_TIMEOUT environment variable or its signal-specific
+// variant, or 10 seconds if they are not set.
+func WithTimeout(duration time.Duration) Option {
+	return fnOpt(func(c config) config {
+		c.timeout = &duration
+		return c
+	})
+}
+
+// WithRetry sets the retry policy of the exporters created from the Conn for
+// transient retryable errors returned by the target endpoint.
+//
+// By default, if this option is not passed, the exporters use their default
+// retry policy. It will retry the export 5 seconds after receiving a
+// retryable error and increase exponentially after each error for no more
+// than a total time of 1 minute.
+func WithRetry(settings RetryConfig) Option {
+	return fnOpt(func(c config) config {
+		rc := retry.Config(settings)
+		c.retry = &rc
+		return c
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpconn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpconn/internal/retry"
+)
+
+func TestNewConfig(t *testing.T) {
+	timeout := 5 * time.Second
+	creds := insecure.NewCredentials()
+
+	testcases := []struct {
+		name    string
+		env     map[string]string
+		options []Option
+		want    config
+	}{
+		{
+			name: "Defaults",
+			want: config{endpoint: defaultEndpoint},
+		},
+		{
+			name: "Options",
+			options: []Option{
+				WithEndpoint("collector:4317"),
+				WithInsecure(),
+				WithTLSCredentials(creds),
+				WithCompressor("gzip"),
+				WithReconnectionPeriod(time.Second),
+				WithServiceConfig("{}"),
+				WithHeaders(map[string]string{"a": "A"}),
+				WithTimeout(timeout),
+				WithRetry(RetryConfig{Enabled: true, MaxElapsedTime: time.Minute}),
+			},
+			want: config{
+				endpoint:           "collector:4317",
+				insecure:           true,
+				tlsCreds:           creds,
+				compression:        "gzip",
+				reconnectionPeriod: time.Second,
+				serviceConfig:      "{}",
+				headers:            map[string]string{"a": "A"},
+				timeout:            &timeout,
+				retry:              &retry.Config{Enabled: true, MaxElapsedTime: time.Minute},
+			},
+		},
+		{
+			name:    "WithEndpointURL",
+			options: []Option{WithEndpointURL("http://collector:4317/prefix")},
+			want: config{
+				endpoint: "collector:4317/prefix",
+				insecure: true,
+			},
+		},
+		{
+			name:    "WithEndpointURL/Invalid",
+			options: []Option{WithEndpointURL("%invalid")},
+			want:    config{endpoint: defaultEndpoint},
+		},
+		{
+			name: "Environment",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT":        "http://env:4317",
+				"OTEL_EXPORTER_OTLP_COMPRESSION":     "zstd",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://traces:4317",
+			},
+			want: config{
+				endpoint:    "env:4317",
+				insecure:    true,
+				compression: "zstd",
+			},
+		},
+		{
+			name: "Environment/Insecure",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "https://env:4317",
+				"OTEL_EXPORTER_OTLP_INSECURE": "true",
+			},
+			want: config{
+				endpoint: "env:4317",
+				insecure: true,
+			},
+		},
+		{
+			name: "Environment/InvalidCertificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE": "/does/not/exist",
+			},
+			want: config{endpoint: defaultEndpoint},
+		},
+		{
+			name: "OptionsOverrideEnvironment",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT":    "http://env:4317",
+				"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd",
+			},
+			options: []Option{
+				WithEndpoint("collector:4317"),
+				WithCompressor("snappy"),
+			},
+			want: config{
+				endpoint:    "collector:4317",
+				insecure:    true,
+				compression: "snappy",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			got := newConfig(tc.options)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGRPCDialOptions(t *testing.T) {
+	base := len(config{}.grpcDialOptions())
+	got := config{
+		compression:        "gzip",
+		reconnectionPeriod: time.Second,
+		serviceConfig:      "{}",
+	}.grpcDialOptions()
+	assert.Len(t, got, base+3)
+
+	// Invalid compressors are ignored.
+	assert.Len(t, config{compression: "invalid"}.grpcDialOptions(), base)
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpconn // import "go.opentelemetry.io/otel/exporters/otlp/otlpconn"
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+)
+
+var errShutdown = errors.New("otlpconn: connection is shutdown")
+
+// Used for testing.
+var newGRPCClientFn = grpc.NewClient
+
+// Conn is a gRPC connection to an OTLP endpoint shared by the trace, metric,
+// and log exporters created from it.
+type Conn struct {
+	cfg     config
+	conn    *grpc.ClientConn
+	stopped atomic.Bool
+}
+
+// New returns a Conn to the endpoint configured by options.
+//
+// The connection is established lazily, when the first request is sent.
+func New(options ...Option) (*Conn, error) {
+	cfg := newConfig(options)
+	conn, err := newGRPCClientFn(cfg.endpoint, cfg.grpcDialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{cfg: cfg, conn: conn}, nil
+}
+
+// ClientConn returns the gRPC connection of c.
+func (c *Conn) ClientConn() *grpc.ClientConn {
+	return c.conn
+}
+
+// NewTraceExporter returns a trace exporter sending spans on c. The headers,
+// timeout, and retry policy of c are used unless overridden by options.
+// Options establishing a connection are ignored.
+//
+// Shutting down the returned exporter does not close c.
+func (c *Conn) NewTraceExporter(ctx context.Context, options ...otlptracegrpc.Option) (*otlptrace.Exporter, error) {
+	if c.stopped.Load() {
+		return nil, errShutdown
+	}
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithGRPCConn(c.conn)}
+	if c.cfg.headers != nil {
+		opts = append(opts, otlptracegrpc.WithHeaders(c.cfg.headers))
+	}
+	if c.cfg.timeout != nil {
+		opts = append(opts, otlptracegrpc.WithTimeout(*c.cfg.timeout))
+	}
+	if c.cfg.retry != nil {
+		opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig(*c.cfg.retry)))
+	}
+	return otlptracegrpc.New(ctx, append(opts, options...)...)
+}
+
+// NewMetricExporter returns a metric exporter sending metric data on c. The
+// headers, timeout, and retry policy of c are used unless overridden by
+// options. Options establishing a connection are ignored.
+//
+// Shutting down the returned exporter does not close c.
+func (c *Conn) NewMetricExporter(ctx context.Context, options ...otlpmetricgrpc.Option) (*otlpmetricgrpc.Exporter, error) {
+	if c.stopped.Load() {
+		return nil, errShutdown
+	}
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithGRPCConn(c.conn)}
+	if c.cfg.headers != nil {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(c.cfg.headers))
+	}
+	if c.cfg.timeout != nil {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(*c.cfg.timeout))
+	}
+	if c.cfg.retry != nil {
+		opts = append(opts, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig(*c.cfg.retry)))
+	}
+	return otlpmetricgrpc.New(ctx, append(opts, options...)...)
+}
+
+// NewLogExporter returns a log exporter sending log records on c. The
+// headers, timeout, and retry policy of c are used unless overridden by
+// options. Options establishing a connection are ignored.
+//
+// Shutting down the returned exporter does not close c.
+func (c *Conn) NewLogExporter(ctx context.Context, options ...otlploggrpc.Option) (*otlploggrpc.Exporter, error) {
+	if c.stopped.Load() {
+		return nil, errShutdown
+	}
+	opts := []otlploggrpc.Option{otlploggrpc.WithGRPCConn(c.conn)}
+	if c.cfg.headers != nil {
+		opts = append(opts, otlploggrpc.WithHeaders(c.cfg.headers))
+	}
+	if c.cfg.timeout != nil {
+		opts = append(opts, otlploggrpc.WithTimeout(*c.cfg.timeout))
+	}
+	if c.cfg.retry != nil {
+		opts = append(opts, otlploggrpc.WithRetry(otlploggrpc.RetryConfig(*c.cfg.retry)))
+	}
+	return otlploggrpc.New(ctx, append(opts, options...)...)
+}
+
+// Shutdown closes the connection. The exporters created from c can no
+// longer export once it is closed, the providers using them need to be shut
+// down first so their pending telemetry is exported.
+//
+// Shutdown can be called multiple times, only the first call closes the
+// connection.
+func (c *Conn) Shutdown(ctx context.Context) error {
+	if c.stopped.Swap(true) {
+		return nil
+	}
+	if err := c.conn.Close(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpconn
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// countingListener counts the connections it accepts.
+type countingListener struct {
+	net.Listener
+	accepted atomic.Int64
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+	return c, err
+}
+
+// collector is an OTLP gRPC server receiving all signals. It records the
+// "x-signal" header of the requests it receives.
+type collector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	listener *countingListener
+	srv      *grpc.Server
+
+	mu      sync.Mutex
+	headers map[string][]string
+}
+
+func newCollector(t *testing.T) *collector {
+	t.Helper()
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	c := &collector{
+		listener: &countingListener{Listener: ln},
+		srv:      grpc.NewServer(),
+		headers:  make(map[string][]string),
+	}
+	coltracepb.RegisterTraceServiceServer(c.srv, c)
+	colmetricpb.RegisterMetricsServiceServer(c.srv, metricsServer{collector: c})
+	collogpb.RegisterLogsServiceServer(c.srv, logsServer{collector: c})
+	go func() { _ = c.srv.Serve(c.listener) }()
+	t.Cleanup(c.srv.Stop)
+	return c
+}
+
+func (c *collector) record(ctx context.Context, signal string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.headers[signal] = append(c.headers[signal], md.Get("x-signal")...)
+}
+
+func (c *collector) Headers() map[string][]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string][]string, len(c.headers))
+	for k, v := range c.headers {
+		out[k] = append([]string(nil), v...)
+	}
+	return out
+}
+
+func (c *collector) Export(ctx context.Context, _ *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.record(ctx, "traces")
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// metricsServer and logsServer are the metrics and logs services of the
+// collector.
+type metricsServer struct {
+	colmetricpb.UnimplementedMetricsServiceServer
+	*collector
+}
+
+func (s metricsServer) Export(ctx context.Context, _ *colmetricpb.ExportMetricsServiceRequest) (*colmetricpb.ExportMetricsServiceResponse, error) {
+	s.record(ctx, "metrics")
+	return &colmetricpb.ExportMetricsServiceResponse{}, nil
+}
+
+type logsServer struct {
+	collogpb.UnimplementedLogsServiceServer
+	*collector
+}
+
+func (s logsServer) Export(ctx context.Context, _ *collogpb.ExportLogsServiceRequest) (*collogpb.ExportLogsServiceResponse, error) {
+	s.record(ctx, "logs")
+	return &collogpb.ExportLogsServiceResponse{}, nil
+}
+
+func TestConn(t *testing.T) {
+	coll := newCollector(t)
+	conn, err := New(
+		WithEndpoint(coll.listener.Addr().String()),
+		WithInsecure(),
+		WithHeaders(map[string]string{"x-signal": "shared"}),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	traceExp, err := conn.NewTraceExporter(ctx)
+	require.NoError(t, err)
+	metricExp, err := conn.NewMetricExporter(ctx)
+	require.NoError(t, err)
+	logExp, err := conn.NewLogExporter(ctx, otlploggrpc.WithHeaders(map[string]string{"x-signal": "logs"}))
+	require.NoError(t, err)
+
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	require.NoError(t, traceExp.ExportSpans(ctx, spans))
+	require.NoError(t, metricExp.Export(ctx, &metricdata.ResourceMetrics{}))
+	var r sdklog.Record
+	r.SetBody(log.StringValue("log"))
+	require.NoError(t, logExp.Export(ctx, []sdklog.Record{r}))
+
+	assert.Equal(t, map[string][]string{
+		"traces":  {"shared"},
+		"metrics": {"shared"},
+		"logs":    {"logs"},
+	}, coll.Headers(), "exporter options take precedence")
+	assert.Equal(t, int64(1), coll.listener.accepted.Load(), "connections")
+
+	require.NoError(t, traceExp.Shutdown(ctx))
+	require.NoError(t, metricExp.Shutdown(ctx))
+	require.NoError(t, logExp.Shutdown(ctx))
+	assert.NotEqual(t, connectivity.Shutdown, conn.ClientConn().GetState(), "exporters do not close the connection")
+
+	require.NoError(t, conn.Shutdown(ctx))
+	assert.Equal(t, connectivity.Shutdown, conn.ClientConn().GetState())
+	assert.NoError(t, conn.Shutdown(ctx), "second shutdown")
+
+	_, err = conn.NewTraceExporter(ctx)
+	assert.ErrorIs(t, err, errShutdown)
+	_, err = conn.NewMetricExporter(ctx)
+	assert.ErrorIs(t, err, errShutdown)
+	_, err = conn.NewLogExporter(ctx)
+	assert.ErrorIs(t, err, errShutdown)
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlpconn provides a gRPC connection to an OTLP endpoint shared by the
+trace, metric, and log exporters.
+
+A [Conn] is created once with the endpoint, TLS, compression, headers,
+timeout, and retry settings of all the exporters. The
+[Conn.NewTraceExporter], [Conn.NewMetricExporter], and [Conn.NewLogExporter]
+methods create exporters sending their telemetry on the connection instead
+of dialing their own. [Conn.Shutdown] closes the connection once the
+providers using the exporters are shut down.
+
+# Environment variables
+
+The following environment variables can be used (instead of options) to
+configure the connection:
+
+  - OTEL_EXPORTER_OTLP_ENDPOINT (default: "https://localhost:4317") -
+    target to which the connection is made. The host and path of the URL are
+    used as the gRPC target. Client transport security is disabled if the
+    scheme is "http".
+  - OTEL_EXPORTER_OTLP_INSECURE (default: "false") -
+    setting "true" disables client transport security.
+  - OTEL_EXPORTER_OTLP_COMPRESSION (default: none) -
+    the compression used for all the requests. Supported values: "gzip",
+    "zstd", "snappy".
+  - OTEL_EXPORTER_OTLP_CERTIFICATE (default: none) -
+    the filepath to the trusted certificate to use when verifying a server's
+    TLS credentials.
+  - OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE and OTEL_EXPORTER_OTLP_CLIENT_KEY
+    (default: none) - the filepaths to the client certificate and its private
+    key to use in mTLS communication.
+
+The certificates are reloaded when their files change. The signal-specific
+variants of these variables, e.g. OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, are not
+used as the connection is shared by all signals. The headers and timeout
+environment variables, including their signal-specific variants, are read by
+each exporter when [WithHeaders] and [WithTimeout] are not used.
+*/
+package otlpconn // import "go.opentelemetry.io/otel/exporters/otlp/otlpconn"
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpconn_test
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpconn"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func Example() {
+	ctx := context.Background()
+	conn, err := otlpconn.New(
+		otlpconn.WithEndpoint("collector:4317"),
+		otlpconn.WithCompressor("gzip"),
+		otlpconn.WithHeaders(map[string]string{"X-Tenant": "example"}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		// Close the connection once all providers are shut down.
+		if err := conn.Shutdown(ctx); err != nil {
+			otel.Handle(err)
+		}
+	}()
+
+	traceExp, err := conn.NewTraceExporter(ctx)
+	if err != nil {
+		panic(err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExp))
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			otel.Handle(err)
+		}
+	}()
+	otel.SetTracerProvider(tp)
+
+	metricExp, err := conn.NewMetricExporter(ctx)
+	if err != nil {
+		panic(err)
+	}
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExp)))
+	defer func() {
+		if err := mp.Shutdown(ctx); err != nil {
+			otel.Handle(err)
+		}
+	}()
+	otel.SetMeterProvider(mp)
+
+	logExp, err := conn.NewLogExporter(ctx)
+	if err != nil {
+		panic(err)
+	}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(logExp)))
+	defer func() {
+		if err := lp.Shutdown(ctx); err != nil {
+			otel.Handle(err)
+		}
+	}()
+	global.SetLoggerProvider(lp)
+}
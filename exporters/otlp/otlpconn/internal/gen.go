@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlpconn/internal"
+
+//go:generate gotmpl --body=../../../../internal/shared/otlp/certreload/certreload.go.tmpl "--data={}" --out=certreload/certreload.go
+//go:generate gotmpl --body=../../../../internal/shared/otlp/certreload/certreload_test.go.tmpl "--data={}" --out=certreload/certreload_test.go
+
+//go:generate gotmpl --body=../../../../internal/shared/otlp/compress/compress.go.tmpl "--data={}" --out=compress/compress.go
+//go:generate gotmpl --body=../../../../internal/shared/otlp/compress/compress_test.go.tmpl "--data={}" --out=compress/compress_test.go
+//go:generate gotmpl --body=../../../../internal/shared/otlp/compress/grpc.go.tmpl "--data={}" --out=compress/grpc.go
+//go:generate gotmpl --body=../../../../internal/shared/otlp/compress/grpc_test.go.tmpl "--data={}" --out=compress/grpc_test.go
+
+//go:generate gotmpl --body=../../../../internal/shared/otlp/envconfig/envconfig.go.tmpl "--data={}" --out=envconfig/envconfig.go
+//go:generate gotmpl --body=../../../../internal/shared/otlp/envconfig/envconfig_test.go.tmpl "--data={}" --out=envconfig/envconfig_test.go
+
+//go:generate gotmpl --body=../../../../internal/shared/otlp/retry/retry.go.tmpl "--data={}" --out=retry/retry.go
+//go:generate gotmpl --body=../../../../internal/shared/otlp/retry/retry_test.go.tmpl "--data={}" --out=retry/retry_test.go
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpconn // import "go.opentelemetry.io/otel/exporters/otlp/otlpconn"
+
+// Version is the current release version of the OpenTelemetry OTLP shared connection in use.
+func Version() string {
+	return "0.50.0"
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlplogfile // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/filewriter"
+)
+
+// defaultPath is the default path of the file written to.
+const defaultPath = "logs.jsonl"
+
+// Option applies an option to the exporter.
+type Option interface {
+	applyFileOption(config) config
+}
+
+type fnOpt func(config) config
+
+func (f fnOpt) applyFileOption(c config) config { return f(c) }
+
+type config struct {
+	path        string
+	maxSize     int64
+	interval    time.Duration
+	compression Compression
+}
+
+func newConfig(options []Option) config {
+	c := config{path: defaultPath}
+	for _, opt := range options {
+		c = opt.applyFileOption(c)
+	}
+	return c
+}
+
+// writerConfig returns the configuration of the file writer used by the
+// exporter.
+func (c config) writerConfig() filewriter.Config {
+	return filewriter.Config{
+		Path:     c.path,
+		MaxSize:  c.maxSize,
+		Interval: c.interval,
+		Gzip:     c.compression == GzipCompression,
+	}
+}
+
+// WithPath sets the path of the file the exporter writes to. The file is
+// created if it does not exist, otherwise it is appended to. Missing parent
+// directories are created.
+//
+// By default, if this option is not passed, "logs.jsonl" is used.
+func WithPath(path string) Option {
+	return fnOpt(func(c config) config {
+		c.path = path
+		return c
+	})
+}
+
+// WithMaxSize sets the number of bytes written to a file before it is rotated.
+// The size is measured before compression. A value of zero or less disables
+// size based rotation.
+//
+// When rotated, the file is renamed by adding the rotation time before its
+// extension, e.g. "logs-20240701T120000.000000000.jsonl", and a new file is
+// created at the configured path.
+//
+// By default, if this option is not passed, files are not rotated based on
+// their size.
+func WithMaxSize(bytes int64) Option {
+	return fnOpt(func(c config) config {
+		c.maxSize = bytes
+		return c
+	})
+}
+
+// WithRotationInterval sets the duration a file is written to before it is
+// rotated. A value of zero or less disables time based rotation.
+//
+// See [WithMaxSize] for how rotated files are named.
+//
+// By default, if this option is not passed, files are not rotated based on
+// time.
+func WithRotationInterval(d time.Duration) Option {
+	return fnOpt(func(c config) config {
+		c.interval = d
+		return c
+	})
+}
+
+// Compression describes the compression used for written files.
+type Compression int
+
+const (
+	// NoCompression represents that no compression should be used.
+	NoCompression Compression = iota
+	// GzipCompression represents that gzip compression should be used.
+	GzipCompression
+)
+
+// WithCompression sets the compression strategy the exporter will use to
+// compress the written files. Compressed files can be appended to across
+// restarts of the exporter.
+//
+// By default, if this option is not passed, no compression strategy will be
+// used.
+func WithCompression(compression Compression) Option {
+	return fnOpt(func(c config) config {
+		c.compression = compression
+		return c
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlplogfile provides an OTLP log exporter writing to local files.
+
+The log records are written in the OTLP JSON Lines file format: each export
+is written as an OTLP/JSON encoded ExportLogsServiceRequest on its own line.
+The written files can be read by the OpenTelemetry Collector otlpjsonfile
+receiver.
+
+By default the log records are written to the "logs.jsonl" file of the
+current working directory. The file can be rotated based on its size, see
+[WithMaxSize], or on time, see [WithRotationInterval], and can be compressed,
+see [WithCompression].
+
+Exporter should be created using [New].
+*/
+package otlplogfile // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile"
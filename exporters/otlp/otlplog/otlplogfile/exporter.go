@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlplogfile // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/filewriter"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal/transform"
+	"go.opentelemetry.io/otel/sdk/log"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+)
+
+// Exporter is a OpenTelemetry log Exporter. It writes log records as OTLP
+// JSON Lines to a file.
+// Exporter must be created with [New].
+type Exporter struct {
+	mu     sync.Mutex
+	writer *filewriter.Writer
+}
+
+// Compile-time check Exporter implements [log.Exporter].
+var _ log.Exporter = (*Exporter)(nil)
+
+// New returns a new [Exporter]. Each export is written as an OTLP/JSON
+// encoded ExportLogsServiceRequest on its own line of the file.
+//
+// An error is returned if the file cannot be opened.
+//
+// It is recommended to use it with a [BatchProcessor]
+// or other processor exporting records asynchronously.
+//
+// [BatchProcessor]: https://pkg.go.dev/go.opentelemetry.io/otel/sdk/log#BatchProcessor
+func New(_ context.Context, options ...Option) (*Exporter, error) {
+	cfg := newConfig(options)
+	w, err := filewriter.New(cfg.writerConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{writer: w}, nil
+}
+
+// Used for testing.
+var transformResourceLogs = transform.ResourceLogs
+
+// Export transforms and writes log records to the file.
+func (e *Exporter) Export(ctx context.Context, records []log.Record) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.writer == nil {
+		return nil
+	}
+
+	otlp := transformResourceLogs(records)
+	if otlp == nil {
+		return nil
+	}
+	b, err := internal.MarshalJSON(&collogpb.ExportLogsServiceRequest{
+		ResourceLogs: otlp,
+	})
+	if err != nil {
+		return err
+	}
+	return e.writer.WriteLine(b)
+}
+
+// Shutdown closes the file written to. Calls to Export or ForceFlush will
+// perform no operation after this is called.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.writer == nil {
+		return nil
+	}
+	err := e.writer.Close()
+	e.writer = nil
+	return err
+}
+
+// ForceFlush does nothing. All exported records are written to the file by
+// Export.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlplogfile
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal"
+	api "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+	"go.opentelemetry.io/otel/trace"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+)
+
+// readRequests returns the requests written to the file at path.
+func readRequests(t *testing.T, path string, gz bool) []*collogpb.ExportLogsServiceRequest {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var r io.Reader = f
+	if gz {
+		zr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		r = zr
+	}
+
+	var out []*collogpb.ExportLogsServiceRequest
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		req := new(collogpb.ExportLogsServiceRequest)
+		require.NoError(t, internal.UnmarshalJSON(scanner.Bytes(), req))
+		out = append(out, req)
+	}
+	require.NoError(t, scanner.Err())
+	return out
+}
+
+var records = []log.Record{
+	logtest.RecordFactory{
+		Body:    api.StringValue("first"),
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+	}.NewRecord(),
+	logtest.RecordFactory{Body: api.StringValue("second")}.NewRecord(),
+}
+
+func TestExporter(t *testing.T) {
+	for name, compression := range map[string]Compression{
+		"NoCompression":   NoCompression,
+		"GzipCompression": GzipCompression,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "logs.jsonl")
+			e, err := New(ctx, WithPath(path), WithCompression(compression))
+			require.NoError(t, err)
+
+			require.NoError(t, e.Export(ctx, records[:1]))
+			require.NoError(t, e.Export(ctx, nil))
+			require.NoError(t, e.Export(ctx, records[1:]))
+			require.NoError(t, e.ForceFlush(ctx))
+			require.NoError(t, e.Shutdown(ctx))
+
+			reqs := readRequests(t, path, compression == GzipCompression)
+			require.Len(t, reqs, 2, "one line per non-empty export")
+			for i, want := range []string{"first", "second"} {
+				lr := reqs[i].GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()[0]
+				assert.Equal(t, want, lr.GetBody().GetStringValue())
+			}
+
+			lr := reqs[0].GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()[0]
+			tID, sID := records[0].TraceID(), records[0].SpanID()
+			assert.Equal(t, tID[:], lr.GetTraceId())
+			assert.Equal(t, sID[:], lr.GetSpanId())
+		})
+	}
+}
+
+func TestExporterShutdown(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "logs.jsonl")
+	e, err := New(ctx, WithPath(path))
+	require.NoError(t, err, "New")
+	assert.NoError(t, e.Shutdown(ctx), "Shutdown Exporter")
+
+	// After Shutdown is called, calls to Export, Shutdown, or ForceFlush
+	// should perform no operation and return nil error.
+	assert.NoError(t, e.Export(ctx, records), "Export on Shutdown Exporter")
+	assert.NoError(t, e.ForceFlush(ctx), "ForceFlush on Shutdown Exporter")
+	assert.NoError(t, e.Shutdown(ctx), "Shutdown on Shutdown Exporter")
+
+	assert.Empty(t, readRequests(t, path, false))
+}
+
+func TestExporterExportCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	e, err := New(ctx, WithPath(filepath.Join(t.TempDir(), "logs.jsonl")))
+	require.NoError(t, err, "New")
+	t.Cleanup(func() { require.NoError(t, e.Shutdown(context.Background())) })
+
+	assert.ErrorIs(t, e.Export(ctx, records), context.Canceled)
+}
+
+func TestExporterRotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	e, err := New(ctx, WithPath(filepath.Join(dir, "logs.jsonl")), WithMaxSize(1))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, e.Export(ctx, records))
+	}
+	require.NoError(t, e.Shutdown(ctx))
+
+	files, err := filepath.Glob(filepath.Join(dir, "logs*.jsonl"))
+	require.NoError(t, err)
+	assert.Len(t, files, 3, "one file per export")
+}
+
+func TestExporterConcurrentSafe(t *testing.T) {
+	const goroutines = 10
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "logs.jsonl")
+	e, err := New(ctx, WithPath(path))
+	require.NoError(t, err, "New")
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, e.Export(ctx, records))
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, e.Shutdown(ctx))
+
+	assert.Len(t, readRequests(t, path, false), goroutines)
+}
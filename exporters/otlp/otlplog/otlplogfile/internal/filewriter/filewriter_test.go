@@ -0,0 +1,198 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/filewriter/filewriter_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filewriter
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, path string, gz bool) string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var r io.Reader = f
+	if gz {
+		zr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		r = zr
+	}
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
+
+// backups returns the rotated files in dir in rotation order.
+func backups(t *testing.T, dir, current string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var out []string
+	for _, e := range entries {
+		if e.Name() != current {
+			out = append(out, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "traces.jsonl")
+	w, err := New(Config{Path: path})
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteLine([]byte(`{"a":1}`)))
+	require.NoError(t, w.WriteLine([]byte(`{"b":2}`)))
+	assert.Equal(t, "{\"a\":1}\n{\"b\":2}\n", readFile(t, path, false))
+
+	require.NoError(t, w.Close())
+	assert.ErrorIs(t, w.WriteLine([]byte(`{}`)), errClosed)
+	assert.NoError(t, w.Close(), "idempotent close")
+
+	// Existing files are appended to.
+	w, err = New(Config{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteLine([]byte(`{"c":3}`)))
+	require.NoError(t, w.Close())
+	assert.Equal(t, "{\"a\":1}\n{\"b\":2}\n{\"c\":3}\n", readFile(t, path, false))
+}
+
+func TestWriterEmptyPath(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
+
+func TestWriterRotateSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs.jsonl")
+	w, err := New(Config{Path: path, MaxSize: 10})
+	require.NoError(t, err)
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	w.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+
+	require.NoError(t, w.WriteLine([]byte("1234")))
+	require.NoError(t, w.WriteLine([]byte("5678")))
+	// Exceeds the size, written to a new file.
+	require.NoError(t, w.WriteLine([]byte("9")))
+	// Larger than the size, still written.
+	require.NoError(t, w.WriteLine([]byte("0123456789")))
+	require.NoError(t, w.Close())
+
+	b := backups(t, dir, "logs.jsonl")
+	require.Len(t, b, 2)
+	assert.Equal(t, filepath.Join(dir, "logs-20240701T120000.001000000.jsonl"), b[0])
+	assert.Equal(t, "1234\n5678\n", readFile(t, b[0], false))
+	assert.Equal(t, "9\n", readFile(t, b[1], false))
+	assert.Equal(t, "0123456789\n", readFile(t, path, false))
+}
+
+func TestWriterRotateInterval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "metrics.jsonl.gz")
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	w, err := New(Config{Path: path, Interval: time.Minute, Gzip: true})
+	require.NoError(t, err)
+	w.now = func() time.Time { return now }
+	w.opened = now
+
+	require.NoError(t, w.WriteLine([]byte("first")))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, w.WriteLine([]byte("second")))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, w.WriteLine([]byte("third")))
+	require.NoError(t, w.Close())
+
+	b := backups(t, dir, "metrics.jsonl.gz")
+	require.Len(t, b, 1)
+	assert.Equal(t, filepath.Join(dir, "metrics-20240701T120100.000000000.jsonl.gz"), b[0])
+	assert.Equal(t, "first\nsecond\n", readFile(t, b[0], true))
+	assert.Equal(t, "third\n", readFile(t, path, true))
+}
+
+func TestWriterGzipAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl.gz")
+	for _, line := range []string{"one", "two"} {
+		w, err := New(Config{Path: path, Gzip: true})
+		require.NoError(t, err)
+		require.NoError(t, w.WriteLine([]byte(line)))
+		require.NoError(t, w.Close())
+	}
+	assert.Equal(t, "one\ntwo\n", readFile(t, path, true))
+}
+
+func TestWriterGzipReopenRotateSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs.jsonl.gz")
+	w, err := New(Config{Path: path, Gzip: true})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteLine([]byte("one")))
+	require.NoError(t, w.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// The existing file is counted, the next line exceeds the size.
+	w, err = New(Config{Path: path, MaxSize: info.Size() + 1, Gzip: true})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteLine([]byte("two")))
+	require.NoError(t, w.Close())
+
+	b := backups(t, dir, "logs.jsonl.gz")
+	require.Len(t, b, 1)
+	assert.Equal(t, "one\n", readFile(t, b[0], true))
+	assert.Equal(t, "two\n", readFile(t, path, true))
+}
+
+func TestWriterGzipReadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl.gz")
+	w, err := New(Config{Path: path, Gzip: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, w.Close()) })
+
+	require.NoError(t, w.WriteLine([]byte("line")))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	// The stream is not terminated, read what is available.
+	b := make([]byte, 5)
+	_, err = io.ReadFull(zr, b)
+	require.NoError(t, err)
+	assert.Equal(t, "line\n", string(b))
+}
+
+func TestBackupName(t *testing.T) {
+	ts := time.Date(2024, 7, 1, 12, 0, 0, 0, time.FixedZone("", 3600))
+	testcases := map[string]string{
+		"traces":                "traces-20240701T110000.000000000",
+		"traces.jsonl":          "traces-20240701T110000.000000000.jsonl",
+		"traces.jsonl.gz":       "traces-20240701T110000.000000000.jsonl.gz",
+		"/tmp/dir.d/traces.gz":  "/tmp/dir.d/traces-20240701T110000.000000000.gz",
+		"/tmp/dir.d/traces.log": "/tmp/dir.d/traces-20240701T110000.000000000.log",
+	}
+	for path, want := range testcases {
+		assert.Equal(t, filepath.FromSlash(want), backupName(filepath.FromSlash(path), ts), path)
+	}
+}
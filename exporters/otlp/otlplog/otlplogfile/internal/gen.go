@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile/internal"
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/json.go.tmpl "--data={}" --out=json.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/json_test.go.tmpl "--data={}" --out=json_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/filewriter.go.tmpl "--data={}" --out=filewriter/filewriter.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/filewriter_test.go.tmpl "--data={}" --out=filewriter/filewriter_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/attr_test.go.tmpl "--data={}" --out=transform/attr_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log.go.tmpl "--data={}" --out=transform/log.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log_attr_test.go.tmpl "--data={}" --out=transform/log_attr_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log_test.go.tmpl "--data={}" --out=transform/log_test.go
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlplogfile // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlplogfile"
+
+// Version is the current release version of the OpenTelemetry OTLP file logs exporter in use.
+func Version() string {
+	return "0.4.0"
+}
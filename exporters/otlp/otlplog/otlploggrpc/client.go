@@ -5,7 +5,9 @@ package otlploggrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/o
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -17,9 +19,15 @@ import (
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/compress"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/observ"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/split"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
 	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
 )
@@ -29,6 +37,17 @@ type client struct {
 	metadata      metadata.MD
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
+	// callOpts are the options of every export call.
+	callOpts []grpc.CallOption
+	// limits are the limits uploads are split to be within.
+	limits split.Limits
+	// inst records the telemetry of the client. It is nil if no
+	// MeterProvider is configured.
+	inst *observ.Instrumentation
+	// measurePayload is true if the payload sizes are measured by the
+	// client. The stats handler of inst cannot be added to a connection
+	// passed with WithGRPCConn, only the size before compression is known.
+	measurePayload bool
 
 	// ourConn keeps track of where conn was created: true if created here in
 	// NewClient, or false if passed with an option. This is important on
@@ -46,18 +65,37 @@ var newGRPCClientFn = grpc.NewClient
 func newClient(cfg config) (*client, error) {
 	c := &client{
 		exportTimeout: cfg.timeout.Value,
-		requestFunc:   cfg.retryCfg.Value.RequestFunc(retryable),
+		requestFunc:   cfg.retryCfg.Value.RequestFunc(retryableCodes(cfg.retryableCodes.Value)),
 		conn:          cfg.gRPCConn.Value,
+		limits: split.Limits{
+			Bytes: cfg.maxRequestSize.Value,
+			Items: cfg.maxRequestItems.Value,
+		},
+		inst: observ.New(
+			cfg.meterProvider.Value,
+			"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc",
+			Version(),
+			observ.LogRecords,
+			"otlp_grpc_log_exporter",
+			cfg.endpoint.Value,
+		),
 	}
 
 	if len(cfg.headers.Value) > 0 {
 		c.metadata = metadata.New(cfg.headers.Value)
 	}
+	if creds := auth.PerRPCCredentials(cfg.authenticator.Value); creds != nil {
+		c.callOpts = append(c.callOpts, grpc.PerRPCCredentials(creds))
+	}
 
+	c.measurePayload = c.inst != nil && c.conn != nil
 	if c.conn == nil {
 		// If the caller did not provide a ClientConn when the client was
 		// created, create one using the configuration they did provide.
 		dialOpts := newGRPCDialOptions(cfg)
+		if c.inst != nil {
+			dialOpts = append(dialOpts, grpc.WithStatsHandler(c.inst.StatsHandler()))
+		}
 
 		conn, err := newGRPCClientFn(cfg.endpoint.Value, dialOpts...)
 		if err != nil {
@@ -96,8 +134,13 @@ func newGRPCDialOptions(cfg config) []grpc.DialOption {
 		))
 	}
 	// Compression
-	if cfg.compression.Value == GzipCompression {
+	switch cfg.compression.Value {
+	case GzipCompression:
 		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	case ZstdCompression:
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(compress.ZstdName)))
+	case SnappyCompression:
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(compress.SnappyName)))
 	}
 	// Reconnection period
 	if cfg.reconnectionPeriod.Value != 0 {
@@ -111,7 +154,8 @@ func newGRPCDialOptions(cfg config) []grpc.DialOption {
 	return dialOpts
 }
 
-// UploadLogs sends proto logs to connected endpoint.
+// UploadLogs sends proto logs to connected endpoint. They are split into
+// several requests if they exceed the configured request limits.
 //
 // Retryable errors from the server will be handled according to any
 // RetryConfig the client was created with.
@@ -120,6 +164,15 @@ func newGRPCDialOptions(cfg config) []grpc.DialOption {
 // ensures this is not called after the Exporter is shutdown. Only thing
 // to do here is send data.
 func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error {
+	var err error
+	for _, part := range split.Logs(rl, c.limits) {
+		err = errors.Join(err, c.uploadLogs(ctx, part))
+	}
+	return err
+}
+
+// uploadLogs sends rl to the connected endpoint in a single request.
+func (c *client) uploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error {
 	select {
 	case <-ctx.Done():
 		// Do not upload if the context is already expired.
@@ -130,14 +183,20 @@ func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(ctx context.Context) error {
-		resp, err := c.lsc.Export(ctx, &collogpb.ExportLogsServiceRequest{
-			ResourceLogs: rl,
-		})
+	start := time.Now()
+	var attempts, rejected int64
+	req := &collogpb.ExportLogsServiceRequest{ResourceLogs: rl}
+	err := c.requestFunc(ctx, func(ctx context.Context) error {
+		attempts++
+		if c.measurePayload {
+			c.inst.RecordUncompressedPayload(ctx, proto.Size(req))
+		}
+		resp, err := c.lsc.Export(ctx, req, c.callOpts...)
 		if resp != nil && resp.PartialSuccess != nil {
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedLogRecords()
 			if n != 0 || msg != "" {
+				rejected = n
 				err := fmt.Errorf("OTLP partial success: %s (%d log records rejected)", msg, n)
 				otel.Handle(err)
 			}
@@ -149,6 +208,35 @@ func (c *client) UploadLogs(ctx context.Context, rl []*logpb.ResourceLogs) error
 		}
 		return err
 	})
+	c.recordExport(ctx, start, rl, attempts, rejected, err)
+	return err
+}
+
+// recordExport records the telemetry of an export of rl that started at start
+// and was attempted attempts times.
+func (c *client) recordExport(ctx context.Context, start time.Time, rl []*logpb.ResourceLogs, attempts, rejected int64, err error) {
+	if c.inst == nil {
+		return
+	}
+	c.inst.RecordRetries(ctx, attempts-1)
+
+	code := status.Code(err)
+	var errType string
+	if err != nil {
+		errType = code.String()
+	}
+	c.inst.RecordExport(ctx, start, logRecordCount(rl), rejected, errType, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+}
+
+// logRecordCount returns the number of log records in rl.
+func logRecordCount(rl []*logpb.ResourceLogs) int64 {
+	var n int
+	for _, r := range rl {
+		for _, sl := range r.GetScopeLogs() {
+			n += len(sl.GetLogRecords())
+		}
+	}
+	return int64(n)
 }
 
 // Shutdown shuts down the client, freeing all resources.
@@ -241,6 +329,24 @@ func retryableGRPCStatus(s *status.Status) (bool, time.Duration) {
 	return false, 0
 }
 
+// retryableCodes returns a function returning if err identifies a request
+// that can be retried, and a duration to wait for if an explicit throttle
+// time is included in err. Only errors with one of the codes cs are
+// retried. The default retryable codes are used if cs is nil.
+func retryableCodes(cs []codes.Code) retry.EvaluateFunc {
+	if cs == nil {
+		return retryable
+	}
+	return func(err error) (bool, time.Duration) {
+		s := status.Convert(err)
+		if !slices.Contains(cs, s.Code()) {
+			return false, 0
+		}
+		_, d := throttleDelay(s)
+		return true, d
+	}
+}
+
 // throttleDelay returns if the status is RetryInfo
 // and the duration to wait for if an explicit throttle time is included.
 func throttleDelay(s *status.Status) (bool, time.Duration) {
@@ -5,9 +5,11 @@ package otlploggrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/o
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -25,6 +27,8 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
 	cpb "go.opentelemetry.io/proto/otlp/common/v1"
@@ -244,6 +248,27 @@ func TestRetryable(t *testing.T) {
 	}
 }
 
+func TestRetryableCodes(t *testing.T) {
+	evaluate := retryableCodes([]codes.Code{codes.Internal, codes.ResourceExhausted})
+
+	ok, _ := evaluate(status.Error(codes.Internal, ""))
+	assert.True(t, ok, "configured code")
+	ok, _ = evaluate(status.Error(codes.Unavailable, ""))
+	assert.False(t, ok, "default code not configured")
+
+	delay := 15 * time.Millisecond
+	s, err := status.New(codes.ResourceExhausted, "WithRetryInfo").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)},
+	)
+	require.NoError(t, err)
+	ok, d := evaluate(s.Err())
+	assert.True(t, ok, "configured code with RetryInfo")
+	assert.Equal(t, delay, d)
+
+	ok, _ = retryableCodes(nil)(status.Error(codes.Unavailable, ""))
+	assert.True(t, ok, "default codes")
+}
+
 func TestRetryableGRPCStatusResourceExhaustedWithRetryInfo(t *testing.T) {
 	delay := 15 * time.Millisecond
 	s, err := status.New(codes.ResourceExhausted, "WithRetryInfo").WithDetails(
@@ -445,18 +470,26 @@ func (c *grpcCollector) Export(ctx context.Context, req *collogpb.ExportLogsServ
 	return &collogpb.ExportLogsServiceResponse{}, nil
 }
 
+// Headers returns the headers received for all requests.
+func (c *grpcCollector) Headers() map[string][]string {
+	// Makes a copy.
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
+	return metadata.Join(c.headers)
+}
+
 // Collect returns the Storage holding all collected requests.
 func (c *grpcCollector) Collect() *storage {
 	return c.storage
 }
 
-func clientFactory(t *testing.T, rCh <-chan exportResult) (*client, *grpcCollector) {
+func clientFactory(t *testing.T, rCh <-chan exportResult, o ...Option) (*client, *grpcCollector) {
 	t.Helper()
 	coll, err := newGRPCCollector("", rCh)
 	require.NoError(t, err)
 
 	addr := coll.listener.Addr().String()
-	opts := []Option{WithEndpoint(addr), WithInsecure()}
+	opts := append([]Option{WithEndpoint(addr), WithInsecure()}, o...)
 	cfg := newConfig(opts)
 	client, err := newClient(cfg)
 	require.NoError(t, err)
@@ -517,6 +550,22 @@ func TestClient(t *testing.T) {
 		}
 	})
 
+	for _, compressor := range []string{"gzip", "zstd", "snappy"} {
+		t.Run("UploadLogs/Compressor/"+compressor, func(t *testing.T) {
+			ctx := context.Background()
+			client, coll := clientFactory(t, nil, WithCompressor(compressor))
+
+			require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+			require.NoError(t, client.Shutdown(ctx))
+			got := coll.Collect().Dump()
+			require.Len(t, got, 1, "upload of one ResourceLogs")
+			diff := cmp.Diff(got[0], resourceLogs[0], cmp.Comparer(proto.Equal))
+			if diff != "" {
+				t.Fatalf("unexpected ResourceLogs:\n%s", diff)
+			}
+		})
+	}
+
 	t.Run("PartialSuccess", func(t *testing.T) {
 		const n, msg = 2, "bad data"
 		rCh := make(chan exportResult, 3)
@@ -560,4 +609,120 @@ func TestClient(t *testing.T) {
 		want := fmt.Sprintf("%s (%d log records rejected)", msg, n)
 		assert.ErrorContains(t, errs[0], want)
 	})
+
+	t.Run("MaxRequestItems", func(t *testing.T) {
+		n := len(logRecords)
+		rCh := make(chan exportResult, n)
+		rCh <- exportResult{Err: status.Error(codes.InvalidArgument, "rejected")}
+		for i := 1; i < n; i++ {
+			rCh <- exportResult{}
+		}
+
+		ctx := context.Background()
+		client, coll := clientFactory(t, rCh, WithMaxRequestItems(1))
+
+		err := client.UploadLogs(ctx, resourceLogs)
+		assert.ErrorContains(t, err, "rejected", "error of the first request")
+		require.NoError(t, client.Shutdown(ctx))
+
+		got := coll.Collect().Dump()
+		require.Len(t, got, n, "one request per log record")
+		for i, rl := range got {
+			assert.True(t, proto.Equal(res, rl.Resource), "resource")
+			require.Len(t, rl.ScopeLogs, 1)
+			assert.True(t, proto.Equal(scope, rl.ScopeLogs[0].Scope), "scope")
+			require.Len(t, rl.ScopeLogs[0].LogRecords, 1)
+			assert.True(t, proto.Equal(logRecords[i], rl.ScopeLogs[0].LogRecords[0]), "log record %d", i)
+		}
+	})
+
+	t.Run("Authenticator", func(t *testing.T) {
+		ctx := context.Background()
+		auth := &countingAuthenticator{}
+		client, coll := clientFactory(t, nil, WithAuthenticator(auth))
+
+		require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+		require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, coll.Headers()["authorization"], "called per request")
+
+		auth.err = errors.New("no token")
+		err := client.UploadLogs(ctx, resourceLogs)
+		assert.ErrorContains(t, err, "no token")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Len(t, coll.Collect().Dump(), 2, "request not sent")
+		require.NoError(t, client.Shutdown(ctx))
+	})
+
+	t.Run("MeterProvider", func(t *testing.T) {
+		rCh := make(chan exportResult, 1)
+		rCh <- exportResult{
+			Response: &collogpb.ExportLogsServiceResponse{
+				PartialSuccess: &collogpb.ExportLogsPartialSuccess{
+					RejectedLogRecords: 1,
+					ErrorMessage:       "bad data",
+				},
+			},
+		}
+
+		defer func(orig otel.ErrorHandler) {
+			otel.SetErrorHandler(orig)
+		}(otel.GetErrorHandler())
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {}))
+
+		r := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(r))
+		ctx := context.Background()
+		client, _ := clientFactory(t, rCh, WithMeterProvider(mp))
+
+		require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+		require.NoError(t, client.Shutdown(ctx))
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, r.Collect(ctx, &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		assert.Equal(t, "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc", rm.ScopeMetrics[0].Scope.Name)
+
+		got := make(map[string]metricdata.Aggregation)
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			got[m.Name] = m.Data
+		}
+
+		exported, ok := got["otel.sdk.exporter.log.exported"].(metricdata.Sum[int64])
+		require.True(t, ok)
+		byErr := make(map[string]int64)
+		for _, dp := range exported.DataPoints {
+			v, _ := dp.Attributes.Value("error.type")
+			byErr[v.AsString()] += dp.Value
+		}
+		n := logRecordCount(resourceLogs)
+		assert.Equal(t, map[string]int64{"": n - 1, "rejected": 1}, byErr)
+
+		duration, ok := got["otel.sdk.exporter.operation.duration"].(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, duration.DataPoints, 1)
+		code, _ := duration.DataPoints[0].Attributes.Value("rpc.grpc.status_code")
+		assert.Equal(t, int64(codes.OK), code.AsInt64())
+
+		for _, name := range []string{
+			"otel.sdk.exporter.payload.uncompressed_size",
+			"otel.sdk.exporter.payload.compressed_size",
+		} {
+			size, ok := got[name].(metricdata.Sum[int64])
+			require.True(t, ok, name)
+			require.Len(t, size.DataPoints, 1, name)
+			assert.Positive(t, size.DataPoints[0].Value, name)
+		}
+	})
+}
+
+type countingAuthenticator struct {
+	n   atomic.Int64
+	err error
+}
+
+func (a *countingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", a.n.Add(1))}, nil
 }
@@ -4,8 +4,8 @@
 package otlploggrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 
 import (
+	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/url"
@@ -15,11 +15,16 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/certreload"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/compress"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/retry"
 	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // Default values.
@@ -88,6 +93,18 @@ type config struct {
 	compression setting[Compression]
 	timeout     setting[time.Duration]
 	retryCfg    setting[retry.Config]
+	// retryableCodes are the codes of the errors retried. The default
+	// retryable codes are used if it is not set.
+	retryableCodes setting[[]codes.Code]
+
+	// meterProvider is used to report telemetry about the exporter.
+	meterProvider setting[metric.MeterProvider]
+	// authenticator provides the authentication headers of every request.
+	authenticator setting[auth.Authenticator]
+	// maxRequestSize and maxRequestItems limit the size of a request,
+	// larger requests are split.
+	maxRequestSize  setting[int]
+	maxRequestItems setting[int]
 
 	// gRPC configurations
 	gRPCCredentials    setting[credentials.TransportCredentials]
@@ -112,7 +129,7 @@ func newConfig(options []Option) config {
 		getEnv[bool](envInsecure, convInsecure),
 	)
 	c.tlsCfg = c.tlsCfg.Resolve(
-		loadEnvTLS[*tls.Config](),
+		loadEnvTLS[*tls.Config](c.endpoint.Value),
 	)
 	c.headers = c.headers.Resolve(
 		getEnv[map[string]string](envHeaders, convHeaders),
@@ -232,15 +249,19 @@ const (
 	NoCompression Compression = iota
 	// GzipCompression represents that gzip compression should be used.
 	GzipCompression
+	// ZstdCompression represents that zstd compression should be used.
+	ZstdCompression
+	// SnappyCompression represents that snappy compression should be used.
+	SnappyCompression
 )
 
 // WithCompressor sets the compressor the gRPC client uses.
-// Supported compressor values: "gzip".
+// Supported compressor values: "gzip", "zstd", "snappy".
 //
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_LOGS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", "zstd", or "snappy". If both are set,
 // OTEL_EXPORTER_OTLP_LOGS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -372,12 +393,123 @@ func WithRetry(rc RetryConfig) Option {
 	})
 }
 
+// WithRetryableCodes sets the codes of the gRPC errors for which the export
+// of log records is retried according to the policy set with WithRetry.
+// Errors with any other code are not retried.
+//
+// By default, if this option is not passed, errors with the Canceled,
+// DeadlineExceeded, Aborted, OutOfRange, Unavailable, and DataLoss codes are
+// retried, as well as ResourceExhausted errors including RetryInfo details.
+func WithRetryableCodes(cs ...codes.Code) Option {
+	return fnOpt(func(c config) config {
+		c.retryableCodes = newSetting(append([]codes.Code{}, cs...))
+		return c
+	})
+}
+
+// WithMeterProvider sets the MeterProvider used to report telemetry about the
+// Exporter itself. The following metrics are reported:
+//
+//   - otel.sdk.exporter.log.exported: the number of log records exported.
+//     Log records that failed to be exported, or that were rejected by the
+//     collector in a partial success response, have the error.type attribute
+//     set.
+//   - otel.sdk.exporter.operation.duration: the duration of exports.
+//   - otel.sdk.exporter.operation.retries: the number of retried requests.
+//   - otel.sdk.exporter.payload.uncompressed_size and
+//     otel.sdk.exporter.payload.compressed_size: the size of the sent
+//     payloads before and after compression.
+//
+// When the gRPC connection is passed with WithGRPCConn, the payload size is
+// measured by the exporter before the request is sent. Only
+// otel.sdk.exporter.payload.uncompressed_size is reported, the size after
+// the compression configured on the connection is not known.
+//
+// By default, if this option is not passed, no telemetry is reported.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return fnOpt(func(c config) config {
+		c.meterProvider = newSetting(mp)
+		return c
+	})
+}
+
+// Authenticator provides the headers used to authenticate export requests,
+// e.g. an "Authorization" header holding a bearer token.
+//
+// Headers is called before every request sent, including retries, and the
+// returned headers are added to the request metadata. Header names are
+// converted to lowercase as required by gRPC. The headers are sent in
+// addition to the headers passed with WithHeaders. They are also sent when the
+// gRPC connection is passed with WithGRPCConn.
+// If Headers returns an error, the request is not sent and the export fails.
+//
+// Headers needs to be safe to call concurrently.
+type Authenticator interface {
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// WithAuthenticator sets the Authenticator used to authenticate every
+// request sent by the exporter. Unlike the static headers passed with
+// WithHeaders, it allows credentials that expire, like OAuth 2.0 access
+// tokens, to be refreshed without creating a new exporter.
+//
+// The go.opentelemetry.io/otel/exporters/otlp/otlpauth package provides an
+// Authenticator using the OAuth 2.0 client credentials grant.
+//
+// By default, if this option is not passed, requests are not authenticated
+// other than with the configured headers and TLS credentials.
+func WithAuthenticator(a Authenticator) Option {
+	return fnOpt(func(c config) config {
+		c.authenticator = newSetting[auth.Authenticator](a)
+		return c
+	})
+}
+
+// WithMaxRequestSize sets the maximum size, in bytes, of the requests sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the log records grouped by their resource and instrumentation
+// scope. The size is the one of the protobuf encoded request, before
+// compression. A single log record larger than n is sent in its own request.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by size.
+func WithMaxRequestSize(n int) Option {
+	return fnOpt(func(c config) config {
+		c.maxRequestSize = newSetting(n)
+		return c
+	})
+}
+
+// WithMaxRequestItems sets the maximum number of log records in a request sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the log records grouped by their resource and instrumentation
+// scope.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by their number of log records.
+func WithMaxRequestItems(n int) Option {
+	return fnOpt(func(c config) config {
+		c.maxRequestItems = newSetting(n)
+		return c
+	})
+}
+
 // convCompression returns the parsed compression encoded in s. NoCompression
 // and an errors are returned if s is unknown.
 func convCompression(s string) (Compression, error) {
 	switch s {
 	case "gzip":
 		return GzipCompression, nil
+	case compress.ZstdName:
+		return ZstdCompression, nil
+	case compress.SnappyName:
+		return SnappyCompression, nil
 	case "none", "":
 		return NoCompression, nil
 	}
@@ -450,46 +582,45 @@ func convDuration(s string) (time.Duration, error) {
 
 // loadEnvTLS returns a resolver that loads a *tls.Config from files defeind by
 // the OTLP TLS environment variables. This will load both the rootCAs and
-// certificates used for mTLS.
+// certificates used for mTLS. They are reloaded when the files change, and the
+// server certificate is verified for the host of endpoint.
 //
 // If the filepath defined is invalid or does not contain valid TLS files, an
 // error is passed to the OTel ErrorHandler and no TLS configuration is
 // provided.
-func loadEnvTLS[T *tls.Config]() resolver[T] {
+func loadEnvTLS[T *tls.Config](endpoint string) resolver[T] {
 	return func(s setting[T]) setting[T] {
 		if s.Set {
 			// Passed, valid, options have precedence.
 			return s
 		}
 
-		var rootCAs *x509.CertPool
-		var err error
+		var files certreload.Files
 		for _, key := range envTLSCert {
 			if v := os.Getenv(key); v != "" {
-				rootCAs, err = loadCertPool(v)
+				files.CA = v
 				break
 			}
 		}
-
-		var certs []tls.Certificate
 		for _, pair := range envTLSClient {
 			cert := os.Getenv(pair.Certificate)
 			key := os.Getenv(pair.Key)
 			if cert != "" && key != "" {
-				var e error
-				certs, e = loadCertificates(cert, key)
-				err = errors.Join(err, e)
+				files.Cert, files.Key = cert, key
 				break
 			}
 		}
+		if files.CA == "" && files.Cert == "" {
+			return s
+		}
 
+		r, err := certreload.New(files, readFile)
 		if err != nil {
-			err = fmt.Errorf("failed to load TLS: %w", err)
-			otel.Handle(err)
-		} else if rootCAs != nil || certs != nil {
-			s.Set = true
-			s.Value = &tls.Config{RootCAs: rootCAs, Certificates: certs}
+			otel.Handle(fmt.Errorf("failed to load TLS: %w", err))
+			return s
 		}
+		s.Set = true
+		s.Value = r.TLSConfig(endpoint)
 		return s
 	}
 }
@@ -497,38 +628,6 @@ func loadEnvTLS[T *tls.Config]() resolver[T] {
 // readFile is used for testing.
 var readFile = os.ReadFile
 
-// loadCertPool loads and returns the *x509.CertPool found at path if it exists
-// and is valid. Otherwise, nil and an error is returned.
-func loadCertPool(path string) (*x509.CertPool, error) {
-	b, err := readFile(path)
-	if err != nil {
-		return nil, err
-	}
-	cp := x509.NewCertPool()
-	if ok := cp.AppendCertsFromPEM(b); !ok {
-		return nil, errors.New("certificate not added")
-	}
-	return cp, nil
-}
-
-// loadCertificates loads and returns the tls.Certificate found at path if it
-// exists and is valid. Otherwise, nil and an error is returned.
-func loadCertificates(certPath, keyPath string) ([]tls.Certificate, error) {
-	cert, err := readFile(certPath)
-	if err != nil {
-		return nil, err
-	}
-	key, err := readFile(keyPath)
-	if err != nil {
-		return nil, err
-	}
-	crt, err := tls.X509KeyPair(cert, key)
-	if err != nil {
-		return nil, err
-	}
-	return []tls.Certificate{crt}, nil
-}
-
 func compressorToCompression(compressor string) Compression {
 	c, err := convCompression(compressor)
 	if err != nil {
@@ -304,6 +304,31 @@ func TestNewConfig(t *testing.T) {
 				gRPCCredentials: newSetting(credentials.NewTLS(tlsCfg)),
 			},
 		},
+		{
+			name: "CompressorOption",
+			options: []Option{
+				WithCompressor("zstd"),
+			},
+			want: config{
+				endpoint:    newSetting(defaultEndpoint),
+				compression: newSetting(ZstdCompression),
+				timeout:     newSetting(defaultTimeout),
+				retryCfg:    newSetting(defaultRetryCfg),
+			},
+		},
+		{
+			name: "SignalCompressionEnvironmentVariables",
+			envars: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION":      "zstd",
+				"OTEL_EXPORTER_OTLP_LOGS_COMPRESSION": "snappy",
+			},
+			want: config{
+				endpoint:    newSetting(defaultEndpoint),
+				compression: newSetting(SnappyCompression),
+				timeout:     newSetting(defaultTimeout),
+				retryCfg:    newSetting(defaultRetryCfg),
+			},
+		},
 		{
 			name: "InvalidEnvironmentVariables",
 			envars: map[string]string{
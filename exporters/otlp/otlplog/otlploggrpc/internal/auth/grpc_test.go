@@ -0,0 +1,41 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/grpc_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPerRPCCredentials(t *testing.T) {
+	assert.Nil(t, PerRPCCredentials(nil))
+
+	ctx := context.Background()
+	creds := PerRPCCredentials(authFunc(func(context.Context) (map[string]string, error) {
+		return map[string]string{"Authorization": "Bearer token"}, nil
+	}))
+	require.NotNil(t, creds)
+	assert.False(t, creds.RequireTransportSecurity())
+
+	md, err := creds.GetRequestMetadata(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token"}, md)
+
+	errAuth := errors.New("no token")
+	creds = PerRPCCredentials(authFunc(func(context.Context) (map[string]string, error) {
+		return nil, errAuth
+	}))
+	_, err = creds.GetRequestMetadata(ctx)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.ErrorContains(t, err, errAuth.Error())
+}
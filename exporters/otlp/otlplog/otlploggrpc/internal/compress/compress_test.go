@@ -0,0 +1,65 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/compress/compress_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compress
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var payload = bytes.Repeat([]byte("OpenTelemetry "), 100)
+
+func TestZstd(t *testing.T) {
+	dec, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer dec.Close()
+
+	for _, data := range [][]byte{payload, {}} {
+		b, err := Zstd(nil, data)
+		require.NoError(t, err)
+
+		got, err := dec.DecodeAll(b, nil)
+		require.NoError(t, err)
+		assert.Equal(t, len(data), len(got))
+		assert.True(t, bytes.Equal(data, got))
+	}
+
+	b, err := Zstd([]byte("prefix"), payload)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(b, []byte("prefix")), "not appended")
+	assert.Less(t, len(b), len(payload), "not compressed")
+}
+
+func TestZstdConcurrentSafe(t *testing.T) {
+	const goroutines = 10
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			_, err := Zstd(nil, payload)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSnappy(t *testing.T) {
+	b := Snappy(payload)
+	assert.Less(t, len(b), len(payload), "not compressed")
+
+	got, err := snappy.Decode(nil, b)
+	require.NoError(t, err)
+	assert.Equal(t, payload, got)
+}
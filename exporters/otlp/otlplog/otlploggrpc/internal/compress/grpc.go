@@ -0,0 +1,157 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/compress/grpc.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compress // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/compress"
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+func init() {
+	// Do not override compressors registered by the user.
+	if encoding.GetCompressor(ZstdName) == nil {
+		encoding.RegisterCompressor(&zstdCompressor{})
+	}
+	if encoding.GetCompressor(SnappyName) == nil {
+		encoding.RegisterCompressor(&snappyCompressor{})
+	}
+}
+
+// zstdCompressor is a gRPC compressor using zstd.
+type zstdCompressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+var _ encoding.Compressor = (*zstdCompressor)(nil)
+
+func (c *zstdCompressor) Name() string { return ZstdName }
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if zw, ok := c.writers.Get().(*zstd.Encoder); ok {
+		zw.Reset(w)
+		return &zstdWriteCloser{Encoder: zw, pool: &c.writers}, nil
+	}
+	zw, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdWriteCloser{Encoder: zw, pool: &c.writers}, nil
+}
+
+// zstdWriteCloser returns its encoder to the pool when closed.
+type zstdWriteCloser struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriteCloser) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return err
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	if zr, ok := c.readers.Get().(*zstd.Decoder); ok {
+		if err := zr.Reset(r); err != nil {
+			return nil, err
+		}
+		return &zstdReader{Decoder: zr, pool: &c.readers}, nil
+	}
+	zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdReader{Decoder: zr, pool: &c.readers}, nil
+}
+
+// zstdReader returns its decoder to the pool once fully read.
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+	done bool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.done = true
+		// Release the reference to the underlying reader.
+		_ = r.Decoder.Reset(nil)
+		r.pool.Put(r.Decoder)
+	}
+	return n, err
+}
+
+// snappyCompressor is a gRPC compressor using the snappy framing format.
+type snappyCompressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+var _ encoding.Compressor = (*snappyCompressor)(nil)
+
+func (c *snappyCompressor) Name() string { return SnappyName }
+
+func (c *snappyCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	sw, ok := c.writers.Get().(*snappy.Writer)
+	if !ok {
+		sw = snappy.NewBufferedWriter(w)
+	} else {
+		sw.Reset(w)
+	}
+	return &snappyWriteCloser{Writer: sw, pool: &c.writers}, nil
+}
+
+// snappyWriteCloser returns its writer to the pool when closed.
+type snappyWriteCloser struct {
+	*snappy.Writer
+	pool *sync.Pool
+}
+
+func (w *snappyWriteCloser) Close() error {
+	err := w.Writer.Close()
+	w.pool.Put(w.Writer)
+	return err
+}
+
+func (c *snappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	sr, ok := c.readers.Get().(*snappy.Reader)
+	if !ok {
+		sr = snappy.NewReader(r)
+	} else {
+		sr.Reset(r)
+	}
+	return &snappyReader{Reader: sr, pool: &c.readers}, nil
+}
+
+// snappyReader returns its reader to the pool once fully read.
+type snappyReader struct {
+	*snappy.Reader
+	pool *sync.Pool
+	done bool
+}
+
+func (r *snappyReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		r.done = true
+		r.Reader.Reset(nil)
+		r.pool.Put(r.Reader)
+	}
+	return n, err
+}
@@ -0,0 +1,58 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/logs.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc/internal/split"
+
+import (
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var logs = splitter[[]*logpb.ResourceLogs]{
+	count: logRecordCount,
+	size: func(rls []*logpb.ResourceLogs, size func(proto.Message) int) int {
+		return size(&collogpb.ExportLogsServiceRequest{ResourceLogs: rls})
+	},
+	cut: func(rls []*logpb.ResourceLogs, n int) ([]*logpb.ResourceLogs, []*logpb.ResourceLogs) {
+		return cut(rls, n, resourceLogRecordCount, cutResourceLogs)
+	},
+}
+
+// Logs returns the resource logs of the requests rls is split in to be
+// within l.
+func Logs(rls []*logpb.ResourceLogs, l Limits) [][]*logpb.ResourceLogs {
+	return logs.split(nil, rls, l)
+}
+
+func logRecordCount(rls []*logpb.ResourceLogs) int {
+	var n int
+	for _, rl := range rls {
+		n += resourceLogRecordCount(rl)
+	}
+	return n
+}
+
+func resourceLogRecordCount(rl *logpb.ResourceLogs) int {
+	var n int
+	for _, sl := range rl.GetScopeLogs() {
+		n += len(sl.LogRecords)
+	}
+	return n
+}
+
+func cutResourceLogs(rl *logpb.ResourceLogs, n int) (*logpb.ResourceLogs, *logpb.ResourceLogs) {
+	head, tail := cut(rl.ScopeLogs, n, func(sl *logpb.ScopeLogs) int {
+		return len(sl.LogRecords)
+	}, cutScopeLogs)
+	return &logpb.ResourceLogs{Resource: rl.Resource, ScopeLogs: head, SchemaUrl: rl.SchemaUrl},
+		&logpb.ResourceLogs{Resource: rl.Resource, ScopeLogs: tail, SchemaUrl: rl.SchemaUrl}
+}
+
+func cutScopeLogs(sl *logpb.ScopeLogs, n int) (*logpb.ScopeLogs, *logpb.ScopeLogs) {
+	return &logpb.ScopeLogs{Scope: sl.Scope, LogRecords: sl.LogRecords[:n], SchemaUrl: sl.SchemaUrl},
+		&logpb.ScopeLogs{Scope: sl.Scope, LogRecords: sl.LogRecords[n:], SchemaUrl: sl.SchemaUrl}
+}
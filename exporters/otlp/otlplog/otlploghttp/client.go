@@ -10,9 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,12 +23,21 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
 	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/compress"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/observ"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/split"
 )
 
+const contentTypeProto = "application/x-protobuf"
+
 type client struct {
 	uploadLogs func(context.Context, []*logpb.ResourceLogs) error
 }
@@ -44,20 +55,23 @@ func newNoopClient() *client {
 
 // newHTTPClient creates a new HTTP log client.
 func newHTTPClient(cfg config) (*client, error) {
-	hc := &http.Client{
-		Transport: ourTransport,
-		Timeout:   cfg.timeout.Value,
-	}
+	hc := cfg.httpClient.Value
+	if hc == nil {
+		hc = &http.Client{
+			Transport: ourTransport,
+			Timeout:   cfg.timeout.Value,
+		}
 
-	if cfg.tlsCfg.Value != nil || cfg.proxy.Value != nil {
-		clonedTransport := ourTransport.Clone()
-		hc.Transport = clonedTransport
+		if cfg.tlsCfg.Value != nil || cfg.proxy.Value != nil {
+			clonedTransport := ourTransport.Clone()
+			hc.Transport = clonedTransport
 
-		if cfg.tlsCfg.Value != nil {
-			clonedTransport.TLSClientConfig = cfg.tlsCfg.Value
-		}
-		if cfg.proxy.Value != nil {
-			clonedTransport.Proxy = cfg.proxy.Value
+			if cfg.tlsCfg.Value != nil {
+				clonedTransport.TLSClientConfig = cfg.tlsCfg.Value
+			}
+			if cfg.proxy.Value != nil {
+				clonedTransport.Proxy = cfg.proxy.Value
+			}
 		}
 	}
 
@@ -83,13 +97,47 @@ func newHTTPClient(cfg config) (*client, error) {
 			req.Header.Set(k, v)
 		}
 	}
-	req.Header.Set("Content-Type", "application/x-protobuf")
+	if cfg.encoding.Value == JSONEncoding {
+		req.Header.Set("Content-Type", internal.ContentTypeJSON)
+	} else {
+		req.Header.Set("Content-Type", contentTypeProto)
+	}
+
+	componentType := "otlp_http_log_exporter"
+	if cfg.encoding.Value == JSONEncoding {
+		componentType = "otlp_http_json_log_exporter"
+	}
+
+	retryableStatusCodes := cfg.retryableStatusCodes.Value
+	if retryableStatusCodes == nil {
+		retryableStatusCodes = defaultRetryableStatusCodes
+	}
+
+	limits := split.Limits{
+		Bytes: cfg.maxRequestSize.Value,
+		Items: cfg.maxRequestItems.Value,
+	}
+	if cfg.encoding.Value == JSONEncoding {
+		limits.Size = internal.JSONSize
+	}
 
 	c := &httpClient{
-		compression: cfg.compression.Value,
-		req:         req,
-		requestFunc: cfg.retryCfg.Value.RequestFunc(evaluate),
-		client:      hc,
+		compression:          cfg.compression.Value,
+		encoding:             cfg.encoding.Value,
+		req:                  req,
+		requestFunc:          cfg.retryCfg.Value.RequestFunc(evaluate),
+		retryableStatusCodes: retryableStatusCodes,
+		client:               hc,
+		auth:                 cfg.authenticator.Value,
+		limits:               limits,
+		inst: observ.New(
+			cfg.meterProvider.Value,
+			"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp",
+			Version(),
+			observ.LogRecords,
+			componentType,
+			cfg.endpoint.Value,
+		),
 	}
 	return &client{uploadLogs: c.uploadLogs}, nil
 }
@@ -98,8 +146,28 @@ type httpClient struct {
 	// req is cloned for every upload the client makes.
 	req         *http.Request
 	compression Compression
+	encoding    Encoding
 	requestFunc retry.RequestFunc
-	client      *http.Client
+	// retryableStatusCodes are the status codes of the responses retried.
+	retryableStatusCodes []int
+	client               *http.Client
+	// auth provides the authentication headers of every request. It is nil
+	// if no Authenticator is configured.
+	auth auth.Authenticator
+	// limits are the limits uploads are split to be within.
+	limits split.Limits
+	// inst records the telemetry of the client. It is nil if no
+	// MeterProvider is configured.
+	inst *observ.Instrumentation
+}
+
+// defaultRetryableStatusCodes are the status codes of the responses retried
+// if no retryable status codes are configured.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
 }
 
 // Keep it in sync with golang's DefaultTransport from net/http! We
@@ -119,12 +187,23 @@ var ourTransport = &http.Transport{
 	ExpectContinueTimeout: 1 * time.Second,
 }
 
+// uploadLogs sends data, split into several requests if it exceeds the
+// configured request limits.
 func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs) error {
 	// The Exporter synchronizes access to client methods. This is not called
 	// after the Exporter is shutdown. Only thing to do here is send data.
 
+	var err error
+	for _, rl := range split.Logs(data, c.limits) {
+		err = errors.Join(err, c.upload(ctx, rl))
+	}
+	return err
+}
+
+// upload sends data in a single request.
+func (c *httpClient) upload(ctx context.Context, data []*logpb.ResourceLogs) error {
 	pbRequest := &collogpb.ExportLogsServiceRequest{ResourceLogs: data}
-	body, err := proto.Marshal(pbRequest)
+	body, err := c.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -133,14 +212,22 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 		return err
 	}
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
+	start := time.Now()
+	var attempts, rejected int64
+	var statusCode int
+	err = c.requestFunc(ctx, func(iCtx context.Context) error {
 		select {
 		case <-iCtx.Done():
 			return iCtx.Err()
 		default:
 		}
 
+		attempts++
 		request.reset(iCtx)
+		if err := auth.SetHeaders(iCtx, c.auth, request.Header); err != nil {
+			return err
+		}
+		c.inst.RecordPayload(iCtx, len(body), request.size)
 		resp, err := c.client.Do(request.Request)
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) && urlErr.Temporary() {
@@ -150,6 +237,7 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 			return err
 		}
 
+		statusCode = resp.StatusCode
 		var rErr error
 		switch sc := resp.StatusCode; {
 		case sc >= 200 && sc <= 299:
@@ -164,26 +252,22 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 				return nil
 			}
 
-			if resp.Header.Get("Content-Type") == "application/x-protobuf" {
-				var respProto collogpb.ExportLogsServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
-					return err
-				}
+			var respProto collogpb.ExportLogsServiceResponse
+			if ok, err := unmarshalResponse(resp.Header, respData.Bytes(), &respProto); !ok || err != nil {
+				return err
+			}
 
-				if respProto.PartialSuccess != nil {
-					msg := respProto.PartialSuccess.GetErrorMessage()
-					n := respProto.PartialSuccess.GetRejectedLogRecords()
-					if n != 0 || msg != "" {
-						err := fmt.Errorf("OTLP partial success: %s (%d log records rejected)", msg, n)
-						otel.Handle(err)
-					}
+			if respProto.PartialSuccess != nil {
+				msg := respProto.PartialSuccess.GetErrorMessage()
+				n := respProto.PartialSuccess.GetRejectedLogRecords()
+				if n != 0 || msg != "" {
+					rejected = n
+					err := fmt.Errorf("OTLP partial success: %s (%d log records rejected)", msg, n)
+					otel.Handle(err)
 				}
 			}
 			return nil
-		case sc == http.StatusTooManyRequests,
-			sc == http.StatusBadGateway,
-			sc == http.StatusServiceUnavailable,
-			sc == http.StatusGatewayTimeout:
+		case slices.Contains(c.retryableStatusCodes, sc):
 			// Retry-able failure.
 			rErr = newResponseError(resp.Header, nil)
 
@@ -213,6 +297,65 @@ func (c *httpClient) uploadLogs(ctx context.Context, data []*logpb.ResourceLogs)
 		}
 		return rErr
 	})
+	c.recordExport(ctx, start, data, attempts, rejected, statusCode, err)
+	return err
+}
+
+// recordExport records the telemetry of an export of rl that started at start
+// and was attempted attempts times. statusCode is the status code of the last
+// response received, or zero if none was.
+func (c *httpClient) recordExport(ctx context.Context, start time.Time, rl []*logpb.ResourceLogs, attempts, rejected int64, statusCode int, err error) {
+	if c.inst == nil {
+		return
+	}
+	c.inst.RecordRetries(ctx, attempts-1)
+
+	var extra []attribute.KeyValue
+	if statusCode != 0 {
+		extra = append(extra, semconv.HTTPResponseStatusCode(statusCode))
+	}
+	errType := observ.ErrorType(err)
+	if err != nil && statusCode != 0 && (statusCode < 200 || statusCode > 299) {
+		errType = strconv.Itoa(statusCode)
+	}
+	c.inst.RecordExport(ctx, start, logRecordCount(rl), rejected, errType, extra...)
+}
+
+// logRecordCount returns the number of log records in rl.
+func logRecordCount(rl []*logpb.ResourceLogs) int64 {
+	var n int
+	for _, r := range rl {
+		for _, sl := range r.GetScopeLogs() {
+			n += len(sl.GetLogRecords())
+		}
+	}
+	return int64(n)
+}
+
+// marshal returns the encoding of m configured for c.
+func (c *httpClient) marshal(m proto.Message) ([]byte, error) {
+	if c.encoding == JSONEncoding {
+		return internal.MarshalJSON(m)
+	}
+	return proto.Marshal(m)
+}
+
+// unmarshalResponse parses the response body data into m based on the
+// Content-Type in header. It returns false if the Content-Type is not a
+// supported OTLP encoding and data was not parsed.
+func unmarshalResponse(header http.Header, data []byte, m proto.Message) (bool, error) {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false, nil
+	}
+	switch mediaType {
+	case contentTypeProto:
+		return true, proto.Unmarshal(data, m)
+	case internal.ContentTypeJSON:
+		return true, internal.UnmarshalJSON(data, m)
+	default:
+		return false, nil
+	}
 }
 
 var gzPool = sync.Pool{
@@ -230,6 +373,7 @@ func (c *httpClient) newRequest(ctx context.Context, body []byte) (request, erro
 	case NoCompression:
 		r.ContentLength = (int64)(len(body))
 		req.bodyReader = bodyReader(body)
+		req.size = len(body)
 	case GzipCompression:
 		// Ensure the content length is not used.
 		r.ContentLength = -1
@@ -250,6 +394,22 @@ func (c *httpClient) newRequest(ctx context.Context, body []byte) (request, erro
 		}
 
 		req.bodyReader = bodyReader(b.Bytes())
+		req.size = b.Len()
+	case ZstdCompression:
+		b, err := compress.Zstd(nil, body)
+		if err != nil {
+			return req, err
+		}
+		r.ContentLength = int64(len(b))
+		r.Header.Set("Content-Encoding", compress.ZstdName)
+		req.bodyReader = bodyReader(b)
+		req.size = len(b)
+	case SnappyCompression:
+		b := compress.Snappy(body)
+		r.ContentLength = int64(len(b))
+		r.Header.Set("Content-Encoding", compress.SnappyName)
+		req.bodyReader = bodyReader(b)
+		req.size = len(b)
 	}
 
 	return req, nil
@@ -268,6 +428,8 @@ type request struct {
 
 	// bodyReader allows the same body to be used for multiple requests.
 	bodyReader func() io.ReadCloser
+	// size is the size of the body, after compression.
+	size int
 }
 
 // reset reinitializes the request Body and uses ctx for the request.
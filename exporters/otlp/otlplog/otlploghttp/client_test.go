@@ -21,23 +21,30 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal"
 	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
 	cpb "go.opentelemetry.io/proto/otlp/common/v1"
 	lpb "go.opentelemetry.io/proto/otlp/logs/v1"
 	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
 
 	"go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
@@ -310,12 +317,17 @@ func (c *httpCollector) Headers() map[string][]string {
 }
 
 func (c *httpCollector) handler(w http.ResponseWriter, r *http.Request) {
-	c.respond(w, c.record(r))
+	c.respond(w, r.Header.Get("Content-Type"), c.record(r))
 }
 
 func (c *httpCollector) record(r *http.Request) exportResult {
-	// Currently only supports protobuf.
-	if v := r.Header.Get("Content-Type"); v != "application/x-protobuf" {
+	var unmarshal func([]byte, proto.Message) error
+	switch v := r.Header.Get("Content-Type"); v {
+	case contentTypeProto:
+		unmarshal = proto.Unmarshal
+	case internal.ContentTypeJSON:
+		unmarshal = internal.UnmarshalJSON
+	default:
 		err := fmt.Errorf("content-type not supported: %s", v)
 		return exportResult{Err: err}
 	}
@@ -325,7 +337,7 @@ func (c *httpCollector) record(r *http.Request) exportResult {
 		return exportResult{Err: err}
 	}
 	pbRequest := &collogpb.ExportLogsServiceRequest{}
-	err = proto.Unmarshal(body, pbRequest)
+	err = unmarshal(body, pbRequest)
 	if err != nil {
 		return exportResult{
 			Err: &httpResponseError{
@@ -362,6 +374,27 @@ func (c *httpCollector) readBody(r *http.Request) (body []byte, err error) {
 				Status: http.StatusInternalServerError,
 			}
 		}
+	case "zstd":
+		zr, err := zstd.NewReader(r.Body)
+		if err != nil {
+			return nil, &httpResponseError{
+				Err:    err,
+				Status: http.StatusInternalServerError,
+			}
+		}
+		reader = zr.IOReadCloser()
+	case "snappy":
+		b, err := io.ReadAll(r.Body)
+		if err == nil {
+			b, err = snappy.Decode(nil, b)
+		}
+		if err != nil {
+			return nil, &httpResponseError{
+				Err:    err,
+				Status: http.StatusInternalServerError,
+			}
+		}
+		reader = io.NopCloser(bytes.NewReader(b))
 	default:
 		reader = r.Body
 	}
@@ -385,7 +418,8 @@ func (c *httpCollector) readBody(r *http.Request) (body []byte, err error) {
 	return body, err
 }
 
-func (c *httpCollector) respond(w http.ResponseWriter, resp exportResult) {
+// respond writes resp to w using the encoding of the request content type.
+func (c *httpCollector) respond(w http.ResponseWriter, contentType string, resp exportResult) {
 	if resp.Err != nil {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -412,12 +446,19 @@ func (c *httpCollector) respond(w http.ResponseWriter, resp exportResult) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/x-protobuf")
+	marshal := proto.Marshal
+	if contentType == internal.ContentTypeJSON {
+		marshal = internal.MarshalJSON
+	} else {
+		contentType = contentTypeProto
+	}
+
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(http.StatusOK)
 	if resp.Response == nil {
 		_, _ = w.Write(emptyExportLogsServiceResponse)
 	} else {
-		r, err := proto.Marshal(resp.Response)
+		r, err := marshal(resp.Response)
 		if err != nil {
 			panic(err)
 		}
@@ -472,12 +513,12 @@ func newWeakCertificate() (tls.Certificate, error) {
 }
 
 func TestClient(t *testing.T) {
-	factory := func(rCh <-chan exportResult) (*client, *httpCollector) {
+	factory := func(rCh <-chan exportResult, o ...Option) (*client, *httpCollector) {
 		coll, err := newHTTPCollector("", rCh)
 		require.NoError(t, err)
 
 		addr := coll.Addr().String()
-		opts := []Option{WithEndpoint(addr), WithInsecure()}
+		opts := append([]Option{WithEndpoint(addr), WithInsecure()}, o...)
 		cfg := newConfig(opts)
 		client, err := newHTTPClient(cfg)
 		require.NoError(t, err)
@@ -506,64 +547,191 @@ func TestClient(t *testing.T) {
 		})
 	})
 
-	t.Run("uploadLogs", func(t *testing.T) {
+	encodings := map[string]Encoding{
+		"Protobuf": ProtobufEncoding,
+		"JSON":     JSONEncoding,
+	}
+
+	for name, enc := range encodings {
+		t.Run("uploadLogs/"+name, func(t *testing.T) {
+			ctx := context.Background()
+			client, coll := factory(nil, WithEncoding(enc))
+
+			require.NoError(t, client.uploadLogs(ctx, resourceLogs))
+			got := coll.Collect().Dump()
+			require.Len(t, got, 1, "upload of one ResourceLogs")
+			diff := cmp.Diff(got[0], resourceLogs[0], cmp.Comparer(proto.Equal))
+			if diff != "" {
+				t.Fatalf("unexpected ResourceLogs:\n%s", diff)
+			}
+		})
+	}
+
+	for name, enc := range encodings {
+		t.Run("PartialSuccess/"+name, func(t *testing.T) {
+			testPartialSuccess(t, func(rCh <-chan exportResult) *client {
+				c, _ := factory(rCh, WithEncoding(enc))
+				return c
+			})
+		})
+	}
+
+	t.Run("MaxRequestItems", func(t *testing.T) {
+		n := len(logRecords)
+		rCh := make(chan exportResult, n)
+		rCh <- exportResult{Err: &httpResponseError{
+			Status: http.StatusBadRequest,
+			Err:    errors.New("rejected"),
+		}}
+		for i := 1; i < n; i++ {
+			rCh <- exportResult{}
+		}
+
 		ctx := context.Background()
-		client, coll := factory(nil)
+		client, coll := factory(rCh, WithMaxRequestItems(1))
+
+		err := client.uploadLogs(ctx, resourceLogs)
+		assert.ErrorContains(t, err, "400", "error of the first request")
 
-		require.NoError(t, client.uploadLogs(ctx, resourceLogs))
 		got := coll.Collect().Dump()
-		require.Len(t, got, 1, "upload of one ResourceLogs")
-		diff := cmp.Diff(got[0], resourceLogs[0], cmp.Comparer(proto.Equal))
-		if diff != "" {
-			t.Fatalf("unexpected ResourceLogs:\n%s", diff)
+		require.Len(t, got, n, "one request per log record")
+		for i, rl := range got {
+			assert.True(t, proto.Equal(res, rl.Resource), "resource")
+			require.Len(t, rl.ScopeLogs, 1)
+			assert.True(t, proto.Equal(scope, rl.ScopeLogs[0].Scope), "scope")
+			require.Len(t, rl.ScopeLogs[0].LogRecords, 1)
+			assert.True(t, proto.Equal(logRecords[i], rl.ScopeLogs[0].LogRecords[0]), "log record %d", i)
 		}
 	})
 
-	t.Run("PartialSuccess", func(t *testing.T) {
-		const n, msg = 2, "bad data"
-		rCh := make(chan exportResult, 3)
-		rCh <- exportResult{
-			Response: &collogpb.ExportLogsServiceResponse{
-				PartialSuccess: &collogpb.ExportLogsPartialSuccess{
-					RejectedLogRecords: n,
-					ErrorMessage:       msg,
-				},
-			},
-		}
+	t.Run("MeterProvider", func(t *testing.T) {
+		rCh := make(chan exportResult, 2)
+		rCh <- exportResult{Err: &httpResponseError{
+			Status: http.StatusServiceUnavailable,
+			Err:    errors.New("unavailable"),
+		}}
 		rCh <- exportResult{
 			Response: &collogpb.ExportLogsServiceResponse{
 				PartialSuccess: &collogpb.ExportLogsPartialSuccess{
-					// Should not be logged.
-					RejectedLogRecords: 0,
-					ErrorMessage:       "",
+					RejectedLogRecords: 1,
+					ErrorMessage:       "bad data",
 				},
 			},
 		}
-		rCh <- exportResult{
-			Response: &collogpb.ExportLogsServiceResponse{},
-		}
-
-		ctx := context.Background()
-		client, _ := factory(rCh)
 
 		defer func(orig otel.ErrorHandler) {
 			otel.SetErrorHandler(orig)
 		}(otel.GetErrorHandler())
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {}))
 
-		errs := []error{}
-		eh := otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) })
-		otel.SetErrorHandler(eh)
-
-		require.NoError(t, client.UploadLogs(ctx, resourceLogs))
-		require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+		r := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(r))
+		ctx := context.Background()
+		client, _ := factory(
+			rCh,
+			WithMeterProvider(mp),
+			WithCompression(GzipCompression),
+			WithRetry(RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Nanosecond,
+				MaxInterval:     time.Millisecond,
+				MaxElapsedTime:  time.Minute,
+			}),
+		)
 		require.NoError(t, client.UploadLogs(ctx, resourceLogs))
 
-		require.Equal(t, 1, len(errs))
-		want := fmt.Sprintf("%s (%d log records rejected)", msg, n)
-		assert.ErrorContains(t, errs[0], want)
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, r.Collect(ctx, &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		assert.Equal(t, "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp", rm.ScopeMetrics[0].Scope.Name)
+
+		got := make(map[string]metricdata.Aggregation)
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			got[m.Name] = m.Data
+		}
+
+		exported, ok := got["otel.sdk.exporter.log.exported"].(metricdata.Sum[int64])
+		require.True(t, ok)
+		byErr := make(map[string]int64)
+		for _, dp := range exported.DataPoints {
+			v, _ := dp.Attributes.Value("error.type")
+			byErr[v.AsString()] += dp.Value
+		}
+		n := logRecordCount(resourceLogs)
+		assert.Equal(t, map[string]int64{"": n - 1, "rejected": 1}, byErr)
+
+		retries, ok := got["otel.sdk.exporter.operation.retries"].(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, retries.DataPoints, 1)
+		assert.Equal(t, int64(1), retries.DataPoints[0].Value)
+
+		duration, ok := got["otel.sdk.exporter.operation.duration"].(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, duration.DataPoints, 1)
+		code, _ := duration.DataPoints[0].Attributes.Value("http.response.status_code")
+		assert.Equal(t, int64(http.StatusOK), code.AsInt64())
+
+		sizes := make(map[string]int64)
+		for _, name := range []string{
+			"otel.sdk.exporter.payload.uncompressed_size",
+			"otel.sdk.exporter.payload.compressed_size",
+		} {
+			size, ok := got[name].(metricdata.Sum[int64])
+			require.True(t, ok, name)
+			require.Len(t, size.DataPoints, 1, name)
+			sizes[name] = size.DataPoints[0].Value
+		}
+		uncompressed := sizes["otel.sdk.exporter.payload.uncompressed_size"]
+		compressed := sizes["otel.sdk.exporter.payload.compressed_size"]
+		assert.Positive(t, compressed)
+		assert.NotEqual(t, uncompressed, compressed, "payload is compressed")
 	})
 }
 
+func testPartialSuccess(t *testing.T, newClient func(<-chan exportResult) *client) {
+	const n, msg = 2, "bad data"
+	rCh := make(chan exportResult, 3)
+	rCh <- exportResult{
+		Response: &collogpb.ExportLogsServiceResponse{
+			PartialSuccess: &collogpb.ExportLogsPartialSuccess{
+				RejectedLogRecords: n,
+				ErrorMessage:       msg,
+			},
+		},
+	}
+	rCh <- exportResult{
+		Response: &collogpb.ExportLogsServiceResponse{
+			PartialSuccess: &collogpb.ExportLogsPartialSuccess{
+				// Should not be logged.
+				RejectedLogRecords: 0,
+				ErrorMessage:       "",
+			},
+		},
+	}
+	rCh <- exportResult{
+		Response: &collogpb.ExportLogsServiceResponse{},
+	}
+
+	ctx := context.Background()
+	client := newClient(rCh)
+
+	defer func(orig otel.ErrorHandler) {
+		otel.SetErrorHandler(orig)
+	}(otel.GetErrorHandler())
+
+	errs := []error{}
+	eh := otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) })
+	otel.SetErrorHandler(eh)
+
+	require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+	require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+	require.NoError(t, client.UploadLogs(ctx, resourceLogs))
+
+	require.Equal(t, 1, len(errs))
+	want := fmt.Sprintf("%s (%d log records rejected)", msg, n)
+	assert.ErrorContains(t, errs[0], want)
+}
+
 func TestClientWithHTTPCollectorRespondingPlainText(t *testing.T) {
 	ctx := context.Background()
 	coll, err := newHTTPCollector("", nil, withHTTPCollectorRespondingPlainText())
@@ -587,6 +755,46 @@ func TestNewWithInvalidEndpoint(t *testing.T) {
 	assert.Nil(t, exp)
 }
 
+func TestWithHTTPClient(t *testing.T) {
+	hc, paths := newUnixSocketServer(t)
+	ctx := context.Background()
+	exp, err := New(ctx, WithEndpoint("localhost"), WithInsecure(), WithHTTPClient(hc))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+	require.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+	assert.Equal(t, "/v1/logs", <-paths)
+}
+
+// newUnixSocketServer returns an HTTP client sending all its requests to a
+// server listening on a Unix domain socket. The server sends the path of the
+// requests it receives on the returned channel.
+func newUnixSocketServer(t *testing.T) (*http.Client, <-chan string) {
+	t.Helper()
+	sock := filepath.Join(t.TempDir(), "otlp.sock")
+	ln, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+
+	paths := make(chan string, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.Path
+			w.WriteHeader(http.StatusOK)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", sock)
+			},
+		},
+	}, paths
+}
+
 func TestConfig(t *testing.T) {
 	factoryFunc := func(ePt string, rCh <-chan exportResult, o ...Option) (log.Exporter, *httpCollector) {
 		coll, err := newHTTPCollector(ePt, rCh)
@@ -635,6 +843,27 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, got[key], []string{headers[key]})
 	})
 
+	t.Run("WithAuthenticator", func(t *testing.T) {
+		auth := &countingAuthenticator{}
+		exp, coll := factoryFunc("", nil,
+			// Overridden by the Authenticator.
+			WithHeaders(map[string]string{"Authorization": "static"}),
+			WithAuthenticator(auth),
+		)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		require.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+		assert.Equal(t, []string{"Bearer token-1"}, coll.Headers()["Authorization"])
+		require.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, coll.Headers()["Authorization"], "called per request")
+
+		auth.err = errors.New("no token")
+		assert.ErrorIs(t, exp.Export(ctx, make([]log.Record, 1)), auth.err)
+		assert.Len(t, coll.Collect().Dump(), 2, "request not sent")
+	})
+
 	t.Run("WithTimeout", func(t *testing.T) {
 		// Do not send on rCh so the Collector never responds to the client.
 		rCh := make(chan exportResult)
@@ -653,14 +882,20 @@ func TestConfig(t *testing.T) {
 		assert.ErrorAs(t, err, new(retryableError))
 	})
 
-	t.Run("WithCompressionGZip", func(t *testing.T) {
-		exp, coll := factoryFunc("", nil, WithCompression(GzipCompression))
-		ctx := context.Background()
-		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
-		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
-		assert.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
-		assert.Len(t, coll.Collect().Dump(), 1)
-	})
+	for name, compression := range map[string]Compression{
+		"GZip":   GzipCompression,
+		"Zstd":   ZstdCompression,
+		"Snappy": SnappyCompression,
+	} {
+		t.Run("WithCompression"+name, func(t *testing.T) {
+			exp, coll := factoryFunc("", nil, WithCompression(compression))
+			ctx := context.Background()
+			t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+			t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+			assert.NoError(t, exp.Export(ctx, make([]log.Record, 1)))
+			assert.Len(t, coll.Collect().Dump(), 1)
+		})
+	}
 
 	t.Run("WithRetry", func(t *testing.T) {
 		emptyErr := errors.New("")
@@ -700,6 +935,37 @@ func TestConfig(t *testing.T) {
 		assert.Len(t, rCh, 0, "failed HTTP responses did not occur")
 	})
 
+	t.Run("WithRetryableStatusCodes", func(t *testing.T) {
+		emptyErr := errors.New("")
+		rCh := make(chan exportResult, 3)
+		rCh <- exportResult{Err: &httpResponseError{
+			Status: http.StatusInternalServerError,
+			Err:    emptyErr,
+		}}
+		// Not retried, only the configured status codes are.
+		rCh <- exportResult{Err: &httpResponseError{
+			Status: http.StatusServiceUnavailable,
+			Err:    emptyErr,
+		}}
+		rCh <- exportResult{}
+		exp, coll := factoryFunc("", rCh,
+			WithRetryableStatusCodes(http.StatusInternalServerError),
+			WithRetry(RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Nanosecond,
+				MaxInterval:     time.Millisecond,
+				MaxElapsedTime:  time.Minute,
+			}),
+		)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		// Push this after Shutdown so the HTTP server doesn't hang.
+		t.Cleanup(func() { close(rCh) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.Error(t, exp.Export(ctx, make([]log.Record, 1)), "non-retryable status code")
+		assert.Len(t, rCh, 1, "retried a non-retryable status code")
+	})
+
 	t.Run("WithRetryAndExporterErr", func(t *testing.T) {
 		exporterErr := errors.New("rpc error: code = Unavailable desc = service.name not found in resource attributes")
 		rCh := make(chan exportResult, 1)
@@ -779,3 +1045,15 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, got[headerKeySetInProxy], []string{headerValueSetInProxy})
 	})
 }
+
+type countingAuthenticator struct {
+	n   atomic.Int64
+	err error
+}
+
+func (a *countingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", a.n.Add(1))}, nil
+}
@@ -4,8 +4,8 @@
 package otlploghttp // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
 
 import (
+	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,8 +16,12 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/certreload"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/compress"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/retry"
 	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // Default values.
@@ -51,6 +55,11 @@ var (
 		"OTEL_EXPORTER_OTLP_COMPRESSION",
 	}
 
+	envProtocol = []string{
+		"OTEL_EXPORTER_OTLP_LOGS_PROTOCOL",
+		"OTEL_EXPORTER_OTLP_PROTOCOL",
+	}
+
 	envTimeout = []string{
 		"OTEL_EXPORTER_OTLP_LOGS_TIMEOUT",
 		"OTEL_EXPORTER_OTLP_TIMEOUT",
@@ -91,9 +100,25 @@ type config struct {
 	tlsCfg      setting[*tls.Config]
 	headers     setting[map[string]string]
 	compression setting[Compression]
+	encoding    setting[Encoding]
 	timeout     setting[time.Duration]
 	proxy       setting[HTTPTransportProxyFunc]
 	retryCfg    setting[retry.Config]
+	// httpClient is the client sending the requests. It is created from the
+	// other settings if it is not set.
+	httpClient setting[*http.Client]
+	// retryableStatusCodes are the status codes of the responses retried.
+	// The default retryable status codes are used if it is not set.
+	retryableStatusCodes setting[[]int]
+
+	// meterProvider is used to report telemetry about the exporter.
+	meterProvider setting[metric.MeterProvider]
+	// authenticator provides the authentication headers of every request.
+	authenticator setting[auth.Authenticator]
+	// maxRequestSize and maxRequestItems limit the size of a request,
+	// larger requests are split.
+	maxRequestSize  setting[int]
+	maxRequestItems setting[int]
 }
 
 func newConfig(options []Option) config {
@@ -115,7 +140,7 @@ func newConfig(options []Option) config {
 		getenv[bool](envInsecure, convInsecure),
 	)
 	c.tlsCfg = c.tlsCfg.Resolve(
-		loadEnvTLS[*tls.Config](),
+		loadEnvTLS[*tls.Config](c.endpoint.Value),
 	)
 	c.headers = c.headers.Resolve(
 		getenv[map[string]string](envHeaders, convHeaders),
@@ -123,6 +148,9 @@ func newConfig(options []Option) config {
 	c.compression = c.compression.Resolve(
 		getenv[Compression](envCompression, convCompression),
 	)
+	c.encoding = c.encoding.Resolve(
+		getenv[Encoding](envProtocol, convEncoding),
+	)
 	c.timeout = c.timeout.Resolve(
 		getenv[time.Duration](envTimeout, convDuration),
 		fallback[time.Duration](defaultTimeout),
@@ -195,6 +223,10 @@ const (
 	NoCompression Compression = iota
 	// GzipCompression represents that gzip compression should be used.
 	GzipCompression
+	// ZstdCompression represents that zstd compression should be used.
+	ZstdCompression
+	// SnappyCompression represents that snappy compression should be used.
+	SnappyCompression
 )
 
 // WithCompression sets the compression strategy the Exporter will use to
@@ -203,7 +235,7 @@ const (
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_LOGS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", "zstd", or "snappy". If both are set,
 // OTEL_EXPORTER_OTLP_LOGS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -215,6 +247,35 @@ func WithCompression(compression Compression) Option {
 	})
 }
 
+// Encoding describes the encoding used for exported payloads.
+type Encoding int
+
+const (
+	// ProtobufEncoding represents that the binary Protobuf encoding should be
+	// used.
+	ProtobufEncoding Encoding = iota
+	// JSONEncoding represents that the OTLP/JSON encoding should be used.
+	JSONEncoding
+)
+
+// WithEncoding sets the encoding the Exporter will use for the HTTP body.
+// Responses from the collector are parsed according to their Content-Type.
+//
+// If the OTEL_EXPORTER_OTLP_PROTOCOL or OTEL_EXPORTER_OTLP_LOGS_PROTOCOL
+// environment variable is set, and this option is not passed, that variable
+// value will be used. That value can be either "http/protobuf" or
+// "http/json". If both are set, OTEL_EXPORTER_OTLP_LOGS_PROTOCOL will take
+// precedence.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, ProtobufEncoding will be used.
+func WithEncoding(encoding Encoding) Option {
+	return fnOpt(func(c config) config {
+		c.encoding = newSetting(encoding)
+		return c
+	})
+}
+
 // WithURLPath sets the URL path the Exporter will send requests to.
 //
 // If the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_LOGS_ENDPOINT
@@ -327,6 +388,107 @@ func WithRetry(rc RetryConfig) Option {
 	})
 }
 
+// WithRetryableStatusCodes sets the status codes of the HTTP responses for
+// which the export of log records is retried according to the policy set
+// with WithRetry. Responses with any other error status code are not
+// retried.
+//
+// By default, if this option is not passed, responses with the 429, 502, 503,
+// and 504 status codes are retried.
+func WithRetryableStatusCodes(codes ...int) Option {
+	return fnOpt(func(c config) config {
+		c.retryableStatusCodes = newSetting(append([]int{}, codes...))
+		return c
+	})
+}
+
+// WithMeterProvider sets the MeterProvider used to report telemetry about the
+// Exporter itself. The following metrics are reported:
+//
+//   - otel.sdk.exporter.log.exported: the number of log records exported.
+//     Log records that failed to be exported, or that were rejected by the
+//     collector in a partial success response, have the error.type attribute
+//     set.
+//   - otel.sdk.exporter.operation.duration: the duration of exports.
+//   - otel.sdk.exporter.operation.retries: the number of retried requests.
+//   - otel.sdk.exporter.payload.uncompressed_size and
+//     otel.sdk.exporter.payload.compressed_size: the size of the sent
+//     payloads before and after compression.
+//
+// By default, if this option is not passed, no telemetry is reported.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return fnOpt(func(c config) config {
+		c.meterProvider = newSetting(mp)
+		return c
+	})
+}
+
+// Authenticator provides the headers used to authenticate export requests,
+// e.g. an "Authorization" header holding a bearer token.
+//
+// Headers is called before every request sent, including retries, and the
+// returned headers are set on the request. They override the headers with
+// the same name passed with WithHeaders.
+// If Headers returns an error, the request is not sent and the export fails.
+//
+// Headers needs to be safe to call concurrently.
+type Authenticator interface {
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// WithAuthenticator sets the Authenticator used to authenticate every
+// request sent by the exporter. Unlike the static headers passed with
+// WithHeaders, it allows credentials that expire, like OAuth 2.0 access
+// tokens, to be refreshed without creating a new exporter.
+//
+// The go.opentelemetry.io/otel/exporters/otlp/otlpauth package provides an
+// Authenticator using the OAuth 2.0 client credentials grant.
+//
+// By default, if this option is not passed, requests are not authenticated
+// other than with the configured headers and TLS credentials.
+func WithAuthenticator(a Authenticator) Option {
+	return fnOpt(func(c config) config {
+		c.authenticator = newSetting[auth.Authenticator](a)
+		return c
+	})
+}
+
+// WithMaxRequestSize sets the maximum size, in bytes, of the requests sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the log records grouped by their resource and instrumentation
+// scope. The size is the one of the request encoded with the protobuf or JSON
+// encoding in use, before compression. A single log record larger than n is sent
+// in its own request.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by size.
+func WithMaxRequestSize(n int) Option {
+	return fnOpt(func(c config) config {
+		c.maxRequestSize = newSetting(n)
+		return c
+	})
+}
+
+// WithMaxRequestItems sets the maximum number of log records in a request sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the log records grouped by their resource and instrumentation
+// scope.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by their number of log records.
+func WithMaxRequestItems(n int) Option {
+	return fnOpt(func(c config) config {
+		c.maxRequestItems = newSetting(n)
+		return c
+	})
+}
+
 // HTTPTransportProxyFunc is a function that resolves which URL to use as proxy
 // for a given request. This type is compatible with http.Transport.Proxy and
 // can be used to set a custom proxy function to the OTLP HTTP client.
@@ -342,6 +504,24 @@ func WithProxy(pf HTTPTransportProxyFunc) Option {
 	})
 }
 
+// WithHTTPClient sets the HTTP client used to send the requests, for example
+// a client whose transport dials a Unix domain socket.
+//
+// This option takes precedence over the WithProxy, WithTimeout, and
+// WithTLSClientConfig options as well as the OTEL_EXPORTER_OTLP_CERTIFICATE,
+// OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE, OTEL_EXPORTER_OTLP_TIMEOUT, and
+// OTEL_EXPORTER_OTLP_LOGS_TIMEOUT environment variables. The passed client is
+// used as is, its Timeout and other fields are not modified.
+//
+// By default, if this option is not passed, a client is created from the
+// other options.
+func WithHTTPClient(c *http.Client) Option {
+	return fnOpt(func(cfg config) config {
+		cfg.httpClient = newSetting(c)
+		return cfg
+	})
+}
+
 // setting is a configuration setting value.
 type setting[T any] struct {
 	Value T
@@ -374,46 +554,45 @@ func (s setting[T]) Resolve(fn ...resolver[T]) setting[T] {
 
 // loadEnvTLS returns a resolver that loads a *tls.Config from files defeind by
 // the OTLP TLS environment variables. This will load both the rootCAs and
-// certificates used for mTLS.
+// certificates used for mTLS. They are reloaded when the files change, and the
+// server certificate is verified for the host of endpoint.
 //
 // If the filepath defined is invalid or does not contain valid TLS files, an
 // error is passed to the OTel ErrorHandler and no TLS configuration is
 // provided.
-func loadEnvTLS[T *tls.Config]() resolver[T] {
+func loadEnvTLS[T *tls.Config](endpoint string) resolver[T] {
 	return func(s setting[T]) setting[T] {
 		if s.Set {
 			// Passed, valid, options have precedence.
 			return s
 		}
 
-		var rootCAs *x509.CertPool
-		var err error
+		var files certreload.Files
 		for _, key := range envTLSCert {
 			if v := os.Getenv(key); v != "" {
-				rootCAs, err = loadCertPool(v)
+				files.CA = v
 				break
 			}
 		}
-
-		var certs []tls.Certificate
 		for _, pair := range envTLSClient {
 			cert := os.Getenv(pair.Certificate)
 			key := os.Getenv(pair.Key)
 			if cert != "" && key != "" {
-				var e error
-				certs, e = loadCertificates(cert, key)
-				err = errors.Join(err, e)
+				files.Cert, files.Key = cert, key
 				break
 			}
 		}
+		if files.CA == "" && files.Cert == "" {
+			return s
+		}
 
+		r, err := certreload.New(files, readFile)
 		if err != nil {
-			err = fmt.Errorf("failed to load TLS: %w", err)
-			otel.Handle(err)
-		} else if rootCAs != nil || certs != nil {
-			s.Set = true
-			s.Value = &tls.Config{RootCAs: rootCAs, Certificates: certs}
+			otel.Handle(fmt.Errorf("failed to load TLS: %w", err))
+			return s
 		}
+		s.Set = true
+		s.Value = r.TLSConfig(endpoint)
 		return s
 	}
 }
@@ -421,38 +600,6 @@ func loadEnvTLS[T *tls.Config]() resolver[T] {
 // readFile is used for testing.
 var readFile = os.ReadFile
 
-// loadCertPool loads and returns the *x509.CertPool found at path if it exists
-// and is valid. Otherwise, nil and an error is returned.
-func loadCertPool(path string) (*x509.CertPool, error) {
-	b, err := readFile(path)
-	if err != nil {
-		return nil, err
-	}
-	cp := x509.NewCertPool()
-	if ok := cp.AppendCertsFromPEM(b); !ok {
-		return nil, errors.New("certificate not added")
-	}
-	return cp, nil
-}
-
-// loadCertificates loads and returns the tls.Certificate found at path if it
-// exists and is valid. Otherwise, nil and an error is returned.
-func loadCertificates(certPath, keyPath string) ([]tls.Certificate, error) {
-	cert, err := readFile(certPath)
-	if err != nil {
-		return nil, err
-	}
-	key, err := readFile(keyPath)
-	if err != nil {
-		return nil, err
-	}
-	crt, err := tls.X509KeyPair(cert, key)
-	if err != nil {
-		return nil, err
-	}
-	return []tls.Certificate{crt}, nil
-}
-
 // getenv returns a resolver that will apply an environment variable value
 // associated with the first set key to a setting value. The conv function is
 // used to convert between the environment variable value and the setting type.
@@ -568,12 +715,30 @@ func convCompression(s string) (Compression, error) {
 	switch s {
 	case "gzip":
 		return GzipCompression, nil
+	case compress.ZstdName:
+		return ZstdCompression, nil
+	case compress.SnappyName:
+		return SnappyCompression, nil
 	case "none", "":
 		return NoCompression, nil
 	}
 	return NoCompression, fmt.Errorf("unknown compression: %s", s)
 }
 
+// convEncoding returns the encoding for the OTLP protocol s. ProtobufEncoding
+// and an error are returned if s is unknown.
+func convEncoding(s string) (Encoding, error) {
+	switch strings.TrimSpace(s) {
+	case "http/json":
+		return JSONEncoding, nil
+	case "http/protobuf", "grpc":
+		// The gRPC protocol is not supported by this exporter, it is
+		// ignored to allow sharing the configuration with gRPC exporters.
+		return ProtobufEncoding, nil
+	}
+	return ProtobufEncoding, fmt.Errorf("unknown protocol: %s", s)
+}
+
 // convDuration converts s into a duration of milliseconds. If s does not
 // contain an integer, 0 and an error are returned.
 func convDuration(s string) (time.Duration, error) {
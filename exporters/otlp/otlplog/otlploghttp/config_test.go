@@ -109,6 +109,7 @@ func TestNewConfig(t *testing.T) {
 				WithHeaders(headers),
 				WithTimeout(time.Second),
 				WithRetry(RetryConfig(rc)),
+				WithEncoding(JSONEncoding),
 				// Do not test WithProxy. Requires func comparison.
 			},
 			want: config{
@@ -118,6 +119,7 @@ func TestNewConfig(t *testing.T) {
 				tlsCfg:      newSetting(tlsCfg),
 				headers:     newSetting(headers),
 				compression: newSetting(GzipCompression),
+				encoding:    newSetting(JSONEncoding),
 				timeout:     newSetting(time.Second),
 				retryCfg:    newSetting(rc),
 			},
@@ -173,6 +175,7 @@ func TestNewConfig(t *testing.T) {
 				"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT":           "https://env.endpoint:8080/prefix",
 				"OTEL_EXPORTER_OTLP_LOGS_HEADERS":            "a=A",
 				"OTEL_EXPORTER_OTLP_LOGS_COMPRESSION":        "gzip",
+				"OTEL_EXPORTER_OTLP_LOGS_PROTOCOL":           "http/json",
 				"OTEL_EXPORTER_OTLP_LOGS_TIMEOUT":            "15000",
 				"OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE":        "cert_path",
 				"OTEL_EXPORTER_OTLP_LOGS_CLIENT_CERTIFICATE": "cert_path",
@@ -185,6 +188,7 @@ func TestNewConfig(t *testing.T) {
 				tlsCfg:      newSetting(tlsCfg),
 				headers:     newSetting(headers),
 				compression: newSetting(GzipCompression),
+				encoding:    newSetting(JSONEncoding),
 				timeout:     newSetting(15 * time.Second),
 				retryCfg:    newSetting(defaultRetryCfg),
 			},
@@ -208,6 +212,7 @@ func TestNewConfig(t *testing.T) {
 				"OTEL_EXPORTER_OTLP_ENDPOINT":           "http://env.endpoint:8080/prefix",
 				"OTEL_EXPORTER_OTLP_HEADERS":            "a=A",
 				"OTEL_EXPORTER_OTLP_COMPRESSION":        "none",
+				"OTEL_EXPORTER_OTLP_PROTOCOL":           "http/protobuf",
 				"OTEL_EXPORTER_OTLP_TIMEOUT":            "15000",
 				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "cert_path",
 				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "cert_path",
@@ -220,6 +225,7 @@ func TestNewConfig(t *testing.T) {
 				tlsCfg:      newSetting(tlsCfg),
 				headers:     newSetting(headers),
 				compression: newSetting(NoCompression),
+				encoding:    newSetting(ProtobufEncoding),
 				timeout:     newSetting(15 * time.Second),
 				retryCfg:    newSetting(defaultRetryCfg),
 			},
@@ -237,6 +243,33 @@ func TestNewConfig(t *testing.T) {
 				retryCfg: newSetting(defaultRetryCfg),
 			},
 		},
+		{
+			name: "CompressionEnvironmentVariables",
+			envars: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd",
+			},
+			want: config{
+				endpoint:    newSetting(defaultEndpoint),
+				path:        newSetting(defaultPath),
+				compression: newSetting(ZstdCompression),
+				timeout:     newSetting(defaultTimeout),
+				retryCfg:    newSetting(defaultRetryCfg),
+			},
+		},
+		{
+			name: "SignalCompressionEnvironmentVariables",
+			envars: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION":      "zstd",
+				"OTEL_EXPORTER_OTLP_LOGS_COMPRESSION": "snappy",
+			},
+			want: config{
+				endpoint:    newSetting(defaultEndpoint),
+				path:        newSetting(defaultPath),
+				compression: newSetting(SnappyCompression),
+				timeout:     newSetting(defaultTimeout),
+				retryCfg:    newSetting(defaultRetryCfg),
+			},
+		},
 		{
 			name: "EnvironmentVariablesPrecedence",
 			envars: map[string]string{
@@ -313,6 +346,7 @@ func TestNewConfig(t *testing.T) {
 				"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT":           "%invalid",
 				"OTEL_EXPORTER_OTLP_LOGS_HEADERS":            "a,%ZZ=valid,key=%ZZ",
 				"OTEL_EXPORTER_OTLP_LOGS_COMPRESSION":        "xz",
+				"OTEL_EXPORTER_OTLP_LOGS_PROTOCOL":           "http/xml",
 				"OTEL_EXPORTER_OTLP_LOGS_TIMEOUT":            "100 seconds",
 				"OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE":        "invalid_cert",
 				"OTEL_EXPORTER_OTLP_LOGS_CLIENT_CERTIFICATE": "invalid_cert",
@@ -334,6 +368,7 @@ func TestNewConfig(t *testing.T) {
 				`invalid header key: %ZZ`,
 				`invalid header value: %ZZ`,
 				`invalid OTEL_EXPORTER_OTLP_LOGS_COMPRESSION value xz: unknown compression: xz`,
+				`invalid OTEL_EXPORTER_OTLP_LOGS_PROTOCOL value http/xml: unknown protocol: http/xml`,
 				`invalid OTEL_EXPORTER_OTLP_LOGS_TIMEOUT value 100 seconds: strconv.Atoi: parsing "100 seconds": invalid syntax`,
 			},
 		},
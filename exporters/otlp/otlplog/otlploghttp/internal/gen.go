@@ -3,9 +3,29 @@
 
 package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal"
 
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/json.go.tmpl "--data={}" --out=json.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/json_test.go.tmpl "--data={}" --out=json_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/auth.go.tmpl "--data={}" --out=auth/auth.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/auth_test.go.tmpl "--data={}" --out=auth/auth_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/certreload/certreload.go.tmpl "--data={}" --out=certreload/certreload.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/certreload/certreload_test.go.tmpl "--data={}" --out=certreload/certreload_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/compress/compress.go.tmpl "--data={}" --out=compress/compress.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/compress/compress_test.go.tmpl "--data={}" --out=compress/compress_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/observ/observ.go.tmpl "--data={}" --out=observ/observ.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/observ/observ_test.go.tmpl "--data={}" --out=observ/observ_test.go
+
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/retry/retry.go.tmpl "--data={}" --out=retry/retry.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/retry/retry_test.go.tmpl "--data={}" --out=retry/retry_test.go
 
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/split.go.tmpl "--data={}" --out=split/split.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/split_test.go.tmpl "--data={}" --out=split/split_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/logs.go.tmpl "--data={}" --out=split/logs.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/logs_test.go.tmpl "--data={}" --out=split/logs_test.go
+
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/attr_test.go.tmpl "--data={}" --out=transform/attr_test.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log.go.tmpl "--data={}" --out=transform/log.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlplog/transform/log_attr_test.go.tmpl "--data={}" --out=transform/log_attr_test.go
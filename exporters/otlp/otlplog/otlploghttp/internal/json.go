@@ -0,0 +1,154 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/json.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContentTypeJSON is the Content-Type of OTLP/JSON payloads.
+const ContentTypeJSON = "application/json"
+
+var (
+	jsonMarshalOptions = protojson.MarshalOptions{
+		// OTLP/JSON requires enum values to be encoded as integers.
+		UseEnumNumbers: true,
+	}
+	jsonUnmarshalOptions = protojson.UnmarshalOptions{
+		// OTLP/JSON receivers need to ignore unknown fields.
+		DiscardUnknown: true,
+	}
+)
+
+// idKeys are the JSON keys of the OTLP fields holding trace and span IDs.
+//
+// The OTLP/JSON encoding deviates from the Protobuf JSON mapping for these
+// fields: they are encoded as case-insensitive hex strings instead of base64
+// strings.
+var idKeys = map[string]struct{}{
+	"traceId":      {},
+	"spanId":       {},
+	"parentSpanId": {},
+}
+
+// MarshalJSON returns the OTLP/JSON encoding of m.
+func MarshalJSON(m proto.Message) ([]byte, error) {
+	b, err := jsonMarshalOptions.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return convertIDs(b, base64ToHex)
+}
+
+// JSONSize returns the size of the OTLP/JSON encoding of m. Zero is returned
+// if m cannot be encoded.
+func JSONSize(m proto.Message) int {
+	b, err := MarshalJSON(m)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// UnmarshalJSON parses the OTLP/JSON-encoded data and stores the result in m.
+func UnmarshalJSON(data []byte, m proto.Message) error {
+	b, err := convertIDs(data, hexToBase64)
+	if err != nil {
+		return err
+	}
+	return jsonUnmarshalOptions.Unmarshal(b, m)
+}
+
+// convertIDs returns the JSON data with all trace and span ID values
+// converted using conv. The data is returned unmodified if it does not
+// contain any ID.
+func convertIDs(data []byte, conv func(string) (string, error)) ([]byte, error) {
+	if !containsIDKey(data) {
+		return data, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// Do not lose the precision of any number.
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := walkIDs(v, conv); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	// Remove the newline added by Encode.
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+func containsIDKey(data []byte) bool {
+	for k := range idKeys {
+		if bytes.Contains(data, []byte(`"`+k+`"`)) {
+			return true
+		}
+	}
+	return false
+}
+
+// walkIDs converts, in place, the values of all ID keys in v using conv.
+func walkIDs(v any, conv func(string) (string, error)) error {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, e := range val {
+			if s, ok := e.(string); ok {
+				if _, isID := idKeys[k]; isID {
+					c, err := conv(s)
+					if err != nil {
+						return err
+					}
+					val[k] = c
+				}
+				continue
+			}
+			if err := walkIDs(e, conv); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, e := range val {
+			if err := walkIDs(e, conv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func base64ToHex(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hexToBase64(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,172 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/observ/observ_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package observ
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func setup(t *testing.T, endpoint string) (*Instrumentation, *sdkmetric.ManualReader) {
+	t.Helper()
+	r := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(r))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+	return New(mp, "test", "v0.1.0", Spans, "otlp_test_span_exporter", endpoint), r
+}
+
+func collect(t *testing.T, r *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, r.Collect(context.Background(), &rm))
+	got := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		assert.Equal(t, "test", sm.Scope.Name)
+		assert.Equal(t, "v0.1.0", sm.Scope.Version)
+		for _, m := range sm.Metrics {
+			got[m.Name] = m
+		}
+	}
+	return got
+}
+
+// componentName returns the otel.component.name attribute of inst.
+func componentName(t *testing.T, inst *Instrumentation) attribute.KeyValue {
+	t.Helper()
+	for _, kv := range inst.attrs {
+		if kv.Key == ComponentNameKey {
+			return kv
+		}
+	}
+	t.Fatal("no component name")
+	return attribute.KeyValue{}
+}
+
+func TestNilInstrumentation(t *testing.T) {
+	inst := New(nil, "test", "", Spans, "otlp_test_span_exporter", "localhost:4317")
+	require.Nil(t, inst)
+
+	ctx := context.Background()
+	assert.NotPanics(t, func() {
+		inst.RecordExport(ctx, time.Now(), 1, 0, "")
+		inst.RecordRetries(ctx, 1)
+		inst.RecordPayload(ctx, 1, 1)
+		inst.RecordUncompressedPayload(ctx, 1)
+	})
+}
+
+func TestRecordExport(t *testing.T) {
+	inst, r := setup(t, "localhost:4317")
+	component := componentName(t, inst)
+	base := []attribute.KeyValue{
+		ComponentTypeKey.String("otlp_test_span_exporter"),
+		component,
+		semconv.ServerAddress("localhost"),
+		semconv.ServerPort(4317),
+	}
+	with := func(kv ...attribute.KeyValue) attribute.Set {
+		return attribute.NewSet(append(append([]attribute.KeyValue{}, base...), kv...)...)
+	}
+
+	ctx := context.Background()
+	inst.RecordExport(ctx, time.Now(), 10, 0, "")
+	inst.RecordExport(ctx, time.Now(), 5, 2, "")
+	inst.RecordExport(ctx, time.Now(), 3, 0, "UNAVAILABLE")
+	inst.RecordRetries(ctx, 2)
+	inst.RecordPayload(ctx, 100, 40)
+	inst.RecordUncompressedPayload(ctx, 10)
+
+	got := collect(t, r)
+
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "otel.sdk.exporter.span.exported",
+		Description: "The number of spans for which the export has finished, either successful or failed.",
+		Unit:        "{span}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Attributes: with(), Value: 13},
+				{Attributes: with(semconv.ErrorTypeKey.String(ErrorTypeRejected)), Value: 2},
+				{Attributes: with(semconv.ErrorTypeKey.String("UNAVAILABLE")), Value: 3},
+			},
+		},
+	}, got["otel.sdk.exporter.span.exported"], metricdatatest.IgnoreTimestamp())
+
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "otel.sdk.exporter.operation.retries",
+		Description: "The number of times a request was retried.",
+		Unit:        "{retry}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Attributes: with(), Value: 2},
+			},
+		},
+	}, got["otel.sdk.exporter.operation.retries"], metricdatatest.IgnoreTimestamp())
+
+	for name, want := range map[string]int64{
+		"otel.sdk.exporter.payload.uncompressed_size": 110,
+		"otel.sdk.exporter.payload.compressed_size":   40,
+	} {
+		sum, ok := got[name].Data.(metricdata.Sum[int64])
+		require.True(t, ok, name)
+		require.Len(t, sum.DataPoints, 1, name)
+		assert.Equal(t, want, sum.DataPoints[0].Value, name)
+		assert.Equal(t, "By", got[name].Unit, name)
+	}
+
+	h, ok := got["otel.sdk.exporter.operation.duration"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	counts := make(map[attribute.Distinct]uint64)
+	for _, dp := range h.DataPoints {
+		counts[dp.Attributes.Equivalent()] = dp.Count
+	}
+	success, failed := with(), with(semconv.ErrorTypeKey.String("UNAVAILABLE"))
+	assert.Equal(t, map[attribute.Distinct]uint64{
+		success.Equivalent(): 2,
+		failed.Equivalent():  1,
+	}, counts)
+}
+
+func TestComponentName(t *testing.T) {
+	a, _ := setup(t, "")
+	b, _ := setup(t, "")
+	assert.NotEqual(t, componentName(t, a), componentName(t, b), "component names are unique")
+	assert.Len(t, a.attrs, 2, "no server attributes")
+}
+
+func TestServerAttrs(t *testing.T) {
+	assert.Nil(t, serverAttrs(""))
+	assert.Equal(t, []attribute.KeyValue{semconv.ServerAddress("collector")}, serverAttrs("collector"))
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.ServerAddress("::1"),
+		semconv.ServerPort(4318),
+	}, serverAttrs("[::1]:4318"))
+}
+
+func TestErrorType(t *testing.T) {
+	assert.Equal(t, "", ErrorType(nil))
+	assert.Equal(t, ErrorTypeTimeout, ErrorType(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.Equal(t, ErrorTypeOther, ErrorType(errors.New("test")))
+	assert.Equal(t, "Unavailable", ErrorType(status.Error(codes.Unavailable, "test")))
+}
@@ -6,12 +6,16 @@
 
 // Package retry provides request retry functionality that can perform
 // configurable exponential backoff for transient errors and honor any
-// explicit throttle responses received.
+// explicit throttle responses received. Retries can be limited by a budget
+// shared by all requests, and requests can fail fast while a circuit breaker
+// is open.
 package retry // import "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp/internal/retry"
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -42,8 +46,40 @@ type Config struct {
 	// trying to send a request/batch.  Once this value is reached, the data
 	// is discarded.
 	MaxElapsedTime time.Duration
+	// Jitter is the randomization factor applied to the backoff intervals,
+	// so that clients failing at the same time do not retry in lockstep. An
+	// interval d is randomized within [d*(1-Jitter), d*(1+Jitter)]. Values
+	// greater than 1 are handled as 1. If zero, the default factor of 0.5 is
+	// used. A negative value disables the randomization.
+	Jitter float64
+	// RetryBudget limits the retries of all the requests to a ratio of the
+	// requests sent. Every request adds RetryBudget to the budget, which
+	// holds at most 10 retries, and every retry consumes 1. Requests are not
+	// retried while the budget is exhausted. For example, 0.1 allows one
+	// retry every 10 requests once the initial budget of 10 retries is
+	// consumed. If zero, retries are not limited.
+	RetryBudget float64
+	// CircuitBreakerThreshold is the number of consecutive attempts failing
+	// with a retryable error after which the circuit breaker opens. While it
+	// is open, requests fail without being attempted. After
+	// CircuitBreakerCooldown, requests are attempted again and the first
+	// failing one opens the circuit breaker again. Any successful attempt,
+	// or attempt failing with an error that is not retryable, closes it. If
+	// zero, the circuit breaker is disabled.
+	//
+	// The circuit breaker is used even if Enabled is false.
+	CircuitBreakerThreshold int
+	// CircuitBreakerCooldown is the duration the circuit breaker stays open.
+	CircuitBreakerCooldown time.Duration
 }
 
+// ErrCircuitOpen is returned by requests not attempted because the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// budgetCapacity is the maximum number of retries held by a retry budget.
+const budgetCapacity = 10
+
 // RequestFunc wraps a request with retry logic.
 type RequestFunc func(context.Context, func(context.Context) error) error
 
@@ -61,20 +97,61 @@ type EvaluateFunc func(error) (bool, time.Duration)
 // RequestFunc returns a RequestFunc using the evaluate function to determine
 // if requests can be retried and based on the exponential backoff
 // configuration of c.
+//
+// The retry budget and circuit breaker of c are shared by all the requests
+// made with the returned RequestFunc.
 func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
+	var breaker *circuitBreaker
+	if c.CircuitBreakerThreshold > 0 {
+		breaker = &circuitBreaker{
+			threshold: c.CircuitBreakerThreshold,
+			cooldown:  c.CircuitBreakerCooldown,
+		}
+	}
+
 	if !c.Enabled {
+		if breaker == nil {
+			return func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}
+		}
 		return func(ctx context.Context, fn func(context.Context) error) error {
-			return fn(ctx)
+			if !breaker.allow() {
+				return ErrCircuitOpen
+			}
+			err := fn(ctx)
+			if err == nil {
+				breaker.record(false)
+				return nil
+			}
+			retryable, _ := evaluate(err)
+			breaker.record(retryable)
+			return err
 		}
 	}
 
+	var budget *retryBudget
+	if c.RetryBudget > 0 {
+		budget = &retryBudget{ratio: c.RetryBudget, tokens: budgetCapacity}
+	}
+
+	jitter := c.Jitter
+	switch {
+	case jitter == 0:
+		jitter = backoff.DefaultRandomizationFactor
+	case jitter < 0:
+		jitter = 0
+	case jitter > 1:
+		jitter = 1
+	}
+
 	return func(ctx context.Context, fn func(context.Context) error) error {
 		// Do not use NewExponentialBackOff since it calls Reset and the code here
 		// must call Reset after changing the InitialInterval (this saves an
 		// unnecessary call to Now).
 		b := &backoff.ExponentialBackOff{
 			InitialInterval:     c.InitialInterval,
-			RandomizationFactor: backoff.DefaultRandomizationFactor,
+			RandomizationFactor: jitter,
 			Multiplier:          backoff.DefaultMultiplier,
 			MaxInterval:         c.MaxInterval,
 			MaxElapsedTime:      c.MaxElapsedTime,
@@ -82,17 +159,33 @@ func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
 			Clock:               backoff.SystemClock,
 		}
 		b.Reset()
+		budget.deposit()
 
+		var err error
 		for {
-			err := fn(ctx)
+			// The circuit breaker can be opened by concurrent requests while
+			// waiting to retry.
+			if !breaker.allow() {
+				if err != nil {
+					return fmt.Errorf("%w: %w", ErrCircuitOpen, err)
+				}
+				return ErrCircuitOpen
+			}
+
+			err = fn(ctx)
 			if err == nil {
+				breaker.record(false)
 				return nil
 			}
 
 			retryable, throttle := evaluate(err)
+			breaker.record(retryable)
 			if !retryable {
 				return err
 			}
+			if !breaker.allow() {
+				return fmt.Errorf("%w: %w", ErrCircuitOpen, err)
+			}
 
 			bOff := b.NextBackOff()
 			if bOff == backoff.Stop {
@@ -111,6 +204,10 @@ func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
 				delay = throttle
 			}
 
+			if !budget.withdraw() {
+				return fmt.Errorf("retry budget exhausted: %w", err)
+			}
+
 			if ctxErr := waitFunc(ctx, delay); ctxErr != nil {
 				return fmt.Errorf("%w: %w", ctxErr, err)
 			}
@@ -118,6 +215,80 @@ func (c Config) RequestFunc(evaluate EvaluateFunc) RequestFunc {
 	}
 }
 
+// retryBudget limits retries to a ratio of the requests. A nil *retryBudget
+// does not limit retries.
+type retryBudget struct {
+	mu     sync.Mutex
+	ratio  float64
+	tokens float64
+}
+
+// deposit adds the budget of a new request.
+func (b *retryBudget) deposit() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+b.ratio, budgetCapacity)
+}
+
+// withdraw returns if a retry is allowed by the budget, consuming it if so.
+func (b *retryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// circuitBreaker stops attempts for a cooldown after a number of consecutive
+// failures. A nil *circuitBreaker allows all attempts.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// allow returns if an attempt can be made.
+func (cb *circuitBreaker) allow() bool {
+	if cb == nil {
+		return true
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return !nowFunc().Before(cb.openUntil)
+}
+
+// record records the result of an attempt. failed is true if the attempt
+// failed with a retryable error.
+func (cb *circuitBreaker) record(failed bool) {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if !failed {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		cb.openUntil = nowFunc().Add(cb.cooldown)
+	}
+}
+
+// Allow override for testing.
+var nowFunc = time.Now
+
 // Allow override for testing.
 var waitFunc = wait
 
@@ -0,0 +1,76 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/logs_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func resourceLogs(resource string, scopes ...*logpb.ScopeLogs) *logpb.ResourceLogs {
+	return &logpb.ResourceLogs{
+		Resource: &rpb.Resource{Attributes: []*cpb.KeyValue{
+			{
+				Key:   "service.name",
+				Value: &cpb.AnyValue{Value: &cpb.AnyValue_StringValue{StringValue: resource}},
+			},
+		}},
+		ScopeLogs: scopes,
+	}
+}
+
+func scopeLogs(scope string, bodies ...string) *logpb.ScopeLogs {
+	records := make([]*logpb.LogRecord, len(bodies))
+	for i, b := range bodies {
+		records[i] = &logpb.LogRecord{Body: &cpb.AnyValue{Value: &cpb.AnyValue_StringValue{StringValue: b}}}
+	}
+	return &logpb.ScopeLogs{Scope: &cpb.InstrumentationScope{Name: scope}, LogRecords: records}
+}
+
+func TestLogs(t *testing.T) {
+	rls := []*logpb.ResourceLogs{
+		resourceLogs("a", scopeLogs("x", "1", "2"), scopeLogs("y", "3")),
+		resourceLogs("b", scopeLogs("x", "4")),
+	}
+
+	assert.Equal(t, [][]*logpb.ResourceLogs{rls}, Logs(rls, Limits{}))
+
+	got := Logs(rls, Limits{Items: 3})
+	want := [][]*logpb.ResourceLogs{
+		{resourceLogs("a", scopeLogs("x", "1", "2"), scopeLogs("y", "3"))},
+		{resourceLogs("b", scopeLogs("x", "4"))},
+	}
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Truef(t, proto.Equal(
+			&collogpb.ExportLogsServiceRequest{ResourceLogs: want[i]},
+			&collogpb.ExportLogsServiceRequest{ResourceLogs: got[i]},
+		), "request %d", i)
+	}
+
+	got = Logs(rls, Limits{Items: 1})
+	want = [][]*logpb.ResourceLogs{
+		{resourceLogs("a", scopeLogs("x", "1"))},
+		{resourceLogs("a", scopeLogs("x", "2"))},
+		{resourceLogs("a", scopeLogs("y", "3"))},
+		{resourceLogs("b", scopeLogs("x", "4"))},
+	}
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Truef(t, proto.Equal(
+			&collogpb.ExportLogsServiceRequest{ResourceLogs: want[i]},
+			&collogpb.ExportLogsServiceRequest{ResourceLogs: got[i]},
+		), "request %d", i)
+	}
+}
@@ -0,0 +1,120 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/split_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func cutInts(groups [][]int, n int) ([][]int, [][]int) {
+	return cut(groups, n, func(g []int) int { return len(g) }, func(g []int, n int) ([]int, []int) {
+		return g[:n], g[n:]
+	})
+}
+
+// ints returns the groups as a slice.
+func ints(groups ...[]int) [][]int {
+	return groups
+}
+
+func TestCut(t *testing.T) {
+	groups := ints([]int{1, 2}, []int{3, 4, 5}, []int{6})
+
+	head, tail := cutInts(groups, 2)
+	assert.Equal(t, ints([]int{1, 2}), head)
+	assert.Equal(t, ints([]int{3, 4, 5}, []int{6}), tail)
+
+	head, tail = cutInts(groups, 3)
+	assert.Equal(t, ints([]int{1, 2}, []int{3}), head)
+	assert.Equal(t, ints([]int{4, 5}, []int{6}), tail)
+
+	head, tail = cutInts(groups, 6)
+	assert.Equal(t, groups, head)
+	assert.Empty(t, tail)
+}
+
+func TestSplitter(t *testing.T) {
+	s := splitter[[][]int]{
+		count: func(groups [][]int) int {
+			var n int
+			for _, g := range groups {
+				n += len(g)
+			}
+			return n
+		},
+		// Every item is 10 bytes.
+		size: func(groups [][]int, _ func(proto.Message) int) int {
+			var n int
+			for _, g := range groups {
+				n += 10 * len(g)
+			}
+			return n
+		},
+		cut: cutInts,
+	}
+	req := ints([]int{1, 2, 3}, []int{4, 5, 6, 7})
+
+	testcases := []struct {
+		name   string
+		limits Limits
+		want   [][][]int
+	}{
+		{
+			name: "NoLimits",
+			want: [][][]int{req},
+		},
+		{
+			name:   "WithinLimits",
+			limits: Limits{Bytes: 70, Items: 7},
+			want:   [][][]int{req},
+		},
+		{
+			name:   "Items",
+			limits: Limits{Items: 3},
+			want: [][][]int{
+				ints([]int{1, 2, 3}),
+				ints([]int{4, 5, 6}),
+				ints([]int{7}),
+			},
+		},
+		{
+			name:   "Bytes",
+			limits: Limits{Bytes: 40},
+			want: [][][]int{
+				ints([]int{1, 2, 3}),
+				ints([]int{4, 5, 6, 7}),
+			},
+		},
+		{
+			name:   "ItemsAndBytes",
+			limits: Limits{Bytes: 20, Items: 5},
+			want: [][][]int{
+				ints([]int{1, 2}),
+				ints([]int{3}),
+				ints([]int{4, 5}),
+				ints([]int{6, 7}),
+			},
+		},
+		{
+			name:   "ItemTooLarge",
+			limits: Limits{Bytes: 5},
+			want: [][][]int{
+				ints([]int{1}), ints([]int{2}), ints([]int{3}), ints([]int{4}),
+				ints([]int{5}), ints([]int{6}), ints([]int{7}),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, s.split(nil, req, tc.limits))
+		})
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlpmetricfile provides an OTLP metric exporter writing to local
+files.
+
+The metric data is written in the OTLP JSON Lines file format: each export is
+written as an OTLP/JSON encoded ExportMetricsServiceRequest on its own line.
+The written files can be read by the OpenTelemetry Collector otlpjsonfile
+receiver.
+
+By default the metric data is written to the "metrics.jsonl" file of the
+current working directory. The file can be rotated based on its size, see
+[WithMaxSize], or on time, see [WithRotationInterval], and can be compressed,
+see [WithCompression].
+
+Exporter should be created using [New] and used with a [metric.PeriodicReader].
+
+[metric.PeriodicReader]: https://pkg.go.dev/go.opentelemetry.io/otel/sdk/metric#PeriodicReader
+*/
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
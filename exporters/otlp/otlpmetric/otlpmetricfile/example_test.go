@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricfile_test
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func Example() {
+	ctx := context.Background()
+	exp, err := otlpmetricfile.New(
+		ctx,
+		otlpmetricfile.WithPath("/var/log/otel/metrics.jsonl"),
+		otlpmetricfile.WithMaxSize(100<<20), // 100 MiB.
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	meterProvider := metric.NewMeterProvider(metric.WithReader(metric.NewPeriodicReader(exp)))
+	defer func() {
+		if err := meterProvider.Shutdown(ctx); err != nil {
+			panic(err)
+		}
+	}()
+	otel.SetMeterProvider(meterProvider)
+
+	// From here, the meterProvider can be used by instrumentation to collect
+	// telemetry.
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal/filewriter"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal/transform"
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+var errShutdown = errors.New("file exporter is shutdown")
+
+// Exporter is a OpenTelemetry metric Exporter writing OTLP JSON Lines to a
+// file.
+// Exporter must be created with [New].
+type Exporter struct {
+	path string
+
+	// Ensure synchronous access to the writer across all functionality.
+	writerMu sync.Mutex
+	writer   *filewriter.Writer
+
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+}
+
+// Compile-time check Exporter implements [metric.Exporter].
+var _ metric.Exporter = (*Exporter)(nil)
+
+// New returns an OpenTelemetry metric Exporter. The Exporter can be used with
+// a PeriodicReader to write OpenTelemetry metric data to a file. Each export
+// is written as an OTLP/JSON encoded ExportMetricsServiceRequest on its own
+// line of the file.
+//
+// An error is returned if the file cannot be opened.
+func New(_ context.Context, options ...Option) (*Exporter, error) {
+	cfg := newConfig(options)
+	w, err := filewriter.New(cfg.writerConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		path:   cfg.path,
+		writer: w,
+
+		temporalitySelector: cfg.temporalitySelector,
+		aggregationSelector: cfg.aggregationSelector,
+	}, nil
+}
+
+// Temporality returns the Temporality to use for an instrument kind.
+func (e *Exporter) Temporality(k metric.InstrumentKind) metricdata.Temporality {
+	return e.temporalitySelector(k)
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (e *Exporter) Aggregation(k metric.InstrumentKind) metric.Aggregation {
+	return e.aggregationSelector(k)
+}
+
+// Export transforms and writes metric data to the file. Nothing is written if
+// rm does not contain any metric data.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+func (e *Exporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	defer global.Debug("OTLP/file exporter export", "Data", rm)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	otlpRm, err := transform.ResourceMetrics(rm)
+	// Best effort write of transformable metrics.
+	if wErr := e.write(otlpRm); wErr != nil {
+		if err == nil {
+			return fmt.Errorf("failed to write metrics: %w", wErr)
+		}
+		// Merge the two errors.
+		return fmt.Errorf("failed to write incomplete metrics (%w): %w", err, wErr)
+	}
+	return err
+}
+
+func (e *Exporter) write(rm *metricpb.ResourceMetrics) error {
+	e.writerMu.Lock()
+	defer e.writerMu.Unlock()
+
+	if e.writer == nil {
+		return errShutdown
+	}
+	if rm == nil || len(rm.ScopeMetrics) == 0 {
+		return nil
+	}
+
+	b, err := internal.MarshalJSON(&colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{rm},
+	})
+	if err != nil {
+		return err
+	}
+	return e.writer.WriteLine(b)
+}
+
+// ForceFlush does nothing, all exported data is written to the file by
+// Export.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+//
+// This method is safe to call concurrently.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.writerMu.Lock()
+	defer e.writerMu.Unlock()
+	if e.writer == nil {
+		return errShutdown
+	}
+	return nil
+}
+
+// Shutdown closes the file written to.
+//
+// This method returns an error if called after Shutdown.
+// This method returns an error if the method is canceled by the passed context.
+//
+// This method is safe to call concurrently.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.writerMu.Lock()
+	defer e.writerMu.Unlock()
+	if e.writer == nil {
+		return errShutdown
+	}
+	err := e.writer.Close()
+	e.writer = nil
+	return err
+}
+
+// MarshalLog returns logging data about the Exporter.
+func (e *Exporter) MarshalLog() interface{} {
+	return struct {
+		Type string
+		Path string
+	}{
+		Type: "OTLP/file",
+		Path: e.path,
+	}
+}
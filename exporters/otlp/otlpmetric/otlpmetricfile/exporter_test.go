@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricfile
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile/internal"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+)
+
+// readRequests returns the requests written to the file at path.
+func readRequests(t *testing.T, path string, gz bool) []*colmetricpb.ExportMetricsServiceRequest {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var r io.Reader = f
+	if gz {
+		zr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		r = zr
+	}
+
+	var out []*colmetricpb.ExportMetricsServiceRequest
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		req := new(colmetricpb.ExportMetricsServiceRequest)
+		require.NoError(t, internal.UnmarshalJSON(scanner.Bytes(), req))
+		out = append(out, req)
+	}
+	require.NoError(t, scanner.Err())
+	return out
+}
+
+func resourceMetrics(name string) *metricdata.ResourceMetrics {
+	return &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "test"},
+			Metrics: []metricdata.Metrics{{
+				Name: name,
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{Value: 1}},
+				},
+			}},
+		}},
+	}
+}
+
+func TestExporter(t *testing.T) {
+	for name, compression := range map[string]Compression{
+		"NoCompression":   NoCompression,
+		"GzipCompression": GzipCompression,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "metrics.jsonl")
+			exp, err := New(ctx, WithPath(path), WithCompression(compression))
+			require.NoError(t, err)
+
+			require.NoError(t, exp.Export(ctx, resourceMetrics("first")))
+			require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}), "empty")
+			require.NoError(t, exp.Export(ctx, resourceMetrics("second")))
+			require.NoError(t, exp.ForceFlush(ctx))
+			require.NoError(t, exp.Shutdown(ctx))
+
+			reqs := readRequests(t, path, compression == GzipCompression)
+			require.Len(t, reqs, 2, "one line per non-empty export")
+			for i, want := range []string{"first", "second"} {
+				m := reqs[i].GetResourceMetrics()[0].GetScopeMetrics()[0].GetMetrics()[0]
+				assert.Equal(t, want, m.GetName())
+				assert.Equal(t, int64(1), m.GetGauge().GetDataPoints()[0].GetAsInt())
+			}
+		})
+	}
+}
+
+func TestExporterShutdown(t *testing.T) {
+	ctx := context.Background()
+	exp, err := New(ctx, WithPath(filepath.Join(t.TempDir(), "metrics.jsonl")))
+	require.NoError(t, err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, exp.Export(canceled, resourceMetrics("m")), context.Canceled)
+	assert.ErrorIs(t, exp.ForceFlush(canceled), context.Canceled)
+	assert.ErrorIs(t, exp.Shutdown(canceled), context.Canceled)
+
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.ErrorIs(t, exp.Export(ctx, resourceMetrics("m")), errShutdown)
+	assert.ErrorIs(t, exp.ForceFlush(ctx), errShutdown)
+	assert.ErrorIs(t, exp.Shutdown(ctx), errShutdown)
+}
+
+func TestExporterRotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	exp, err := New(ctx, WithPath(filepath.Join(dir, "metrics.jsonl")), WithMaxSize(1))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.Export(ctx, resourceMetrics("m")))
+	}
+	require.NoError(t, exp.Shutdown(ctx))
+
+	files, err := filepath.Glob(filepath.Join(dir, "metrics*.jsonl"))
+	require.NoError(t, err)
+	assert.Len(t, files, 3, "one file per export")
+}
+
+func TestExporterSelectors(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+
+	exp, err := New(ctx, WithPath(path))
+	require.NoError(t, err)
+	assert.Equal(t, metricdata.CumulativeTemporality, exp.Temporality(metric.InstrumentKindCounter))
+	assert.Equal(t, metric.AggregationSum{}, exp.Aggregation(metric.InstrumentKindCounter))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	exp, err = New(
+		ctx,
+		WithPath(path),
+		WithTemporalitySelector(func(metric.InstrumentKind) metricdata.Temporality {
+			return metricdata.DeltaTemporality
+		}),
+		WithAggregationSelector(func(metric.InstrumentKind) metric.Aggregation {
+			return metric.AggregationDrop{}
+		}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, metricdata.DeltaTemporality, exp.Temporality(metric.InstrumentKindCounter))
+	assert.Equal(t, metric.AggregationDrop{}, exp.Aggregation(metric.InstrumentKindCounter))
+	require.NoError(t, exp.Shutdown(ctx))
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+// Version is the current release version of the OpenTelemetry OTLP file metrics exporter in use.
+func Version() string {
+	return "0.50.0"
+}
@@ -5,6 +5,8 @@ package otlpmetricgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpme
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -12,11 +14,16 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/observ"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/oconf"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/split"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
@@ -25,6 +32,17 @@ type client struct {
 	metadata      metadata.MD
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
+	// callOpts are the options of every export call.
+	callOpts []grpc.CallOption
+	// limits are the limits uploads are split to be within.
+	limits split.Limits
+	// inst records the telemetry of the client. It is nil if no
+	// MeterProvider is configured.
+	inst *observ.Instrumentation
+	// measurePayload is true if the payload sizes are measured by the
+	// client. The stats handler of inst cannot be added to a connection
+	// passed with WithGRPCConn, only the size before compression is known.
+	measurePayload bool
 
 	// ourConn keeps track of where conn was created: true if created here in
 	// NewClient, or false if passed with an option. This is important on
@@ -39,20 +57,39 @@ type client struct {
 func newClient(_ context.Context, cfg oconf.Config) (*client, error) {
 	c := &client{
 		exportTimeout: cfg.Metrics.Timeout,
-		requestFunc:   cfg.RetryConfig.RequestFunc(retryable),
+		requestFunc:   cfg.RetryConfig.RequestFunc(retryableCodes(cfg.RetryableGRPCCodes)),
 		conn:          cfg.GRPCConn,
+		limits: split.Limits{
+			Bytes: cfg.Metrics.MaxRequestSize,
+			Items: cfg.Metrics.MaxRequestItems,
+		},
+		inst: observ.New(
+			cfg.MeterProvider,
+			"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc",
+			Version(),
+			observ.DataPoints,
+			"otlp_grpc_metric_exporter",
+			cfg.Metrics.Endpoint,
+		),
 	}
 
 	if len(cfg.Metrics.Headers) > 0 {
 		c.metadata = metadata.New(cfg.Metrics.Headers)
 	}
+	if creds := auth.PerRPCCredentials(cfg.Authenticator); creds != nil {
+		c.callOpts = append(c.callOpts, grpc.PerRPCCredentials(creds))
+	}
 
+	c.measurePayload = c.inst != nil && c.conn != nil
 	if c.conn == nil {
 		// If the caller did not provide a ClientConn when the client was
 		// created, create one using the configuration they did provide.
 		userAgent := "OTel Go OTLP over gRPC metrics exporter/" + Version()
 		dialOpts := []grpc.DialOption{grpc.WithUserAgent(userAgent)}
 		dialOpts = append(dialOpts, cfg.DialOptions...)
+		if c.inst != nil {
+			dialOpts = append(dialOpts, grpc.WithStatsHandler(c.inst.StatsHandler()))
+		}
 
 		conn, err := grpc.NewClient(cfg.Metrics.Endpoint, dialOpts...)
 		if err != nil {
@@ -96,7 +133,8 @@ func (c *client) Shutdown(ctx context.Context) error {
 	return err
 }
 
-// UploadMetrics sends protoMetrics to connected endpoint.
+// UploadMetrics sends protoMetrics to connected endpoint. They are
+// split into several requests if they exceed the configured request limits.
 //
 // Retryable errors from the server will be handled according to any
 // RetryConfig the client was created with.
@@ -105,6 +143,16 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	// ensures this is not called after the Exporter is shutdown. Only thing
 	// to do here is send data.
 
+	var err error
+	for _, rm := range split.Metrics(protoMetrics, c.limits) {
+		err = errors.Join(err, c.uploadMetrics(ctx, rm))
+	}
+	return err
+}
+
+// uploadMetrics sends protoMetrics to the connected endpoint in a single
+// request.
+func (c *client) uploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	select {
 	case <-ctx.Done():
 		// Do not upload if the context is already expired.
@@ -115,14 +163,22 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
-		resp, err := c.msc.Export(iCtx, &colmetricpb.ExportMetricsServiceRequest{
-			ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
-		})
+	start := time.Now()
+	var attempts, rejected int64
+	req := &colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
+	}
+	err := c.requestFunc(ctx, func(iCtx context.Context) error {
+		attempts++
+		if c.measurePayload {
+			c.inst.RecordUncompressedPayload(iCtx, proto.Size(req))
+		}
+		resp, err := c.msc.Export(iCtx, req, c.callOpts...)
 		if resp != nil && resp.PartialSuccess != nil {
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedDataPoints()
 			if n != 0 || msg != "" {
+				rejected = n
 				err := internal.MetricPartialSuccessError(n, msg)
 				otel.Handle(err)
 			}
@@ -134,6 +190,46 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		}
 		return err
 	})
+	c.recordExport(ctx, start, protoMetrics, attempts, rejected, err)
+	return err
+}
+
+// recordExport records the telemetry of an export of protoMetrics that
+// started at start and was attempted attempts times.
+func (c *client) recordExport(ctx context.Context, start time.Time, protoMetrics *metricpb.ResourceMetrics, attempts, rejected int64, err error) {
+	if c.inst == nil {
+		return
+	}
+	c.inst.RecordRetries(ctx, attempts-1)
+
+	code := status.Code(err)
+	var errType string
+	if err != nil {
+		errType = code.String()
+	}
+	c.inst.RecordExport(ctx, start, dataPointCount(protoMetrics), rejected, errType, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+}
+
+// dataPointCount returns the number of data points in rm.
+func dataPointCount(rm *metricpb.ResourceMetrics) int64 {
+	var n int
+	for _, sm := range rm.GetScopeMetrics() {
+		for _, m := range sm.GetMetrics() {
+			switch d := m.GetData().(type) {
+			case *metricpb.Metric_Gauge:
+				n += len(d.Gauge.GetDataPoints())
+			case *metricpb.Metric_Sum:
+				n += len(d.Sum.GetDataPoints())
+			case *metricpb.Metric_Histogram:
+				n += len(d.Histogram.GetDataPoints())
+			case *metricpb.Metric_ExponentialHistogram:
+				n += len(d.ExponentialHistogram.GetDataPoints())
+			case *metricpb.Metric_Summary:
+				n += len(d.Summary.GetDataPoints())
+			}
+		}
+	}
+	return int64(n)
 }
 
 // exportContext returns a copy of parent with an appropriate deadline and
@@ -188,6 +284,24 @@ func retryableGRPCStatus(s *status.Status) (bool, time.Duration) {
 	return false, 0
 }
 
+// retryableCodes returns a function returning if err identifies a request
+// that can be retried, and a duration to wait for if an explicit throttle
+// time is included in err. Only errors with one of the codes cs are
+// retried. The default retryable codes are used if cs is nil.
+func retryableCodes(cs []codes.Code) retry.EvaluateFunc {
+	if cs == nil {
+		return retryable
+	}
+	return func(err error) (bool, time.Duration) {
+		s := status.Convert(err)
+		if !slices.Contains(cs, s.Code()) {
+			return false, 0
+		}
+		_, d := throttleDelay(s)
+		return true, d
+	}
+}
+
 // throttleDelay returns if the status is RetryInfo
 // and the duration to wait for if an explicit throttle time is included.
 func throttleDelay(s *status.Status) (bool, time.Duration) {
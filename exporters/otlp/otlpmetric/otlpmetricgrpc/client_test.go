@@ -5,6 +5,9 @@ package otlpmetricgrpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -20,6 +23,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/otest"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 )
 
 func TestThrottleDelay(t *testing.T) {
@@ -126,6 +130,27 @@ func TestRetryable(t *testing.T) {
 	}
 }
 
+func TestRetryableCodes(t *testing.T) {
+	evaluate := retryableCodes([]codes.Code{codes.Internal, codes.ResourceExhausted})
+
+	ok, _ := evaluate(status.Error(codes.Internal, ""))
+	assert.True(t, ok, "configured code")
+	ok, _ = evaluate(status.Error(codes.Unavailable, ""))
+	assert.False(t, ok, "default code not configured")
+
+	delay := 15 * time.Millisecond
+	s, err := status.New(codes.ResourceExhausted, "WithRetryInfo").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)},
+	)
+	require.NoError(t, err)
+	ok, d := evaluate(s.Err())
+	assert.True(t, ok, "configured code with RetryInfo")
+	assert.Equal(t, delay, d)
+
+	ok, _ = retryableCodes(nil)(status.Error(codes.Unavailable, ""))
+	assert.True(t, ok, "default codes")
+}
+
 func TestRetryableGRPCStatusResourceExhaustedWithRetryInfo(t *testing.T) {
 	delay := 15 * time.Millisecond
 	s, err := status.New(codes.ResourceExhausted, "WithRetryInfo").WithDetails(
@@ -218,6 +243,65 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, got[key], []string{headers[key]})
 	})
 
+	t.Run("WithAuthenticator", func(t *testing.T) {
+		auth := &countingAuthenticator{}
+		exp, coll := factoryFunc(nil, WithAuthenticator(auth))
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Equal(t, []string{"Bearer token-1"}, coll.Headers()["authorization"])
+		require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, coll.Headers()["authorization"], "called per request")
+
+		auth.err = errors.New("no token")
+		err := exp.Export(ctx, &metricdata.ResourceMetrics{})
+		assert.ErrorContains(t, err, "no token")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("WithMaxRequestItems", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 3)
+		rCh <- otest.ExportResult{Err: status.Error(codes.InvalidArgument, "rejected")}
+		rCh <- otest.ExportResult{}
+		rCh <- otest.ExportResult{}
+		exp, coll := factoryFunc(rCh, WithMaxRequestItems(2))
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		rm := &metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "requests",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Value: 1}, {Value: 2}, {Value: 3}, {Value: 4}, {Value: 5},
+					},
+				},
+			}},
+		}}}
+		err := exp.Export(ctx, rm)
+		assert.ErrorContains(t, err, "rejected", "error of the first request")
+
+		got := coll.Collect().Dump()
+		require.Len(t, got, 3, "requests")
+		var values []int64
+		for _, req := range got {
+			for _, sm := range req.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					assert.Equal(t, "requests", m.Name)
+					for _, dp := range m.GetSum().GetDataPoints() {
+						values = append(values, dp.GetAsInt())
+					}
+				}
+			}
+		}
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, values)
+	})
+
 	t.Run("WithTimeout", func(t *testing.T) {
 		// Do not send on rCh so the Collector never responds to the client.
 		rCh := make(chan otest.ExportResult)
@@ -234,6 +318,51 @@ func TestConfig(t *testing.T) {
 		assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
 	})
 
+	t.Run("WithMeterProvider", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 1)
+		rCh <- otest.ExportResult{Response: &colmetricpb.ExportMetricsServiceResponse{
+			PartialSuccess: &colmetricpb.ExportMetricsPartialSuccess{
+				RejectedDataPoints: 1,
+				ErrorMessage:       "partially successful",
+			},
+		}}
+		reader := metric.NewManualReader()
+		mp := metric.NewMeterProvider(metric.WithReader(reader))
+		exp, coll := factoryFunc(rCh, WithMeterProvider(mp))
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{
+			ScopeMetrics: []metricdata.ScopeMetrics{{
+				Metrics: []metricdata.Metrics{{
+					Name: "gauge",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{{Value: 1}, {Value: 2}},
+					},
+				}},
+			}},
+		}))
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		assert.Equal(t, "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc", rm.ScopeMetrics[0].Scope.Name)
+
+		var exported metricdata.Sum[int64]
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			if m.Name == "otel.sdk.exporter.metric_data_point.exported" {
+				exported = m.Data.(metricdata.Sum[int64])
+			}
+		}
+		byErr := make(map[string]int64)
+		for _, dp := range exported.DataPoints {
+			v, _ := dp.Attributes.Value("error.type")
+			byErr[v.AsString()] += dp.Value
+		}
+		assert.Equal(t, map[string]int64{"": 1, "rejected": 1}, byErr)
+	})
+
 	t.Run("WithCustomUserAgent", func(t *testing.T) {
 		key := "user-agent"
 		customerUserAgent := "custom-user-agent"
@@ -248,3 +377,15 @@ func TestConfig(t *testing.T) {
 		assert.Contains(t, got[key][0], customerUserAgent)
 	})
 }
+
+type countingAuthenticator struct {
+	n   atomic.Int64
+	err error
+}
+
+func (a *countingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", a.n.Add(1))}, nil
+}
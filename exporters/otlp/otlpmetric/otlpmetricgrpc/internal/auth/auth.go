@@ -0,0 +1,38 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/auth.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package auth provides the authentication of OTLP export requests.
+package auth // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/auth"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Authenticator provides the headers used to authenticate an export request.
+//
+// Headers is called before every request sent, including retries. It needs
+// to be safe to call concurrently.
+type Authenticator interface {
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// SetHeaders sets the headers returned by a on header, replacing any existing
+// value. Nothing is done if a is nil.
+func SetHeaders(ctx context.Context, a Authenticator, header http.Header) error {
+	if a == nil {
+		return nil
+	}
+	h, err := a.Headers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate request: %w", err)
+	}
+	for k, v := range h {
+		header.Set(k, v)
+	}
+	return nil
+}
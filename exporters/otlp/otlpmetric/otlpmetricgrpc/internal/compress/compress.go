@@ -0,0 +1,61 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/compress/compress.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package compress provides the zstd and snappy compression of OTLP payloads.
+package compress // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/compress"
+
+import (
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// ZstdName is the name of the zstd compression. It is used as the
+	// Content-Encoding of HTTP requests and as the name of the gRPC
+	// compressor.
+	ZstdName = "zstd"
+	// SnappyName is the name of the snappy compression. It is used as the
+	// Content-Encoding of HTTP requests and as the name of the gRPC
+	// compressor.
+	SnappyName = "snappy"
+)
+
+var (
+	zstdEncoderOnce sync.Once
+	zstdEncoder     *zstd.Encoder
+	errZstdEncoder  error
+)
+
+// encoder returns the zstd encoder shared by all calls to Zstd. A zstd
+// Encoder can be used concurrently to encode whole payloads.
+func encoder() (*zstd.Encoder, error) {
+	zstdEncoderOnce.Do(func() {
+		zstdEncoder, errZstdEncoder = zstd.NewWriter(nil,
+			zstd.WithEncoderConcurrency(1),
+			zstd.WithZeroFrames(true),
+		)
+	})
+	return zstdEncoder, errZstdEncoder
+}
+
+// Zstd returns data compressed as a single zstd frame appended to dst.
+func Zstd(dst, data []byte) ([]byte, error) {
+	enc, err := encoder()
+	if err != nil {
+		return nil, err
+	}
+	return enc.EncodeAll(data, dst), nil
+}
+
+// Snappy returns data compressed using the snappy block format.
+//
+// The block format is the format expected by the OpenTelemetry Collector for
+// the snappy Content-Encoding of HTTP requests.
+func Snappy(data []byte) []byte {
+	return snappy.Encode(nil, data)
+}
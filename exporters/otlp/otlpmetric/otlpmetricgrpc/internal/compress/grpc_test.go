@@ -0,0 +1,48 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/compress/grpc_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compress
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestGRPCCompressors(t *testing.T) {
+	for _, name := range []string{ZstdName, SnappyName} {
+		t.Run(name, func(t *testing.T) {
+			c := encoding.GetCompressor(name)
+			require.NotNil(t, c, "compressor not registered")
+			assert.Equal(t, name, c.Name())
+
+			// Run twice to use pooled writers and readers.
+			for i := 0; i < 2; i++ {
+				var buf bytes.Buffer
+				w, err := c.Compress(&buf)
+				require.NoError(t, err)
+				_, err = w.Write(payload)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+				assert.Less(t, buf.Len(), len(payload), "not compressed")
+
+				r, err := c.Decompress(&buf)
+				require.NoError(t, err)
+				got, err := io.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, payload, got)
+
+				n, err := r.Read(make([]byte, 1))
+				assert.Equal(t, 0, n)
+				assert.ErrorIs(t, err, io.EOF, "read after EOF")
+			}
+		})
+	}
+}
@@ -6,15 +6,37 @@ package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/o
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/partialsuccess.go.tmpl "--data={}" --out=partialsuccess.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/partialsuccess_test.go.tmpl "--data={}" --out=partialsuccess_test.go
 
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/auth.go.tmpl "--data={}" --out=auth/auth.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/auth_test.go.tmpl "--data={}" --out=auth/auth_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/grpc.go.tmpl "--data={}" --out=auth/grpc.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/auth/grpc_test.go.tmpl "--data={}" --out=auth/grpc_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/certreload/certreload.go.tmpl "--data={}" --out=certreload/certreload.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/certreload/certreload_test.go.tmpl "--data={}" --out=certreload/certreload_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/compress/compress.go.tmpl "--data={}" --out=compress/compress.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/compress/compress_test.go.tmpl "--data={}" --out=compress/compress_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/compress/grpc.go.tmpl "--data={}" --out=compress/grpc.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/compress/grpc_test.go.tmpl "--data={}" --out=compress/grpc_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/observ/observ.go.tmpl "--data={}" --out=observ/observ.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/observ/observ_test.go.tmpl "--data={}" --out=observ/observ_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/observ/grpc.go.tmpl "--data={}" --out=observ/grpc.go
+
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/retry/retry.go.tmpl "--data={}" --out=retry/retry.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/retry/retry_test.go.tmpl "--data={}" --out=retry/retry_test.go
 
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/split.go.tmpl "--data={}" --out=split/split.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/split_test.go.tmpl "--data={}" --out=split/split_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/metrics.go.tmpl "--data={}" --out=split/metrics.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/split/metrics_test.go.tmpl "--data={}" --out=split/metrics_test.go
+
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/envconfig/envconfig.go.tmpl "--data={}" --out=envconfig/envconfig.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/envconfig/envconfig_test.go.tmpl "--data={}" --out=envconfig/envconfig_test.go
 
-//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/oconf/envconfig.go.tmpl "--data={\"envconfigImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/envconfig\"}" --out=oconf/envconfig.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/oconf/envconfig.go.tmpl "--data={\"certreloadImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/certreload\", \"envconfigImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/envconfig\"}" --out=oconf/envconfig.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/oconf/envconfig_test.go.tmpl "--data={}" --out=oconf/envconfig_test.go
-//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/oconf/options.go.tmpl "--data={\"retryImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/retry\"}" --out=oconf/options.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/oconf/options.go.tmpl "--data={\"authImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/auth\", \"compressImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/compress\", \"retryImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/retry\"}" --out=oconf/options.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/oconf/options_test.go.tmpl "--data={\"envconfigImportPath\": \"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/envconfig\"}" --out=oconf/options_test.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/oconf/optiontypes.go.tmpl "--data={}" --out=oconf/optiontypes.go
 //go:generate gotmpl --body=../../../../../internal/shared/otlp/otlpmetric/oconf/tls.go.tmpl "--data={}" --out=oconf/tls.go
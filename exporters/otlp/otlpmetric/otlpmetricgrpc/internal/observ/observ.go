@@ -0,0 +1,276 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/observ/observ.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package observ provides the instrumentation an OTLP exporter uses to report
+// telemetry about its own operation.
+package observ // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/observ"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	// ComponentTypeKey is the attribute Key identifying the type of the
+	// exporter, e.g. "otlp_grpc_span_exporter".
+	ComponentTypeKey = attribute.Key("otel.component.type")
+	// ComponentNameKey is the attribute Key uniquely identifying the
+	// exporter instance within the process, e.g.
+	// "otlp_grpc_span_exporter/0".
+	ComponentNameKey = attribute.Key("otel.component.name")
+
+	// ErrorTypeRejected is the error.type value of items rejected by the
+	// receiving endpoint in a partial success response.
+	ErrorTypeRejected = "rejected"
+	// ErrorTypeTimeout is the error.type value of exports that did not
+	// complete before their deadline.
+	ErrorTypeTimeout = "timeout"
+	// ErrorTypeOther is the error.type value of errors that have neither a
+	// gRPC status nor an HTTP response status code, e.g. connection errors.
+	ErrorTypeOther = "_OTHER"
+)
+
+// Item is the kind of telemetry item an exporter exports.
+type Item int
+
+const (
+	// Spans are exported by trace exporters.
+	Spans Item = iota
+	// DataPoints are exported by metric exporters.
+	DataPoints
+	// LogRecords are exported by log exporters.
+	LogRecords
+)
+
+// exportedCounter returns the name, unit, and description of the counter of
+// exported items.
+func (i Item) exportedCounter() (name, unit, desc string) {
+	switch i {
+	case DataPoints:
+		return "otel.sdk.exporter.metric_data_point.exported",
+			"{data_point}",
+			"The number of metric data points for which the export has finished, either successful or failed."
+	case LogRecords:
+		return "otel.sdk.exporter.log.exported",
+			"{log_record}",
+			"The number of log records for which the export has finished, either successful or failed."
+	default:
+		return "otel.sdk.exporter.span.exported",
+			"{span}",
+			"The number of spans for which the export has finished, either successful or failed."
+	}
+}
+
+// componentIDs holds the next identifier of each component type.
+var componentIDs [3]atomic.Int64
+
+// Instrumentation records the telemetry of an exporter.
+//
+// A nil *Instrumentation is valid and records nothing. It is what New returns
+// when no MeterProvider is configured, so exporters without self-telemetry
+// have no overhead.
+type Instrumentation struct {
+	exported     metric.Int64Counter
+	duration     metric.Float64Histogram
+	retries      metric.Int64Counter
+	uncompressed metric.Int64Counter
+	compressed   metric.Int64Counter
+
+	// attrs are the attributes common to all measurements.
+	attrs []attribute.KeyValue
+	set   metric.MeasurementOption
+}
+
+// New returns the Instrumentation of an exporter of item with the
+// componentType (e.g. "otlp_grpc_span_exporter") sending to endpoint. The
+// instruments are created with a Meter named scope, with version, from mp.
+//
+// If mp is nil, nil is returned. Errors creating instruments are sent to the
+// global error handler and the affected instruments do not record.
+func New(mp metric.MeterProvider, scope, version string, item Item, componentType, endpoint string) *Instrumentation {
+	if mp == nil {
+		return nil
+	}
+
+	id := componentIDs[item].Add(1) - 1
+	attrs := []attribute.KeyValue{
+		ComponentTypeKey.String(componentType),
+		ComponentNameKey.String(componentType + "/" + strconv.FormatInt(id, 10)),
+	}
+	attrs = append(attrs, serverAttrs(endpoint)...)
+
+	m := mp.Meter(scope, metric.WithInstrumentationVersion(version))
+	inst := &Instrumentation{
+		attrs: attrs,
+		set:   metric.WithAttributeSet(attribute.NewSet(attrs...)),
+	}
+
+	var err, e error
+	name, unit, desc := item.exportedCounter()
+	inst.exported, e = m.Int64Counter(
+		name,
+		metric.WithUnit(unit),
+		metric.WithDescription(desc),
+	)
+	err = errors.Join(err, e)
+	inst.duration, e = m.Float64Histogram(
+		"otel.sdk.exporter.operation.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("The duration of exporting a batch of telemetry records."),
+	)
+	err = errors.Join(err, e)
+	inst.retries, e = m.Int64Counter(
+		"otel.sdk.exporter.operation.retries",
+		metric.WithUnit("{retry}"),
+		metric.WithDescription("The number of times a request was retried."),
+	)
+	err = errors.Join(err, e)
+	inst.uncompressed, e = m.Int64Counter(
+		"otel.sdk.exporter.payload.uncompressed_size",
+		metric.WithUnit("By"),
+		metric.WithDescription("The size of the sent payloads before compression."),
+	)
+	err = errors.Join(err, e)
+	inst.compressed, e = m.Int64Counter(
+		"otel.sdk.exporter.payload.compressed_size",
+		metric.WithUnit("By"),
+		metric.WithDescription("The size of the sent payloads after compression."),
+	)
+	err = errors.Join(err, e)
+	if err != nil {
+		otel.Handle(fmt.Errorf("failed to create exporter instruments: %w", err))
+	}
+	return inst
+}
+
+// serverAttrs returns the server.address and server.port attributes of
+// endpoint, a "host:port" or "host" string.
+func serverAttrs(endpoint string) []attribute.KeyValue {
+	if endpoint == "" {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return []attribute.KeyValue{semconv.ServerAddress(endpoint)}
+	}
+	attrs := []attribute.KeyValue{semconv.ServerAddress(host)}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, semconv.ServerPort(p))
+	}
+	return attrs
+}
+
+// ErrorType returns the error.type value describing err. The empty string is
+// returned if err is nil. The gRPC status code name is returned for errors
+// with a gRPC status. Callers use the response status code instead for
+// errors of HTTP responses.
+func ErrorType(err error) string {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorTypeTimeout
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code().String()
+	}
+	return ErrorTypeOther
+}
+
+// RecordExport records the completion of an export of n items that started
+// at start. The export failed if errType is not empty. Otherwise, rejected is
+// the number of items the endpoint reported as rejected in a partial success
+// response. The extra attributes are added to the operation duration, e.g.
+// the response status code.
+func (i *Instrumentation) RecordExport(ctx context.Context, start time.Time, n, rejected int64, errType string, extra ...attribute.KeyValue) {
+	if i == nil {
+		return
+	}
+	elapsed := time.Since(start).Seconds()
+
+	if errType != "" {
+		opt := i.withAttrs(semconv.ErrorTypeKey.String(errType))
+		if i.exported != nil {
+			i.exported.Add(ctx, n, opt)
+		}
+		if i.duration != nil {
+			attrs := append([]attribute.KeyValue{semconv.ErrorTypeKey.String(errType)}, extra...)
+			i.duration.Record(ctx, elapsed, i.withAttrs(attrs...))
+		}
+		return
+	}
+
+	if i.exported != nil {
+		if rejected > n {
+			rejected = n
+		}
+		if ok := n - rejected; ok > 0 {
+			i.exported.Add(ctx, ok, i.set)
+		}
+		if rejected > 0 {
+			i.exported.Add(ctx, rejected, i.withAttrs(semconv.ErrorTypeKey.String(ErrorTypeRejected)))
+		}
+	}
+	if i.duration != nil {
+		opt := i.set
+		if len(extra) > 0 {
+			opt = i.withAttrs(extra...)
+		}
+		i.duration.Record(ctx, elapsed, opt)
+	}
+}
+
+// RecordRetries records n retried requests.
+func (i *Instrumentation) RecordRetries(ctx context.Context, n int64) {
+	if i == nil || i.retries == nil || n <= 0 {
+		return
+	}
+	i.retries.Add(ctx, n, i.set)
+}
+
+// RecordPayload records the size of a sent payload before and after its
+// compression. When the payload is not compressed, both sizes are equal.
+func (i *Instrumentation) RecordPayload(ctx context.Context, uncompressed, compressed int) {
+	if i == nil {
+		return
+	}
+	if i.uncompressed != nil {
+		i.uncompressed.Add(ctx, int64(uncompressed), i.set)
+	}
+	if i.compressed != nil {
+		i.compressed.Add(ctx, int64(compressed), i.set)
+	}
+}
+
+// RecordUncompressedPayload records the size of a sent payload before its
+// compression. It is used when the size after compression is not known.
+func (i *Instrumentation) RecordUncompressedPayload(ctx context.Context, uncompressed int) {
+	if i == nil || i.uncompressed == nil {
+		return
+	}
+	i.uncompressed.Add(ctx, int64(uncompressed), i.set)
+}
+
+// withAttrs returns a measurement option with the common attributes and
+// attrs.
+func (i *Instrumentation) withAttrs(attrs ...attribute.KeyValue) metric.MeasurementOption {
+	all := make([]attribute.KeyValue, 0, len(i.attrs)+len(attrs))
+	all = append(all, i.attrs...)
+	all = append(all, attrs...)
+	return metric.WithAttributeSet(attribute.NewSet(all...))
+}
@@ -7,6 +7,7 @@
 package oconf
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/url"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/envconfig"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
@@ -252,6 +254,8 @@ func TestConfigs(t *testing.T) {
 				} else {
 					// nolint:staticcheck // ignoring tlsCert.RootCAs.Subjects is deprecated ERR because cert does not come from SystemCertPool.
 					assert.Equal(t, tlsCert.RootCAs.Subjects(), c.Metrics.TLSCfg.RootCAs.Subjects())
+					// The server certificate is verified for the endpoint host.
+					assert.Equal(t, "localhost", c.Metrics.TLSCfg.ServerName)
 				}
 			},
 		},
@@ -331,6 +335,60 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		// MeterProvider Tests
+		{
+			name: "Test Without MeterProvider",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Nil(t, c.MeterProvider)
+			},
+		},
+		{
+			name: "Test With MeterProvider",
+			opts: []GenericOption{
+				WithMeterProvider(noop.NewMeterProvider()),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, noop.NewMeterProvider(), c.MeterProvider)
+			},
+		},
+
+		// Authenticator Tests
+		{
+			name: "Test Without Authenticator",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Nil(t, c.Authenticator)
+			},
+		},
+		{
+			name: "Test With Authenticator",
+			opts: []GenericOption{
+				WithAuthenticator(testAuthenticator{}),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, testAuthenticator{}, c.Authenticator)
+			},
+		},
+
+		// Request size Tests
+		{
+			name: "Test Without Request Limits",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Zero(t, c.Metrics.MaxRequestSize)
+				assert.Zero(t, c.Metrics.MaxRequestItems)
+			},
+		},
+		{
+			name: "Test With Request Limits",
+			opts: []GenericOption{
+				WithMaxRequestSize(4 << 20),
+				WithMaxRequestItems(1000),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, 4<<20, c.Metrics.MaxRequestSize)
+				assert.Equal(t, 1000, c.Metrics.MaxRequestItems)
+			},
+		},
+
 		// Compression Tests
 		{
 			name: "Test With Compression",
@@ -359,6 +417,25 @@ func TestConfigs(t *testing.T) {
 				assert.Equal(t, GzipCompression, c.Metrics.Compression)
 			},
 		},
+		{
+			name: "Test Environment Zstd Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, ZstdCompression, c.Metrics.Compression)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Snappy Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION":         "zstd",
+				"OTEL_EXPORTER_OTLP_METRICS_COMPRESSION": "snappy",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, SnappyCompression, c.Metrics.Compression)
+			},
+		},
 		{
 			name: "Test Mixed Environment and With Compression",
 			opts: []GenericOption{
@@ -372,6 +449,63 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		// Marshaler Tests
+		{
+			name: "Test Default Marshaler",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test With Marshaler",
+			opts: []GenericOption{
+				WithMarshal(MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         "http/json",
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment gRPC Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Mixed Environment and With Marshaler",
+			opts: []GenericOption{
+				WithMarshal(MarshalProto),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+
 		// Timeout Tests
 		{
 			name: "Test With Timeout",
@@ -581,3 +715,9 @@ func TestCleanPath(t *testing.T) {
 		})
 	}
 }
+
+type testAuthenticator struct{}
+
+func (testAuthenticator) Headers(context.Context) (map[string]string, error) {
+	return map[string]string{"Authorization": "Bearer token"}, nil
+}
@@ -27,6 +27,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
@@ -341,6 +343,29 @@ func (c *HTTPCollector) readBody(r *http.Request) (body []byte, err error) {
 				Status: http.StatusInternalServerError,
 			}
 		}
+	case "zstd":
+		var dec *zstd.Decoder
+		dec, err = zstd.NewReader(r.Body)
+		if err != nil {
+			return nil, &HTTPResponseError{
+				Err:    err,
+				Status: http.StatusInternalServerError,
+			}
+		}
+		reader = dec.IOReadCloser()
+	case "snappy":
+		var data []byte
+		data, err = io.ReadAll(r.Body)
+		if err == nil {
+			data, err = snappy.Decode(nil, data)
+		}
+		if err != nil {
+			return nil, &HTTPResponseError{
+				Err:    err,
+				Status: http.StatusInternalServerError,
+			}
+		}
+		reader = io.NopCloser(bytes.NewReader(data))
 	default:
 		reader = r.Body
 	}
@@ -0,0 +1,114 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/metrics.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/split"
+
+import (
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var metrics = splitter[[]*metricpb.ResourceMetrics]{
+	count: func(rms []*metricpb.ResourceMetrics) int {
+		var n int
+		for _, rm := range rms {
+			n += resourceDataPointCount(rm)
+		}
+		return n
+	},
+	size: func(rms []*metricpb.ResourceMetrics, size func(proto.Message) int) int {
+		return size(&colmetricpb.ExportMetricsServiceRequest{ResourceMetrics: rms})
+	},
+	cut: func(rms []*metricpb.ResourceMetrics, n int) ([]*metricpb.ResourceMetrics, []*metricpb.ResourceMetrics) {
+		return cut(rms, n, resourceDataPointCount, cutResourceMetrics)
+	},
+}
+
+// Metrics returns the resource metrics of the requests rm is split in to be
+// within l. A metric is split in several metrics with the same name and
+// properties if its data points are split.
+func Metrics(rm *metricpb.ResourceMetrics, l Limits) []*metricpb.ResourceMetrics {
+	parts := metrics.split(nil, []*metricpb.ResourceMetrics{rm}, l)
+	out := make([]*metricpb.ResourceMetrics, 0, len(parts))
+	for _, p := range parts {
+		// Parts contain a single resource as they are split from one.
+		out = append(out, p...)
+	}
+	return out
+}
+
+func resourceDataPointCount(rm *metricpb.ResourceMetrics) int {
+	var n int
+	for _, sm := range rm.GetScopeMetrics() {
+		n += scopeDataPointCount(sm)
+	}
+	return n
+}
+
+func scopeDataPointCount(sm *metricpb.ScopeMetrics) int {
+	var n int
+	for _, m := range sm.GetMetrics() {
+		n += dataPointCount(m)
+	}
+	return n
+}
+
+func dataPointCount(m *metricpb.Metric) int {
+	switch d := m.Data.(type) {
+	case *metricpb.Metric_Gauge:
+		return len(d.Gauge.GetDataPoints())
+	case *metricpb.Metric_Sum:
+		return len(d.Sum.GetDataPoints())
+	case *metricpb.Metric_Histogram:
+		return len(d.Histogram.GetDataPoints())
+	case *metricpb.Metric_ExponentialHistogram:
+		return len(d.ExponentialHistogram.GetDataPoints())
+	case *metricpb.Metric_Summary:
+		return len(d.Summary.GetDataPoints())
+	}
+	return 0
+}
+
+func cutResourceMetrics(rm *metricpb.ResourceMetrics, n int) (*metricpb.ResourceMetrics, *metricpb.ResourceMetrics) {
+	head, tail := cut(rm.ScopeMetrics, n, scopeDataPointCount, cutScopeMetrics)
+	return &metricpb.ResourceMetrics{Resource: rm.Resource, ScopeMetrics: head, SchemaUrl: rm.SchemaUrl},
+		&metricpb.ResourceMetrics{Resource: rm.Resource, ScopeMetrics: tail, SchemaUrl: rm.SchemaUrl}
+}
+
+func cutScopeMetrics(sm *metricpb.ScopeMetrics, n int) (*metricpb.ScopeMetrics, *metricpb.ScopeMetrics) {
+	head, tail := cut(sm.Metrics, n, dataPointCount, cutMetric)
+	return &metricpb.ScopeMetrics{Scope: sm.Scope, Metrics: head, SchemaUrl: sm.SchemaUrl},
+		&metricpb.ScopeMetrics{Scope: sm.Scope, Metrics: tail, SchemaUrl: sm.SchemaUrl}
+}
+
+func cutMetric(m *metricpb.Metric, n int) (*metricpb.Metric, *metricpb.Metric) {
+	head := &metricpb.Metric{Name: m.Name, Description: m.Description, Unit: m.Unit, Metadata: m.Metadata}
+	tail := &metricpb.Metric{Name: m.Name, Description: m.Description, Unit: m.Unit, Metadata: m.Metadata}
+	switch d := m.Data.(type) {
+	case *metricpb.Metric_Gauge:
+		dp := d.Gauge.DataPoints
+		head.Data = &metricpb.Metric_Gauge{Gauge: &metricpb.Gauge{DataPoints: dp[:n]}}
+		tail.Data = &metricpb.Metric_Gauge{Gauge: &metricpb.Gauge{DataPoints: dp[n:]}}
+	case *metricpb.Metric_Sum:
+		dp, t, mono := d.Sum.DataPoints, d.Sum.AggregationTemporality, d.Sum.IsMonotonic
+		head.Data = &metricpb.Metric_Sum{Sum: &metricpb.Sum{DataPoints: dp[:n], AggregationTemporality: t, IsMonotonic: mono}}
+		tail.Data = &metricpb.Metric_Sum{Sum: &metricpb.Sum{DataPoints: dp[n:], AggregationTemporality: t, IsMonotonic: mono}}
+	case *metricpb.Metric_Histogram:
+		dp, t := d.Histogram.DataPoints, d.Histogram.AggregationTemporality
+		head.Data = &metricpb.Metric_Histogram{Histogram: &metricpb.Histogram{DataPoints: dp[:n], AggregationTemporality: t}}
+		tail.Data = &metricpb.Metric_Histogram{Histogram: &metricpb.Histogram{DataPoints: dp[n:], AggregationTemporality: t}}
+	case *metricpb.Metric_ExponentialHistogram:
+		dp, t := d.ExponentialHistogram.DataPoints, d.ExponentialHistogram.AggregationTemporality
+		head.Data = &metricpb.Metric_ExponentialHistogram{ExponentialHistogram: &metricpb.ExponentialHistogram{DataPoints: dp[:n], AggregationTemporality: t}}
+		tail.Data = &metricpb.Metric_ExponentialHistogram{ExponentialHistogram: &metricpb.ExponentialHistogram{DataPoints: dp[n:], AggregationTemporality: t}}
+	case *metricpb.Metric_Summary:
+		dp := d.Summary.DataPoints
+		head.Data = &metricpb.Metric_Summary{Summary: &metricpb.Summary{DataPoints: dp[:n]}}
+		tail.Data = &metricpb.Metric_Summary{Summary: &metricpb.Summary{DataPoints: dp[n:]}}
+	}
+	return head, tail
+}
@@ -0,0 +1,145 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/metrics_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func numberPoints(values ...int64) []*metricpb.NumberDataPoint {
+	dps := make([]*metricpb.NumberDataPoint, len(values))
+	for i, v := range values {
+		dps[i] = &metricpb.NumberDataPoint{Value: &metricpb.NumberDataPoint_AsInt{AsInt: v}}
+	}
+	return dps
+}
+
+func sum(values ...int64) *metricpb.Metric {
+	return &metricpb.Metric{
+		Name: "sum",
+		Unit: "1",
+		Data: &metricpb.Metric_Sum{Sum: &metricpb.Sum{
+			DataPoints:             numberPoints(values...),
+			AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+			IsMonotonic:            true,
+		}},
+	}
+}
+
+func gauge(values ...int64) *metricpb.Metric {
+	return &metricpb.Metric{
+		Name: "gauge",
+		Data: &metricpb.Metric_Gauge{Gauge: &metricpb.Gauge{DataPoints: numberPoints(values...)}},
+	}
+}
+
+func histogram(counts ...uint64) *metricpb.Metric {
+	dps := make([]*metricpb.HistogramDataPoint, len(counts))
+	for i, c := range counts {
+		dps[i] = &metricpb.HistogramDataPoint{Count: c}
+	}
+	return &metricpb.Metric{
+		Name: "histogram",
+		Data: &metricpb.Metric_Histogram{Histogram: &metricpb.Histogram{
+			DataPoints:             dps,
+			AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}},
+	}
+}
+
+func expHistogram(counts ...uint64) *metricpb.Metric {
+	dps := make([]*metricpb.ExponentialHistogramDataPoint, len(counts))
+	for i, c := range counts {
+		dps[i] = &metricpb.ExponentialHistogramDataPoint{Count: c}
+	}
+	return &metricpb.Metric{
+		Name: "exponential_histogram",
+		Data: &metricpb.Metric_ExponentialHistogram{ExponentialHistogram: &metricpb.ExponentialHistogram{
+			DataPoints:             dps,
+			AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}},
+	}
+}
+
+func summary(counts ...uint64) *metricpb.Metric {
+	dps := make([]*metricpb.SummaryDataPoint, len(counts))
+	for i, c := range counts {
+		dps[i] = &metricpb.SummaryDataPoint{Count: c}
+	}
+	return &metricpb.Metric{
+		Name: "summary",
+		Data: &metricpb.Metric_Summary{Summary: &metricpb.Summary{DataPoints: dps}},
+	}
+}
+
+func resourceMetrics(metrics ...*metricpb.Metric) *metricpb.ResourceMetrics {
+	return &metricpb.ResourceMetrics{
+		Resource: &rpb.Resource{Attributes: []*cpb.KeyValue{
+			{
+				Key:   "service.name",
+				Value: &cpb.AnyValue{Value: &cpb.AnyValue_StringValue{StringValue: "test"}},
+			},
+		}},
+		ScopeMetrics: []*metricpb.ScopeMetrics{
+			{
+				Scope:   &cpb.InstrumentationScope{Name: "scope"},
+				Metrics: metrics,
+			},
+		},
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	rm := resourceMetrics(
+		sum(1, 2, 3),
+		gauge(4, 5),
+		histogram(6, 7),
+		expHistogram(8),
+		summary(9, 10),
+	)
+
+	assert.Equal(t, []*metricpb.ResourceMetrics{rm}, Metrics(rm, Limits{}))
+
+	got := Metrics(rm, Limits{Items: 4})
+	want := []*metricpb.ResourceMetrics{
+		resourceMetrics(sum(1, 2, 3), gauge(4)),
+		resourceMetrics(gauge(5), histogram(6, 7), expHistogram(8)),
+		resourceMetrics(summary(9, 10)),
+	}
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Truef(t, proto.Equal(want[i], got[i]), "request %d:\nwant %v\ngot  %v", i, want[i], got[i])
+	}
+
+	got = Metrics(rm, Limits{Items: 1})
+	require.Len(t, got, 10)
+	for _, m := range got {
+		assert.Equal(t, 1, resourceDataPointCount(m))
+	}
+}
+
+func TestMetricsBytes(t *testing.T) {
+	values := make([]int64, 1000)
+	rm := resourceMetrics(sum(values...))
+
+	const limit = 500
+	got := Metrics(rm, Limits{Bytes: limit})
+	require.Greater(t, len(got), 1)
+	var n int
+	for _, req := range got {
+		assert.LessOrEqual(t, metrics.size([]*metricpb.ResourceMetrics{req}, proto.Size), limit)
+		n += resourceDataPointCount(req)
+	}
+	assert.Equal(t, 1000, n, "all data points sent")
+}
@@ -0,0 +1,93 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/split.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package split provides splitting of OTLP export requests exceeding the
+// size accepted by a server into several smaller requests.
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc/internal/split"
+
+import "google.golang.org/protobuf/proto"
+
+// Limits are the maximum size of an export request. A zero or negative
+// value means no limit.
+type Limits struct {
+	// Bytes is the maximum size of the encoded request, before compression.
+	Bytes int
+	// Items is the maximum number of spans, metric data points, or log
+	// records in a request.
+	Items int
+	// Size returns the size of an encoded request. The size of the protobuf
+	// encoding is used if it is nil. It is set when requests are sent with
+	// another encoding, e.g. JSON.
+	Size func(proto.Message) int
+}
+
+// size returns the size of the encoded m.
+func (l Limits) size(m proto.Message) int {
+	if l.Size == nil {
+		return proto.Size(m)
+	}
+	return l.Size(m)
+}
+
+// splitter splits requests of type T.
+type splitter[T any] struct {
+	// count returns the number of items in a request.
+	count func(T) int
+	// size returns the size of a request encoded with the passed size
+	// function.
+	size func(T, func(proto.Message) int) int
+	// cut splits a request after its first n items. The resource and
+	// scope grouping of the items is preserved in both returned requests.
+	cut func(T, int) (T, T)
+}
+
+// split appends to dst the requests req is split in to be within l.
+//
+// A request is split at the item limit, then split in halves until it is
+// within the byte limit. A single item exceeding the byte limit is not split
+// further and is returned in its own request.
+func (s splitter[T]) split(dst []T, req T, l Limits) []T {
+	n := s.count(req)
+	for l.Items > 0 && n > l.Items {
+		var head T
+		head, req = s.cut(req, l.Items)
+		dst = s.splitBytes(dst, head, l.Items, l)
+		n -= l.Items
+	}
+	return s.splitBytes(dst, req, n, l)
+}
+
+// splitBytes appends to dst req, containing n items, split in halves until
+// each half is at most l.Bytes.
+func (s splitter[T]) splitBytes(dst []T, req T, n int, l Limits) []T {
+	if n <= 1 || l.Bytes <= 0 || s.size(req, l.size) <= l.Bytes {
+		return append(dst, req)
+	}
+	half := n / 2
+	head, tail := s.cut(req, half)
+	dst = s.splitBytes(dst, head, half, l)
+	return s.splitBytes(dst, tail, n-half, l)
+}
+
+// cut splits groups after their first n items. The count function returns
+// the number of items in a group, and split splits a group after its first n
+// items.
+func cut[G any](groups []G, n int, count func(G) int, split func(G, int) (G, G)) (head, tail []G) {
+	for i, g := range groups {
+		if n == 0 {
+			return head, groups[i:]
+		}
+		c := count(g)
+		if c <= n {
+			head = append(head, g)
+			n -= c
+			continue
+		}
+		h, t := split(g, n)
+		return append(head, h), append([]G{t}, groups[i+1:]...)
+	}
+	return head, nil
+}
@@ -10,9 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,19 +23,47 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/compress"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/observ"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/oconf"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/split"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
 
+const contentTypeProto = "application/x-protobuf"
+
 type client struct {
 	// req is cloned for every upload the client makes.
 	req         *http.Request
 	compression Compression
+	encoding    Encoding
 	requestFunc retry.RequestFunc
-	httpClient  *http.Client
+	// retryableStatusCodes are the status codes of the responses retried.
+	retryableStatusCodes []int
+	httpClient           *http.Client
+	// auth provides the authentication headers of every request. It is nil
+	// if no Authenticator is configured.
+	auth auth.Authenticator
+	// limits are the limits uploads are split to be within.
+	limits split.Limits
+	// inst records the telemetry of the client. It is nil if no
+	// MeterProvider is configured.
+	inst *observ.Instrumentation
+}
+
+// defaultRetryableStatusCodes are the status codes of the responses retried
+// if no retryable status codes are configured.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
 }
 
 // Keep it in sync with golang's DefaultTransport from net/http! We
@@ -55,20 +85,23 @@ var ourTransport = &http.Transport{
 
 // newClient creates a new HTTP metric client.
 func newClient(cfg oconf.Config) (*client, error) {
-	httpClient := &http.Client{
-		Transport: ourTransport,
-		Timeout:   cfg.Metrics.Timeout,
-	}
+	httpClient := cfg.Metrics.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Transport: ourTransport,
+			Timeout:   cfg.Metrics.Timeout,
+		}
 
-	if cfg.Metrics.TLSCfg != nil || cfg.Metrics.Proxy != nil {
-		clonedTransport := ourTransport.Clone()
-		httpClient.Transport = clonedTransport
+		if cfg.Metrics.TLSCfg != nil || cfg.Metrics.Proxy != nil {
+			clonedTransport := ourTransport.Clone()
+			httpClient.Transport = clonedTransport
 
-		if cfg.Metrics.TLSCfg != nil {
-			clonedTransport.TLSClientConfig = cfg.Metrics.TLSCfg
-		}
-		if cfg.Metrics.Proxy != nil {
-			clonedTransport.Proxy = cfg.Metrics.Proxy
+			if cfg.Metrics.TLSCfg != nil {
+				clonedTransport.TLSClientConfig = cfg.Metrics.TLSCfg
+			}
+			if cfg.Metrics.Proxy != nil {
+				clonedTransport.Proxy = cfg.Metrics.Proxy
+			}
 		}
 	}
 
@@ -94,13 +127,45 @@ func newClient(cfg oconf.Config) (*client, error) {
 			req.Header.Set(k, v)
 		}
 	}
-	req.Header.Set("Content-Type", "application/x-protobuf")
+	encoding := Encoding(cfg.Metrics.Marshaler)
+	componentType := "otlp_http_metric_exporter"
+	if encoding == JSONEncoding {
+		req.Header.Set("Content-Type", internal.ContentTypeJSON)
+		componentType = "otlp_http_json_metric_exporter"
+	} else {
+		req.Header.Set("Content-Type", contentTypeProto)
+	}
+
+	retryableStatusCodes := cfg.RetryableHTTPStatusCodes
+	if retryableStatusCodes == nil {
+		retryableStatusCodes = defaultRetryableStatusCodes
+	}
+
+	limits := split.Limits{
+		Bytes: cfg.Metrics.MaxRequestSize,
+		Items: cfg.Metrics.MaxRequestItems,
+	}
+	if encoding == JSONEncoding {
+		limits.Size = internal.JSONSize
+	}
 
 	return &client{
-		compression: Compression(cfg.Metrics.Compression),
-		req:         req,
-		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
-		httpClient:  httpClient,
+		compression:          Compression(cfg.Metrics.Compression),
+		encoding:             encoding,
+		req:                  req,
+		requestFunc:          cfg.RetryConfig.RequestFunc(evaluate),
+		retryableStatusCodes: retryableStatusCodes,
+		httpClient:           httpClient,
+		auth:                 cfg.Authenticator,
+		limits:               limits,
+		inst: observ.New(
+			cfg.MeterProvider,
+			"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp",
+			Version(),
+			observ.DataPoints,
+			componentType,
+			cfg.Metrics.Endpoint,
+		),
 	}, nil
 }
 
@@ -115,7 +180,8 @@ func (c *client) Shutdown(ctx context.Context) error {
 	return ctx.Err()
 }
 
-// UploadMetrics sends protoMetrics to the connected endpoint.
+// UploadMetrics sends protoMetrics to the connected endpoint. They are
+// split into several requests if they exceed the configured request limits.
 //
 // Retryable errors from the server will be handled according to any
 // RetryConfig the client was created with.
@@ -124,10 +190,20 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	// ensures this is not called after the Exporter is shutdown. Only thing
 	// to do here is send data.
 
+	var err error
+	for _, rm := range split.Metrics(protoMetrics, c.limits) {
+		err = errors.Join(err, c.uploadMetrics(ctx, rm))
+	}
+	return err
+}
+
+// uploadMetrics sends protoMetrics to the connected endpoint in a single
+// request.
+func (c *client) uploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	pbRequest := &colmetricpb.ExportMetricsServiceRequest{
 		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
 	}
-	body, err := proto.Marshal(pbRequest)
+	body, err := c.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -136,14 +212,22 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		return err
 	}
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
+	start := time.Now()
+	var attempts, rejected int64
+	var statusCode int
+	err = c.requestFunc(ctx, func(iCtx context.Context) error {
 		select {
 		case <-iCtx.Done():
 			return iCtx.Err()
 		default:
 		}
 
+		attempts++
 		request.reset(iCtx)
+		if err := auth.SetHeaders(iCtx, c.auth, request.Header); err != nil {
+			return err
+		}
+		c.inst.RecordPayload(iCtx, len(body), request.size)
 		resp, err := c.httpClient.Do(request.Request)
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) && urlErr.Temporary() {
@@ -153,6 +237,7 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 			return err
 		}
 
+		statusCode = resp.StatusCode
 		var rErr error
 		switch sc := resp.StatusCode; {
 		case sc >= 200 && sc <= 299:
@@ -167,26 +252,22 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 				return nil
 			}
 
-			if resp.Header.Get("Content-Type") == "application/x-protobuf" {
-				var respProto colmetricpb.ExportMetricsServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
-					return err
-				}
+			var respProto colmetricpb.ExportMetricsServiceResponse
+			if ok, err := unmarshalResponse(resp.Header, respData.Bytes(), &respProto); !ok || err != nil {
+				return err
+			}
 
-				if respProto.PartialSuccess != nil {
-					msg := respProto.PartialSuccess.GetErrorMessage()
-					n := respProto.PartialSuccess.GetRejectedDataPoints()
-					if n != 0 || msg != "" {
-						err := internal.MetricPartialSuccessError(n, msg)
-						otel.Handle(err)
-					}
+			if respProto.PartialSuccess != nil {
+				msg := respProto.PartialSuccess.GetErrorMessage()
+				n := respProto.PartialSuccess.GetRejectedDataPoints()
+				if n != 0 || msg != "" {
+					rejected = n
+					err := internal.MetricPartialSuccessError(n, msg)
+					otel.Handle(err)
 				}
 			}
 			return nil
-		case sc == http.StatusTooManyRequests,
-			sc == http.StatusBadGateway,
-			sc == http.StatusServiceUnavailable,
-			sc == http.StatusGatewayTimeout:
+		case slices.Contains(c.retryableStatusCodes, sc):
 			// Retry-able failure.
 			rErr = newResponseError(resp.Header, nil)
 
@@ -216,6 +297,76 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		}
 		return rErr
 	})
+	c.recordExport(ctx, start, protoMetrics, attempts, rejected, statusCode, err)
+	return err
+}
+
+// recordExport records the telemetry of an export of protoMetrics that
+// started at start and was attempted attempts times. statusCode is the status
+// code of the last response received, or zero if none was.
+func (c *client) recordExport(ctx context.Context, start time.Time, protoMetrics *metricpb.ResourceMetrics, attempts, rejected int64, statusCode int, err error) {
+	if c.inst == nil {
+		return
+	}
+	c.inst.RecordRetries(ctx, attempts-1)
+
+	var extra []attribute.KeyValue
+	if statusCode != 0 {
+		extra = append(extra, semconv.HTTPResponseStatusCode(statusCode))
+	}
+	errType := observ.ErrorType(err)
+	if err != nil && statusCode != 0 && (statusCode < 200 || statusCode > 299) {
+		errType = strconv.Itoa(statusCode)
+	}
+	c.inst.RecordExport(ctx, start, dataPointCount(protoMetrics), rejected, errType, extra...)
+}
+
+// dataPointCount returns the number of data points in rm.
+func dataPointCount(rm *metricpb.ResourceMetrics) int64 {
+	var n int
+	for _, sm := range rm.GetScopeMetrics() {
+		for _, m := range sm.GetMetrics() {
+			switch d := m.GetData().(type) {
+			case *metricpb.Metric_Gauge:
+				n += len(d.Gauge.GetDataPoints())
+			case *metricpb.Metric_Sum:
+				n += len(d.Sum.GetDataPoints())
+			case *metricpb.Metric_Histogram:
+				n += len(d.Histogram.GetDataPoints())
+			case *metricpb.Metric_ExponentialHistogram:
+				n += len(d.ExponentialHistogram.GetDataPoints())
+			case *metricpb.Metric_Summary:
+				n += len(d.Summary.GetDataPoints())
+			}
+		}
+	}
+	return int64(n)
+}
+
+// marshal returns the encoding of m configured for c.
+func (c *client) marshal(m proto.Message) ([]byte, error) {
+	if c.encoding == JSONEncoding {
+		return internal.MarshalJSON(m)
+	}
+	return proto.Marshal(m)
+}
+
+// unmarshalResponse parses the response body data into m based on the
+// Content-Type in header. It returns false if the Content-Type is not a
+// supported OTLP encoding and data was not parsed.
+func unmarshalResponse(header http.Header, data []byte, m proto.Message) (bool, error) {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false, nil
+	}
+	switch mediaType {
+	case contentTypeProto:
+		return true, proto.Unmarshal(data, m)
+	case internal.ContentTypeJSON:
+		return true, internal.UnmarshalJSON(data, m)
+	default:
+		return false, nil
+	}
 }
 
 var gzPool = sync.Pool{
@@ -233,6 +384,7 @@ func (c *client) newRequest(ctx context.Context, body []byte) (request, error) {
 	case NoCompression:
 		r.ContentLength = (int64)(len(body))
 		req.bodyReader = bodyReader(body)
+		req.size = len(body)
 	case GzipCompression:
 		// Ensure the content length is not used.
 		r.ContentLength = -1
@@ -253,6 +405,22 @@ func (c *client) newRequest(ctx context.Context, body []byte) (request, error) {
 		}
 
 		req.bodyReader = bodyReader(b.Bytes())
+		req.size = b.Len()
+	case ZstdCompression:
+		b, err := compress.Zstd(nil, body)
+		if err != nil {
+			return req, err
+		}
+		r.ContentLength = int64(len(b))
+		r.Header.Set("Content-Encoding", compress.ZstdName)
+		req.bodyReader = bodyReader(b)
+		req.size = len(b)
+	case SnappyCompression:
+		b := compress.Snappy(body)
+		r.ContentLength = int64(len(b))
+		r.Header.Set("Content-Encoding", compress.SnappyName)
+		req.bodyReader = bodyReader(b)
+		req.size = len(b)
 	}
 
 	return req, nil
@@ -271,6 +439,8 @@ type request struct {
 
 	// bodyReader allows the same body to be used for multiple requests.
 	bodyReader func() io.ReadCloser
+	// size is the size of the body, after compression.
+	size int
 }
 
 // reset reinitializes the request Body and uses ctx for the request.
@@ -8,19 +8,27 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/oconf"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/otest"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 )
 
@@ -73,6 +81,47 @@ func TestClientWithHTTPCollectorRespondingPlainText(t *testing.T) {
 	require.Len(t, got, 1, "upload of one ResourceMetrics")
 }
 
+func TestClientJSONEncoding(t *testing.T) {
+	var (
+		contentType string
+		got         colmetricpb.ExportMetricsServiceRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) || !assert.NoError(t, internal.UnmarshalJSON(body, &got)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(`{"partialSuccess":{"rejectedDataPoints":"3","errorMessage":"partially successful"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var errs []error
+	eh := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(e error) { errs = append(errs, e) }))
+	t.Cleanup(func() { otel.SetErrorHandler(eh) })
+
+	ctx := context.Background()
+	opts := []Option{WithEndpointURL(srv.URL), WithEncoding(JSONEncoding)}
+	client, err := newClient(oconf.NewHTTPConfig(asHTTPOptions(opts)...))
+	require.NoError(t, err)
+
+	rm := &mpb.ResourceMetrics{SchemaUrl: "https://example.com/schema"}
+	require.NoError(t, client.UploadMetrics(ctx, rm))
+	require.NoError(t, client.Shutdown(ctx))
+
+	assert.Equal(t, "application/json", contentType)
+	require.Len(t, got.ResourceMetrics, 1)
+	assert.Equal(t, rm.SchemaUrl, got.ResourceMetrics[0].SchemaUrl)
+
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "partially successful")
+	assert.ErrorContains(t, errs[0], "3 metric data points rejected")
+}
+
 func TestNewWithInvalidEndpoint(t *testing.T) {
 	ctx := context.Background()
 	exp, err := New(ctx, WithEndpoint("host:invalid-port"))
@@ -80,6 +129,46 @@ func TestNewWithInvalidEndpoint(t *testing.T) {
 	assert.Nil(t, exp)
 }
 
+func TestWithHTTPClient(t *testing.T) {
+	hc, paths := newUnixSocketServer(t)
+	ctx := context.Background()
+	exp, err := New(ctx, WithEndpoint("localhost"), WithInsecure(), WithHTTPClient(hc))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+	require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+	assert.Equal(t, "/v1/metrics", <-paths)
+}
+
+// newUnixSocketServer returns an HTTP client sending all its requests to a
+// server listening on a Unix domain socket. The server sends the path of the
+// requests it receives on the returned channel.
+func newUnixSocketServer(t *testing.T) (*http.Client, <-chan string) {
+	t.Helper()
+	sock := filepath.Join(t.TempDir(), "otlp.sock")
+	ln, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+
+	paths := make(chan string, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.Path
+			w.WriteHeader(http.StatusOK)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", sock)
+			},
+		},
+	}, paths
+}
+
 func TestConfig(t *testing.T) {
 	factoryFunc := func(ePt string, rCh <-chan otest.ExportResult, o ...Option) (metric.Exporter, *otest.HTTPCollector) {
 		coll, err := otest.NewHTTPCollector(ePt, rCh)
@@ -127,6 +216,71 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, got[key], []string{headers[key]})
 	})
 
+	t.Run("WithAuthenticator", func(t *testing.T) {
+		auth := &countingAuthenticator{}
+		exp, coll := factoryFunc("", nil,
+			// Overridden by the Authenticator.
+			WithHeaders(map[string]string{"Authorization": "static"}),
+			WithAuthenticator(auth),
+		)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Equal(t, []string{"Bearer token-1"}, coll.Headers()["Authorization"])
+		require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, coll.Headers()["Authorization"], "called per request")
+
+		auth.err = errors.New("no token")
+		assert.ErrorIs(t, exp.Export(ctx, &metricdata.ResourceMetrics{}), auth.err)
+		assert.Len(t, coll.Collect().Dump(), 2, "request not sent")
+	})
+
+	t.Run("WithMaxRequestItems", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 3)
+		rCh <- otest.ExportResult{Err: &otest.HTTPResponseError{
+			Err:    errors.New("rejected"),
+			Status: http.StatusBadRequest,
+		}}
+		rCh <- otest.ExportResult{}
+		rCh <- otest.ExportResult{}
+		exp, coll := factoryFunc("", rCh, WithMaxRequestItems(2))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		rm := &metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "requests",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{Value: 1}, {Value: 2}, {Value: 3}, {Value: 4}, {Value: 5},
+					},
+				},
+			}},
+		}}}
+		err := exp.Export(ctx, rm)
+		assert.ErrorContains(t, err, "400 Bad Request", "error of the first request")
+
+		got := coll.Collect().Dump()
+		require.Len(t, got, 3, "requests")
+		var values []int64
+		for _, req := range got {
+			for _, sm := range req.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					assert.Equal(t, "requests", m.Name)
+					for _, dp := range m.GetSum().GetDataPoints() {
+						values = append(values, dp.GetAsInt())
+					}
+				}
+			}
+		}
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, values)
+	})
+
 	t.Run("WithTimeout", func(t *testing.T) {
 		// Do not send on rCh so the Collector never responds to the client.
 		rCh := make(chan otest.ExportResult)
@@ -145,14 +299,21 @@ func TestConfig(t *testing.T) {
 		assert.ErrorAs(t, err, new(retryableError))
 	})
 
-	t.Run("WithCompressionGZip", func(t *testing.T) {
-		exp, coll := factoryFunc("", nil, WithCompression(GzipCompression))
-		ctx := context.Background()
-		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
-		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
-		assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
-		assert.Len(t, coll.Collect().Dump(), 1)
-	})
+	compressions := map[string]Compression{
+		"GZip":   GzipCompression,
+		"Zstd":   ZstdCompression,
+		"Snappy": SnappyCompression,
+	}
+	for name, compression := range compressions {
+		t.Run("WithCompression"+name, func(t *testing.T) {
+			exp, coll := factoryFunc("", nil, WithCompression(compression))
+			ctx := context.Background()
+			t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+			t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+			assert.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{}))
+			assert.Len(t, coll.Collect().Dump(), 1)
+		})
+	}
 
 	t.Run("WithRetry", func(t *testing.T) {
 		emptyErr := errors.New("")
@@ -192,6 +353,113 @@ func TestConfig(t *testing.T) {
 		assert.Len(t, rCh, 0, "failed HTTP responses did not occur")
 	})
 
+	t.Run("WithRetryableStatusCodes", func(t *testing.T) {
+		emptyErr := errors.New("")
+		rCh := make(chan otest.ExportResult, 3)
+		rCh <- otest.ExportResult{Err: &otest.HTTPResponseError{
+			Status: http.StatusInternalServerError,
+			Err:    emptyErr,
+		}}
+		// Not retried, only the configured status codes are.
+		rCh <- otest.ExportResult{Err: &otest.HTTPResponseError{
+			Status: http.StatusServiceUnavailable,
+			Err:    emptyErr,
+		}}
+		rCh <- otest.ExportResult{}
+		exp, coll := factoryFunc("", rCh,
+			WithRetryableStatusCodes(http.StatusInternalServerError),
+			WithRetry(RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Nanosecond,
+				MaxInterval:     time.Millisecond,
+				MaxElapsedTime:  time.Minute,
+			}),
+		)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		// Push this after Shutdown so the HTTP server doesn't hang.
+		t.Cleanup(func() { close(rCh) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.Error(t, exp.Export(ctx, &metricdata.ResourceMetrics{}), "non-retryable status code")
+		assert.Len(t, rCh, 1, "retried a non-retryable status code")
+	})
+
+	t.Run("WithMeterProvider", func(t *testing.T) {
+		rCh := make(chan otest.ExportResult, 2)
+		rCh <- otest.ExportResult{Err: &otest.HTTPResponseError{
+			Status: http.StatusServiceUnavailable,
+			Err:    errors.New(""),
+		}}
+		rCh <- otest.ExportResult{Response: &colmetricpb.ExportMetricsServiceResponse{
+			PartialSuccess: &colmetricpb.ExportMetricsPartialSuccess{
+				RejectedDataPoints: 1,
+				ErrorMessage:       "partially successful",
+			},
+		}}
+		reader := metric.NewManualReader()
+		mp := metric.NewMeterProvider(metric.WithReader(reader))
+		exp, coll := factoryFunc("", rCh,
+			WithRetry(RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Nanosecond,
+				MaxInterval:     time.Millisecond,
+				MaxElapsedTime:  time.Minute,
+			}),
+			WithCompression(GzipCompression),
+			WithMeterProvider(mp),
+		)
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		// Push this after Shutdown so the HTTP server doesn't hang.
+		t.Cleanup(func() { close(rCh) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+		require.NoError(t, exp.Export(ctx, &metricdata.ResourceMetrics{
+			ScopeMetrics: []metricdata.ScopeMetrics{{
+				Metrics: []metricdata.Metrics{{
+					Name: "gauge",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{{Value: 1}, {Value: 2}},
+					},
+				}},
+			}},
+		}))
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		assert.Equal(t, "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp", rm.ScopeMetrics[0].Scope.Name)
+
+		got := make(map[string]metricdata.Aggregation)
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			got[m.Name] = m.Data
+		}
+
+		exported, ok := got["otel.sdk.exporter.metric_data_point.exported"].(metricdata.Sum[int64])
+		require.True(t, ok)
+		byErr := make(map[string]int64)
+		for _, dp := range exported.DataPoints {
+			v, _ := dp.Attributes.Value("error.type")
+			byErr[v.AsString()] += dp.Value
+		}
+		assert.Equal(t, map[string]int64{"": 1, "rejected": 1}, byErr)
+
+		retries, ok := got["otel.sdk.exporter.operation.retries"].(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, retries.DataPoints, 1)
+		assert.Equal(t, int64(1), retries.DataPoints[0].Value)
+
+		for _, name := range []string{
+			"otel.sdk.exporter.payload.uncompressed_size",
+			"otel.sdk.exporter.payload.compressed_size",
+		} {
+			size, ok := got[name].(metricdata.Sum[int64])
+			require.True(t, ok, name)
+			require.Len(t, size.DataPoints, 1, name)
+			assert.Positive(t, size.DataPoints[0].Value, name)
+		}
+	})
+
 	t.Run("WithRetryAndExporterErr", func(t *testing.T) {
 		exporterErr := errors.New("rpc error: code = Unavailable desc = service.name not found in resource attributes")
 		rCh := make(chan otest.ExportResult, 1)
@@ -271,3 +539,15 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, got[headerKeySetInProxy], []string{headerValueSetInProxy})
 	})
 }
+
+type countingAuthenticator struct {
+	n   atomic.Int64
+	err error
+}
+
+func (a *countingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", a.n.Add(1))}, nil
+}
@@ -4,6 +4,7 @@
 package otlpmetrichttp // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
 	"net/url"
@@ -11,6 +12,7 @@ import (
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/oconf"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/retry"
+	metricapi "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
 
@@ -30,6 +32,24 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression = Compression(oconf.GzipCompression)
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression = Compression(oconf.ZstdCompression)
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with snappy.
+	SnappyCompression = Compression(oconf.SnappyCompression)
+)
+
+// Encoding describes the encoding used for payloads sent to the collector.
+type Encoding oconf.Marshaler
+
+const (
+	// ProtobufEncoding tells the driver to send payloads using the binary
+	// Protobuf encoding.
+	ProtobufEncoding = Encoding(oconf.MarshalProto)
+	// JSONEncoding tells the driver to send payloads using the OTLP/JSON
+	// encoding.
+	JSONEncoding = Encoding(oconf.MarshalJSON)
 )
 
 // Option applies an option to the Exporter.
@@ -98,7 +118,7 @@ func WithEndpointURL(u string) Option {
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be either "none", "gzip", "zstd", or "snappy". If both are set,
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -107,6 +127,21 @@ func WithCompression(compression Compression) Option {
 	return wrappedOption{oconf.WithCompression(oconf.Compression(compression))}
 }
 
+// WithEncoding sets the encoding the Exporter will use for the HTTP body.
+// Responses from the collector are parsed according to their Content-Type.
+//
+// If the OTEL_EXPORTER_OTLP_PROTOCOL or OTEL_EXPORTER_OTLP_METRICS_PROTOCOL
+// environment variable is set, and this option is not passed, that variable
+// value will be used. That value can be either "http/protobuf" or
+// "http/json". If both are set, OTEL_EXPORTER_OTLP_METRICS_PROTOCOL will
+// take precedence.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, ProtobufEncoding will be used.
+func WithEncoding(encoding Encoding) Option {
+	return wrappedOption{oconf.WithMarshal(oconf.Marshaler(encoding))}
+}
+
 // WithURLPath sets the URL path the Exporter will send requests to.
 //
 // If the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_METRICS_ENDPOINT
@@ -197,6 +232,19 @@ func WithRetry(rc RetryConfig) Option {
 	return wrappedOption{oconf.WithRetry(retry.Config(rc))}
 }
 
+// WithRetryableStatusCodes sets the status codes of the HTTP responses for
+// which the export of metric data is retried according to the policy set with
+// WithRetry. Responses with any other error status code are not retried.
+//
+// By default, if this option is not passed, responses with the 429, 502, 503,
+// and 504 status codes are retried.
+func WithRetryableStatusCodes(codes ...int) Option {
+	return wrappedOption{oconf.NewHTTPOption(func(cfg oconf.Config) oconf.Config {
+		cfg.RetryableHTTPStatusCodes = append([]int{}, codes...)
+		return cfg
+	})}
+}
+
 // WithTemporalitySelector sets the TemporalitySelector the client will use to
 // determine the Temporality of an instrument based on its kind. If this option
 // is not used, the client will use the DefaultTemporalitySelector from the
@@ -220,3 +268,93 @@ func WithAggregationSelector(selector metric.AggregationSelector) Option {
 func WithProxy(pf HTTPTransportProxyFunc) Option {
 	return wrappedOption{oconf.WithProxy(oconf.HTTPTransportProxyFunc(pf))}
 }
+
+// WithHTTPClient sets the HTTP client used to send the requests, for example
+// a client whose transport dials a Unix domain socket.
+//
+// This option takes precedence over the WithProxy, WithTimeout, and
+// WithTLSClientConfig options as well as the OTEL_EXPORTER_OTLP_CERTIFICATE,
+// OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE, OTEL_EXPORTER_OTLP_TIMEOUT, and
+// OTEL_EXPORTER_OTLP_METRICS_TIMEOUT environment variables. The passed client
+// is used as is, its Timeout and other fields are not modified.
+//
+// By default, if this option is not passed, a client is created from the
+// other options.
+func WithHTTPClient(c *http.Client) Option {
+	return wrappedOption{oconf.WithHTTPClient(c)}
+}
+
+// WithMeterProvider sets the MeterProvider used to report telemetry about the
+// Exporter itself. The following metrics are reported:
+//
+//   - otel.sdk.exporter.metric_data_point.exported: the number of data points
+//     exported. Data points that failed to be exported, or that were rejected
+//     by the collector in a partial success response, have the error.type
+//     attribute set.
+//   - otel.sdk.exporter.operation.duration: the duration of exports.
+//   - otel.sdk.exporter.operation.retries: the number of retried requests.
+//   - otel.sdk.exporter.payload.uncompressed_size and
+//     otel.sdk.exporter.payload.compressed_size: the size of the sent
+//     payloads before and after compression.
+//
+// By default, if this option is not passed, no telemetry is reported.
+func WithMeterProvider(mp metricapi.MeterProvider) Option {
+	return wrappedOption{oconf.WithMeterProvider(mp)}
+}
+
+// Authenticator provides the headers used to authenticate export requests,
+// e.g. an "Authorization" header holding a bearer token.
+//
+// Headers is called before every request sent, including retries, and the
+// returned headers are set on the request. They override the headers with
+// the same name passed with WithHeaders.
+// If Headers returns an error, the request is not sent and the export fails.
+//
+// Headers needs to be safe to call concurrently.
+type Authenticator interface {
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// WithAuthenticator sets the Authenticator used to authenticate every
+// request sent by the exporter. Unlike the static headers passed with
+// WithHeaders, it allows credentials that expire, like OAuth 2.0 access
+// tokens, to be refreshed without creating a new exporter.
+//
+// The go.opentelemetry.io/otel/exporters/otlp/otlpauth package provides an
+// Authenticator using the OAuth 2.0 client credentials grant.
+//
+// By default, if this option is not passed, requests are not authenticated
+// other than with the configured headers and TLS credentials.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{oconf.WithAuthenticator(a)}
+}
+
+// WithMaxRequestSize sets the maximum size, in bytes, of the requests sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the data points grouped by their resource and instrumentation
+// scope. The size is the one of the request encoded with the protobuf or JSON
+// encoding in use, before compression. A single data point larger than n is sent
+// in its own request.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by size.
+func WithMaxRequestSize(n int) Option {
+	return wrappedOption{oconf.WithMaxRequestSize(n)}
+}
+
+// WithMaxRequestItems sets the maximum number of data points in a request sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the data points grouped by their resource and instrumentation
+// scope.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by their number of data points.
+func WithMaxRequestItems(n int) Option {
+	return wrappedOption{oconf.WithMaxRequestItems(n)}
+}
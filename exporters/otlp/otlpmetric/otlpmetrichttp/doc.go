@@ -39,10 +39,16 @@ The configuration can be overridden by [WithTimeout] option.
 
 OTEL_EXPORTER_OTLP_COMPRESSION, OTEL_EXPORTER_OTLP_METRICS_COMPRESSION (default: none) -
 compression strategy the exporter uses to compress the HTTP body.
-Supported values: "gzip".
+Supported values: "gzip", "zstd", "snappy".
 OTEL_EXPORTER_OTLP_METRICS_COMPRESSION takes precedence over OTEL_EXPORTER_OTLP_COMPRESSION.
 The configuration can be overridden by [WithCompression] option.
 
+OTEL_EXPORTER_OTLP_PROTOCOL, OTEL_EXPORTER_OTLP_METRICS_PROTOCOL (default: "http/protobuf") -
+encoding the exporter uses for the HTTP body.
+Supported values: "http/protobuf", "http/json".
+OTEL_EXPORTER_OTLP_METRICS_PROTOCOL takes precedence over OTEL_EXPORTER_OTLP_PROTOCOL.
+The configuration can be overridden by [WithEncoding] option.
+
 OTEL_EXPORTER_OTLP_CERTIFICATE, OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE (default: none) -
 filepath to the trusted certificate to use when verifying a server's TLS credentials.
 OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE takes precedence over OTEL_EXPORTER_OTLP_CERTIFICATE.
@@ -58,6 +64,10 @@ filepath to the client's private key to use in mTLS communication in PEM format.
 OTEL_EXPORTER_OTLP_METRICS_CLIENT_KEY takes precedence over OTEL_EXPORTER_OTLP_CLIENT_KEY.
 The configuration can be overridden by [WithTLSClientConfig] option.
 
+The files defined by the certificate and key environment variables are
+checked for changes whenever a new connection is established. Certificates
+rotated on disk are used without restarting the exporter.
+
 OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE (default: "cumulative") -
 aggregation temporality to use on the basis of instrument kind. Supported values:
   - "cumulative" - Cumulative aggregation temporality for all instrument kinds,
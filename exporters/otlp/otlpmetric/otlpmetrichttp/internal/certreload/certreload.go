@@ -0,0 +1,242 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/certreload/certreload.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package certreload provides TLS configurations reloading their certificates
+// when the files they are loaded from change.
+package certreload // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/certreload"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Files are the paths of the PEM encoded files a TLS configuration is loaded
+// from.
+type Files struct {
+	// CA is the path of the certificate authorities used to verify the
+	// server certificate. The system certificate authorities are used if it
+	// is empty.
+	CA string
+	// Cert and Key are the paths of the client certificate and its private
+	// key. No client certificate is used if either is empty.
+	Cert, Key string
+}
+
+// Reloader holds the certificates loaded from Files. They are reloaded when
+// the files change, so certificates rotated on disk are used without
+// restarting the process.
+type Reloader struct {
+	files    Files
+	readFile func(string) ([]byte, error)
+	stat     func(string) (os.FileInfo, error)
+
+	mu sync.Mutex
+	// caStamps and certStamps identify the version of the files the
+	// certificates were loaded from.
+	caStamps   []stamp
+	certStamps []stamp
+	roots      *x509.CertPool
+	cert       *tls.Certificate
+}
+
+// stamp identifies the version of a file.
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// New returns a Reloader of the certificates of files read with readFile. An
+// error is returned if the certificates cannot be loaded.
+func New(files Files, readFile func(string) ([]byte, error)) (*Reloader, error) {
+	r := &Reloader{files: files, readFile: readFile, stat: os.Stat}
+
+	var err error
+	if files.CA != "" {
+		r.caStamps = r.stamps(files.CA)
+		var e error
+		r.roots, e = r.loadRoots()
+		err = errors.Join(err, e)
+	}
+	if files.Cert != "" && files.Key != "" {
+		r.certStamps = r.stamps(files.Cert, files.Key)
+		var e error
+		r.cert, e = r.loadCert()
+		err = errors.Join(err, e)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig returns a tls.Config for connections to endpoint using the
+// certificates of r. The endpoint is in the host[:port] form, it may have a
+// scheme or a path. The files are checked for changes before every
+// handshake, and the certificates are reloaded if they changed. Connections
+// already established are not affected.
+//
+// The RootCAs and Certificates fields hold the certificates loaded when r
+// was created. They are not used for handshakes.
+func (r *Reloader) TLSConfig(endpoint string) *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	host := serverName(endpoint)
+	c := &tls.Config{ServerName: host}
+	if r.roots != nil {
+		c.RootCAs = r.roots
+		// RootCAs cannot be changed once the configuration is in use. The
+		// server certificate is verified with the current certificate
+		// authorities in VerifyConnection instead.
+		c.InsecureSkipVerify = true // nolint:gosec // Verified by VerifyConnection.
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			return r.verifyConnection(cs, host)
+		}
+	}
+	if r.cert != nil {
+		c.Certificates = []tls.Certificate{*r.cert}
+		c.GetClientCertificate = r.clientCertificate
+	}
+	return c
+}
+
+// serverName returns the host of endpoint.
+func serverName(endpoint string) string {
+	if _, after, ok := strings.Cut(endpoint, "://"); ok {
+		endpoint = strings.TrimLeft(after, "/")
+	}
+	endpoint, _, _ = strings.Cut(endpoint, "/")
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(endpoint, "["), "]")
+}
+
+// verifyConnection verifies the server certificate of cs is valid for host
+// with the current certificate authorities. It performs the verification
+// that is skipped by setting InsecureSkipVerify.
+//
+// The host is used instead of the server name of cs, which is empty when
+// connecting to an IP address.
+func (r *Reloader) verifyConnection(cs tls.ConnectionState, host string) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: server did not provide a certificate")
+	}
+	if host == "" {
+		return errors.New("tls: no server name to verify the certificate for")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         r.currentRoots(),
+		DNSName:       host,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// clientCertificate returns the current client certificate.
+func (r *Reloader) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, changed := r.changed(r.certStamps, r.files.Cert, r.files.Key); changed {
+		cert, err := r.loadCert()
+		if err != nil {
+			// Keep using the previous certificate. The files may be in the
+			// process of being rotated, they are checked again on the next
+			// handshake.
+			otel.Handle(fmt.Errorf("failed to reload TLS client certificate: %w", err))
+		} else {
+			r.cert, r.certStamps = cert, s
+		}
+	}
+	return r.cert, nil
+}
+
+// currentRoots returns the current certificate authorities.
+func (r *Reloader) currentRoots() *x509.CertPool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, changed := r.changed(r.caStamps, r.files.CA); changed {
+		roots, err := r.loadRoots()
+		if err != nil {
+			otel.Handle(fmt.Errorf("failed to reload TLS certificate authorities: %w", err))
+		} else {
+			r.roots, r.caStamps = roots, s
+		}
+	}
+	return r.roots
+}
+
+// stamps returns the current stamps of paths. The zero stamp is returned for
+// the files that cannot be accessed.
+func (r *Reloader) stamps(paths ...string) []stamp {
+	s := make([]stamp, len(paths))
+	for i, p := range paths {
+		if info, err := r.stat(p); err == nil {
+			s[i] = stamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return s
+}
+
+// changed returns the current stamps of paths and if they are different from
+// old. Files that cannot be accessed are not considered changed, the
+// certificates loaded from them are kept until they are replaced.
+func (r *Reloader) changed(old []stamp, paths ...string) ([]stamp, bool) {
+	current := r.stamps(paths...)
+	var changed bool
+	for i := range current {
+		if current[i] == (stamp{}) {
+			return old, false
+		}
+		if current[i] != old[i] {
+			changed = true
+		}
+	}
+	return current, changed
+}
+
+func (r *Reloader) loadRoots() (*x509.CertPool, error) {
+	b, err := r.readFile(r.files.CA)
+	if err != nil {
+		return nil, err
+	}
+	cp := x509.NewCertPool()
+	if ok := cp.AppendCertsFromPEM(b); !ok {
+		return nil, errors.New("certificate not added")
+	}
+	return cp, nil
+}
+
+func (r *Reloader) loadCert() (*tls.Certificate, error) {
+	cert, err := r.readFile(r.files.Cert)
+	if err != nil {
+		return nil, err
+	}
+	key, err := r.readFile(r.files.Key)
+	if err != nil {
+		return nil, err
+	}
+	crt, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, err
+	}
+	return &crt, nil
+}
@@ -17,12 +17,16 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/compress"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp/internal/retry"
 	"go.opentelemetry.io/otel/internal/global"
+	metricapi "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
 
@@ -55,6 +59,18 @@ type (
 		Compression Compression
 		Timeout     time.Duration
 		URLPath     string
+		Marshaler   Marshaler
+
+		// MaxRequestSize is the maximum size, in bytes, and MaxRequestItems
+		// the maximum number of items of an export request. Larger requests
+		// are split. Zero means no limit.
+		MaxRequestSize  int
+		MaxRequestItems int
+
+		// ReloadingTLSCfg returns the TLS configuration for an endpoint
+		// reloading its certificates when the files they are loaded from
+		// change. It is used if neither TLSCfg nor GRPCCredentials are set.
+		ReloadingTLSCfg func(endpoint string) *tls.Config
 
 		// gRPC configurations
 		GRPCCredentials credentials.TransportCredentials
@@ -63,6 +79,9 @@ type (
 		AggregationSelector metric.AggregationSelector
 
 		Proxy HTTPTransportProxyFunc
+		// HTTPClient is the client used to send the HTTP requests. The
+		// client is created from the other HTTP configurations if it is nil.
+		HTTPClient *http.Client
 	}
 
 	Config struct {
@@ -70,12 +89,25 @@ type (
 		Metrics SignalConfig
 
 		RetryConfig retry.Config
+		// RetryableGRPCCodes are the codes of the gRPC errors retried and
+		// RetryableHTTPStatusCodes the status codes of the HTTP responses
+		// retried. The default codes are used if they are nil.
+		RetryableGRPCCodes       []codes.Code
+		RetryableHTTPStatusCodes []int
 
 		// gRPC configurations
 		ReconnectionPeriod time.Duration
 		ServiceConfig      string
 		DialOptions        []grpc.DialOption
 		GRPCConn           *grpc.ClientConn
+
+		// MeterProvider is used to report telemetry about the exporter
+		// itself. No telemetry is reported if it is nil.
+		MeterProvider metricapi.MeterProvider
+
+		// Authenticator provides the headers authenticating every request.
+		// No authentication is done if it is nil.
+		Authenticator auth.Authenticator
 	}
 )
 
@@ -98,6 +130,9 @@ func NewHTTPConfig(opts ...HTTPOption) Config {
 	for _, opt := range opts {
 		cfg = opt.ApplyHTTPOption(cfg)
 	}
+	if cfg.Metrics.TLSCfg == nil && cfg.Metrics.ReloadingTLSCfg != nil {
+		cfg.Metrics.TLSCfg = cfg.Metrics.ReloadingTLSCfg(cfg.Metrics.Endpoint)
+	}
 	cfg.Metrics.URLPath = cleanPath(cfg.Metrics.URLPath, DefaultMetricsPath)
 	return cfg
 }
@@ -135,6 +170,9 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 	for _, opt := range opts {
 		cfg = opt.ApplyGRPCOption(cfg)
 	}
+	if cfg.Metrics.GRPCCredentials == nil && cfg.Metrics.ReloadingTLSCfg != nil {
+		cfg.Metrics.GRPCCredentials = credentials.NewTLS(cfg.Metrics.ReloadingTLSCfg(cfg.Metrics.Endpoint))
+	}
 
 	if cfg.ServiceConfig != "" {
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultServiceConfig(cfg.ServiceConfig))
@@ -150,8 +188,13 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 		cfg.Metrics.GRPCCredentials = creds
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithTransportCredentials(creds))
 	}
-	if cfg.Metrics.Compression == GzipCompression {
+	switch cfg.Metrics.Compression {
+	case GzipCompression:
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	case ZstdCompression:
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(compress.ZstdName)))
+	case SnappyCompression:
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(compress.SnappyName)))
 	}
 	if cfg.ReconnectionPeriod != 0 {
 		p := grpc.ConnectParams{
@@ -302,6 +345,13 @@ func WithCompression(compression Compression) GenericOption {
 	})
 }
 
+func WithMarshal(m Marshaler) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.Marshaler = m
+		return cfg
+	})
+}
+
 func WithURLPath(urlPath string) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Metrics.URLPath = urlPath
@@ -374,3 +424,38 @@ func WithProxy(pf HTTPTransportProxyFunc) GenericOption {
 		return cfg
 	})
 }
+
+func WithHTTPClient(c *http.Client) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.HTTPClient = c
+		return cfg
+	})
+}
+
+func WithMeterProvider(mp metricapi.MeterProvider) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.MeterProvider = mp
+		return cfg
+	})
+}
+
+func WithAuthenticator(a auth.Authenticator) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Authenticator = a
+		return cfg
+	})
+}
+
+func WithMaxRequestSize(n int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.MaxRequestSize = n
+		return cfg
+	})
+}
+
+func WithMaxRequestItems(n int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.MaxRequestItems = n
+		return cfg
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal/filewriter"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var errNotStarted = errors.New("file client not started")
+
+type client struct {
+	cfg    config
+	writer atomic.Pointer[filewriter.Writer]
+}
+
+// Compile time check *client implements otlptrace.Client.
+var _ otlptrace.Client = (*client)(nil)
+
+// NewClient creates a new file trace client. The client writes each upload
+// as an OTLP/JSON encoded ExportTraceServiceRequest on its own line of the
+// configured file.
+func NewClient(opts ...Option) otlptrace.Client {
+	return &client{cfg: newConfig(opts)}
+}
+
+// Start opens the file written to.
+func (c *client) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w, err := filewriter.New(c.cfg.writerConfig())
+	if err != nil {
+		return err
+	}
+	c.writer.Store(w)
+	return nil
+}
+
+// Stop closes the file written to.
+func (c *client) Stop(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w := c.writer.Swap(nil)
+	if w == nil {
+		return nil
+	}
+	return w.Close()
+}
+
+// UploadTraces writes protoSpans to the file.
+func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(protoSpans) == 0 {
+		return nil
+	}
+
+	w := c.writer.Load()
+	if w == nil {
+		return errNotStarted
+	}
+
+	b, err := internal.MarshalJSON(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	})
+	if err != nil {
+		return err
+	}
+	return w.WriteLine(b)
+}
+
+// MarshalLog is the marshaling function used by the logging system to represent this Client.
+func (c *client) MarshalLog() interface{} {
+	return struct {
+		Type string
+		Path string
+	}{
+		Type: "otlptracefile",
+		Path: c.cfg.path,
+	}
+}
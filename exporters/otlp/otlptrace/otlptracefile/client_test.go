@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// readRequests returns the requests written to the file at path.
+func readRequests(t *testing.T, path string, gz bool) []*coltracepb.ExportTraceServiceRequest {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var r io.Reader = f
+	if gz {
+		zr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		r = zr
+	}
+
+	var out []*coltracepb.ExportTraceServiceRequest
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		req := new(coltracepb.ExportTraceServiceRequest)
+		require.NoError(t, internal.UnmarshalJSON(scanner.Bytes(), req))
+		out = append(out, req)
+	}
+	require.NoError(t, scanner.Err())
+	return out
+}
+
+var spans = tracetest.SpanStubs{
+	{
+		Name: "span 0",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x02},
+		}),
+	},
+	{Name: "span 1"},
+}.Snapshots()
+
+func TestExporter(t *testing.T) {
+	for name, compression := range map[string]Compression{
+		"NoCompression":   NoCompression,
+		"GzipCompression": GzipCompression,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "traces.jsonl")
+			exp, err := New(ctx, WithPath(path), WithCompression(compression))
+			require.NoError(t, err)
+
+			require.NoError(t, exp.ExportSpans(ctx, spans[:1]))
+			require.NoError(t, exp.ExportSpans(ctx, spans[1:]))
+			require.NoError(t, exp.ExportSpans(ctx, nil))
+			require.NoError(t, exp.Shutdown(ctx))
+
+			reqs := readRequests(t, path, compression == GzipCompression)
+			require.Len(t, reqs, 2, "one line per export")
+			want := [][]string{{"span 0"}, {"span 1"}}
+			for i, req := range reqs {
+				var got []string
+				for _, rs := range req.GetResourceSpans() {
+					for _, ss := range rs.GetScopeSpans() {
+						for _, s := range ss.GetSpans() {
+							got = append(got, s.GetName())
+						}
+					}
+				}
+				assert.Equal(t, want[i], got)
+			}
+
+			s := reqs[0].GetResourceSpans()[0].GetScopeSpans()[0].GetSpans()[0]
+			tID, sID := spans[0].SpanContext().TraceID(), spans[0].SpanContext().SpanID()
+			assert.Equal(t, tID[:], s.GetTraceId())
+			assert.Equal(t, sID[:], s.GetSpanId())
+		})
+	}
+}
+
+func TestExporterRotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	exp, err := New(ctx, WithPath(filepath.Join(dir, "traces.jsonl")), WithMaxSize(1))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.ExportSpans(ctx, spans[:1]))
+	}
+	require.NoError(t, exp.Shutdown(ctx))
+
+	files, err := filepath.Glob(filepath.Join(dir, "traces*.jsonl"))
+	require.NoError(t, err)
+	assert.Len(t, files, 3, "one file per export")
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient(WithPath(filepath.Join(t.TempDir(), "traces.jsonl")))
+
+	rs := []*tracepb.ResourceSpans{{}}
+	assert.ErrorIs(t, c.UploadTraces(ctx, rs), errNotStarted)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, c.Start(canceled), context.Canceled)
+
+	require.NoError(t, c.Start(ctx))
+	assert.ErrorIs(t, c.UploadTraces(canceled, rs), context.Canceled)
+	assert.NoError(t, c.UploadTraces(ctx, rs))
+	assert.NoError(t, c.Stop(ctx))
+	assert.ErrorIs(t, c.UploadTraces(ctx, rs), errNotStarted)
+	assert.NoError(t, c.Stop(ctx), "second stop")
+}
+
+func TestNewInvalidPath(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	// A file cannot be used as a directory.
+	_, err := New(context.Background(), WithPath(filepath.Join(file, "traces.jsonl")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otlptracefile provides an OTLP span exporter writing to local files.
+
+The spans are written in the OTLP JSON Lines file format: each export is
+written as an OTLP/JSON encoded ExportTraceServiceRequest on its own line.
+The written files can be read by the OpenTelemetry Collector otlpjsonfile
+receiver.
+
+By default the spans are written to the "traces.jsonl" file of the current
+working directory. The file can be rotated based on its size, see
+[WithMaxSize], or on time, see [WithRotationInterval], and can be compressed,
+see [WithCompression].
+
+Exporter should be created using [New].
+*/
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlptracefile_test
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func Example() {
+	ctx := context.Background()
+	exp, err := otlptracefile.New(
+		ctx,
+		otlptracefile.WithPath("/var/log/otel/traces.jsonl.gz"),
+		otlptracefile.WithCompression(otlptracefile.GzipCompression),
+		otlptracefile.WithRotationInterval(time.Hour),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	tracerProvider := trace.NewTracerProvider(trace.WithBatcher(exp))
+	defer func() {
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			panic(err)
+		}
+	}()
+	otel.SetTracerProvider(tracerProvider)
+
+	// From here, the tracerProvider can be used by instrumentation to collect
+	// telemetry.
+}
@@ -0,0 +1,210 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/filewriter/filewriter.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filewriter provides a writer of OTLP JSON Lines files that can
+// rotate and compress the files it writes.
+package filewriter // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal/filewriter"
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the format of the timestamp added to the name of
+// rotated files. It does not contain any colon to be a valid file name on
+// all platforms.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// errClosed is returned when writing to a closed Writer.
+var errClosed = errors.New("file writer closed")
+
+// Config configures a Writer.
+type Config struct {
+	// Path is the path of the file written to.
+	Path string
+	// MaxSize is the number of bytes written to a file before it is rotated.
+	// The size is measured before compression, except for the content of an
+	// existing file that is appended to. A value of zero or less means the
+	// file is never rotated based on its size.
+	MaxSize int64
+	// Interval is the duration a file is written to before it is rotated. A
+	// value of zero or less means the file is never rotated based on time.
+	Interval time.Duration
+	// Gzip compresses the written files using gzip.
+	Gzip bool
+}
+
+// Writer writes lines to a file, rotating it when it reaches the configured
+// size or age.
+//
+// When a file is rotated, it is closed and renamed by adding the rotation
+// time to its name. A new file is then created at the configured path.
+//
+// Writer is safe for concurrent use.
+type Writer struct {
+	cfg Config
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+
+	mu     sync.Mutex
+	file   *os.File
+	gz     *gzip.Writer
+	out    io.Writer
+	size   int64
+	opened time.Time
+	closed bool
+}
+
+// New returns a Writer writing to the file at cfg.Path. The file is created if
+// it does not exist, otherwise it is appended to.
+func New(cfg Config) (*Writer, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("file writer: empty path")
+	}
+	w := &Writer{cfg: cfg, now: time.Now}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// open opens the file at the configured path. The mu lock needs to be held
+// when calling this method, unless during the creation of w.
+func (w *Writer) open() error {
+	if dir := filepath.Dir(w.cfg.Path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("file writer: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("file writer: %w", err)
+	}
+
+	w.file, w.out, w.size = f, f, 0
+	if info, err := f.Stat(); err == nil {
+		// The content of an existing compressed file is counted with its
+		// compressed size, its uncompressed size is not known.
+		w.size = info.Size()
+	}
+	if w.cfg.Gzip {
+		// Appending creates a new gzip member. Multi-member gzip files
+		// are valid and read as a single stream.
+		w.gz = gzip.NewWriter(f)
+		w.out = w.gz
+	}
+	w.opened = w.now()
+	return nil
+}
+
+// WriteLine writes line followed by a newline to the file. The file is
+// rotated before the write if the line would make it exceed the configured
+// size, or if it has been written to for longer than the configured interval.
+//
+// The line is readable from the file once WriteLine returns, compressed data
+// is flushed to the file by each call.
+func (w *Writer) WriteLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return errClosed
+	}
+
+	n := int64(len(line)) + 1
+	if w.shouldRotate(n) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	buf := make([]byte, 0, n)
+	buf = append(append(buf, line...), '\n')
+	written, err := w.out.Write(buf)
+	w.size += int64(written)
+	if err != nil {
+		return err
+	}
+	if w.gz != nil {
+		return w.gz.Flush()
+	}
+	return nil
+}
+
+// shouldRotate returns whether the file needs to be rotated before n bytes
+// are written to it. The mu lock needs to be held when calling this method.
+func (w *Writer) shouldRotate(n int64) bool {
+	if w.size == 0 {
+		// Never rotate an empty file, it would only create an empty backup.
+		return false
+	}
+	if w.cfg.MaxSize > 0 && w.size+n > w.cfg.MaxSize {
+		return true
+	}
+	return w.cfg.Interval > 0 && w.now().Sub(w.opened) >= w.cfg.Interval
+}
+
+// rotate closes the current file, renames it, and opens a new one. The mu
+// lock needs to be held when calling this method.
+func (w *Writer) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+	renameErr := os.Rename(w.cfg.Path, backupName(w.cfg.Path, w.now()))
+	// Reopen the file even if it could not be renamed so w stays usable.
+	if err := w.open(); err != nil {
+		return errors.Join(renameErr, err)
+	}
+	if renameErr != nil {
+		return fmt.Errorf("file writer: rotate: %w", renameErr)
+	}
+	return nil
+}
+
+// closeFile flushes and closes the current file. The mu lock needs to be held
+// when calling this method.
+func (w *Writer) closeFile() error {
+	var err error
+	if w.gz != nil {
+		err = w.gz.Close()
+		w.gz = nil
+	}
+	return errors.Join(err, w.file.Close())
+}
+
+// Close flushes and closes the file. Writing to w after Close is called
+// returns an error.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.closeFile()
+}
+
+// backupName returns the name a file at path is renamed to when it is rotated
+// at t. The timestamp is added before the file extensions, e.g.
+// "traces.jsonl.gz" becomes "traces-20240701T120000.000000000.jsonl.gz".
+func backupName(path string, t time.Time) string {
+	dir, name := filepath.Split(path)
+	ext := filepath.Ext(name)
+	if ext == ".gz" {
+		ext = filepath.Ext(strings.TrimSuffix(name, ext)) + ext
+	}
+	base := strings.TrimSuffix(name, ext)
+	return filepath.Join(dir, base+"-"+t.UTC().Format(backupTimeFormat)+ext)
+}
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile/internal"
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/json.go.tmpl "--data={}" --out=json.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/json_test.go.tmpl "--data={}" --out=json_test.go
+
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/filewriter.go.tmpl "--data={}" --out=filewriter/filewriter.go
+//go:generate gotmpl --body=../../../../../internal/shared/otlp/filewriter/filewriter_test.go.tmpl "--data={}" --out=filewriter/filewriter_test.go
@@ -6,6 +6,7 @@ package otlptracegrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlptra
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"time"
 
@@ -14,12 +15,17 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/observ"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/split"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
@@ -30,6 +36,17 @@ type client struct {
 	metadata      metadata.MD
 	exportTimeout time.Duration
 	requestFunc   retry.RequestFunc
+	// callOpts are the options of every export call.
+	callOpts []grpc.CallOption
+	// limits are the limits exports are split to be within.
+	limits split.Limits
+	// inst records the telemetry of the client. It is nil if no
+	// MeterProvider is configured.
+	inst *observ.Instrumentation
+	// measurePayload is true if the payload sizes are measured by the
+	// client. The stats handler of inst cannot be added to a connection
+	// passed with WithGRPCConn, only the size before compression is known.
+	measurePayload bool
 
 	// stopCtx is used as a parent context for all exports. Therefore, when it
 	// is canceled with the stopFunc all exports are canceled.
@@ -63,16 +80,38 @@ func newClient(opts ...Option) *client {
 	c := &client{
 		endpoint:      cfg.Traces.Endpoint,
 		exportTimeout: cfg.Traces.Timeout,
-		requestFunc:   cfg.RetryConfig.RequestFunc(retryable),
+		requestFunc:   cfg.RetryConfig.RequestFunc(retryableCodes(cfg.RetryableGRPCCodes)),
 		dialOpts:      cfg.DialOptions,
 		stopCtx:       ctx,
 		stopFunc:      cancel,
 		conn:          cfg.GRPCConn,
+		limits: split.Limits{
+			Bytes: cfg.Traces.MaxRequestSize,
+			Items: cfg.Traces.MaxRequestItems,
+		},
+		inst: observ.New(
+			cfg.MeterProvider,
+			"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc",
+			otlptrace.Version(),
+			observ.Spans,
+			"otlp_grpc_span_exporter",
+			cfg.Traces.Endpoint,
+		),
+	}
+	if c.inst != nil {
+		if c.conn == nil {
+			c.dialOpts = append(c.dialOpts, grpc.WithStatsHandler(c.inst.StatsHandler()))
+		} else {
+			c.measurePayload = true
+		}
 	}
 
 	if len(cfg.Traces.Headers) > 0 {
 		c.metadata = metadata.New(cfg.Traces.Headers)
 	}
+	if creds := auth.PerRPCCredentials(cfg.Authenticator); creds != nil {
+		c.callOpts = append(c.callOpts, grpc.PerRPCCredentials(creds))
+	}
 
 	return c
 }
@@ -170,7 +209,8 @@ func (c *client) Stop(ctx context.Context) error {
 
 var errShutdown = errors.New("the client is shutdown")
 
-// UploadTraces sends a batch of spans.
+// UploadTraces sends a batch of spans. The batch is split into several
+// requests if it exceeds the configured request limits.
 //
 // Retryable errors from the server will be handled according to any
 // RetryConfig the client was created with.
@@ -186,17 +226,33 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		return errShutdown
 	}
 
+	var err error
+	for _, rss := range split.Spans(protoSpans, c.limits) {
+		err = errors.Join(err, c.uploadTraces(ctx, rss))
+	}
+	return err
+}
+
+// uploadTraces sends protoSpans in a single request. The caller needs to hold
+// a read lock of tscMu.
+func (c *client) uploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
-		resp, err := c.tsc.Export(iCtx, &coltracepb.ExportTraceServiceRequest{
-			ResourceSpans: protoSpans,
-		})
+	start := time.Now()
+	var attempts, rejected int64
+	req := &coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans}
+	err := c.requestFunc(ctx, func(iCtx context.Context) error {
+		attempts++
+		if c.measurePayload {
+			c.inst.RecordUncompressedPayload(iCtx, proto.Size(req))
+		}
+		resp, err := c.tsc.Export(iCtx, req, c.callOpts...)
 		if resp != nil && resp.PartialSuccess != nil {
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedSpans()
 			if n != 0 || msg != "" {
+				rejected = n
 				err := internal.TracePartialSuccessError(n, msg)
 				otel.Handle(err)
 			}
@@ -208,6 +264,35 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		}
 		return err
 	})
+	c.recordExport(ctx, start, protoSpans, attempts, rejected, err)
+	return err
+}
+
+// recordExport records the telemetry of an export of protoSpans that started
+// at start and was attempted attempts times.
+func (c *client) recordExport(ctx context.Context, start time.Time, protoSpans []*tracepb.ResourceSpans, attempts, rejected int64, err error) {
+	if c.inst == nil {
+		return
+	}
+	c.inst.RecordRetries(ctx, attempts-1)
+
+	code := status.Code(err)
+	var errType string
+	if err != nil {
+		errType = code.String()
+	}
+	c.inst.RecordExport(ctx, start, spanCount(protoSpans), rejected, errType, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+}
+
+// spanCount returns the number of spans in rs.
+func spanCount(rs []*tracepb.ResourceSpans) int64 {
+	var n int
+	for _, r := range rs {
+		for _, ss := range r.GetScopeSpans() {
+			n += len(ss.GetSpans())
+		}
+	}
+	return int64(n)
 }
 
 // exportContext returns a copy of parent with an appropriate deadline and
@@ -272,6 +357,24 @@ func retryableGRPCStatus(s *status.Status) (bool, time.Duration) {
 	return false, 0
 }
 
+// retryableCodes returns a function returning if err identifies a request
+// that can be retried, and a duration to wait for if an explicit throttle
+// time is included in err. Only errors with one of the codes cs are
+// retried. The default retryable codes are used if cs is nil.
+func retryableCodes(cs []codes.Code) retry.EvaluateFunc {
+	if cs == nil {
+		return retryable
+	}
+	return func(err error) (bool, time.Duration) {
+		s := status.Convert(err)
+		if !slices.Contains(cs, s.Code()) {
+			return false, 0
+		}
+		_, d := throttleDelay(s)
+		return true, d
+	}
+}
+
 // throttleDelay returns of the status is RetryInfo
 // and the its duration to wait for if an explicit throttle time.
 func throttleDelay(s *status.Status) (bool, time.Duration) {
@@ -8,7 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -18,6 +21,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/status"
 
@@ -26,6 +30,8 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/otlptracetest"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
@@ -227,6 +233,111 @@ func TestNewWithHeaders(t *testing.T) {
 	assert.Equal(t, "value1", headers.Get("header1")[0])
 }
 
+type countingAuthenticator struct {
+	n   atomic.Int64
+	err error
+}
+
+func (a *countingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer token-%d", a.n.Add(1))}, nil
+}
+
+func TestNewWithAuthenticator(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	auth := &countingAuthenticator{}
+	exp := newGRPCExporter(t, ctx, mc.endpoint,
+		otlptracegrpc.WithHeaders(map[string]string{"header1": "value1"}),
+		otlptracegrpc.WithAuthenticator(auth))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Equal(t, []string{"Bearer token-1"}, mc.getHeaders().Get("authorization"))
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	headers := mc.getHeaders()
+	assert.Equal(t, []string{"Bearer token-2"}, headers.Get("authorization"), "called per request")
+	assert.Equal(t, []string{"value1"}, headers.Get("header1"))
+
+	auth.err = errors.New("no token")
+	err := exp.ExportSpans(ctx, roSpans)
+	assert.ErrorContains(t, err, "no token")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestCertificateRotation(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeCA := func(pem *pemCertificate, mod time.Time) {
+		require.NoError(t, os.WriteFile(caFile, pem.Certificate, 0o600))
+		require.NoError(t, os.Chtimes(caFile, mod, mod))
+	}
+
+	first, err := generateWeakCertificate()
+	require.NoError(t, err)
+	mod := time.Now().Add(-time.Minute)
+	writeCA(first, mod)
+	t.Setenv("OTEL_EXPORTER_OTLP_CERTIFICATE", caFile)
+
+	mc := runMockCollectorWithConfig(t, &mockConfig{endpoint: "localhost:0", tlsCert: first})
+
+	ctx, cancel := contextWithTimeout(context.Background(), t, 10*time.Second)
+	defer cancel()
+	exp, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(mc.endpoint),
+		otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 10 * time.Millisecond,
+			MaxInterval:     10 * time.Millisecond,
+			MaxElapsedTime:  5 * time.Second,
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(context.Background())) })
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Len(t, mc.getSpans(), 1)
+
+	// The collector restarts with a new certificate, trusted by the updated
+	// CA file.
+	require.NoError(t, mc.stop())
+	second, err := generateWeakCertificate()
+	require.NoError(t, err)
+	writeCA(second, mod.Add(time.Second))
+	mc = runMockCollectorWithConfig(t, &mockConfig{endpoint: mc.endpoint, tlsCert: second})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Len(t, mc.getSpans(), 1)
+}
+
+func TestMaxRequestItems(t *testing.T) {
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		endpoint: "localhost:0",
+		errors:   []error{status.Error(codes.InvalidArgument, "rejected")},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithMaxRequestItems(2))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	spans := tracetest.SpanStubs{{Name: "1"}, {Name: "2"}, {Name: "3"}, {Name: "4"}, {Name: "5"}}.Snapshots()
+	err := exp.ExportSpans(ctx, spans)
+	assert.ErrorContains(t, err, "rejected", "error of the first request")
+
+	mc.traceSvc.mu.RLock()
+	requests := mc.traceSvc.requests
+	mc.traceSvc.mu.RUnlock()
+	assert.Equal(t, 3, requests)
+	// The spans of the first request are rejected, the others are sent.
+	got := mc.getSpans()
+	require.Len(t, got, 3)
+	assert.Equal(t, "3", got[0].Name)
+}
+
 func TestExportSpansTimeoutHonored(t *testing.T) {
 	ctx, cancel := contextWithTimeout(context.Background(), t, 1*time.Minute)
 	t.Cleanup(cancel)
@@ -406,6 +517,98 @@ func TestPartialSuccess(t *testing.T) {
 	require.Contains(t, errs[0].Error(), "2 spans rejected")
 }
 
+func TestMeterProvider(t *testing.T) {
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		partial: &coltracepb.ExportTracePartialSuccess{
+			RejectedSpans: 2,
+			ErrorMessage:  "partially successful",
+		},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {}))
+
+	r := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(r))
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithMeterProvider(mp))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	spans := tracetest.SpanStubs{{Name: "a"}, {Name: "b"}, {Name: "c"}}.Snapshots()
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, r.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc", rm.ScopeMetrics[0].Scope.Name)
+
+	got := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m.Data
+	}
+
+	exported, ok := got["otel.sdk.exporter.span.exported"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	byErr := make(map[string]int64)
+	for _, dp := range exported.DataPoints {
+		v, _ := dp.Attributes.Value("error.type")
+		byErr[v.AsString()] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"": 1, "rejected": 2}, byErr)
+
+	duration, ok := got["otel.sdk.exporter.operation.duration"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+	code, _ := duration.DataPoints[0].Attributes.Value("rpc.grpc.status_code")
+	assert.Equal(t, int64(codes.OK), code.AsInt64())
+
+	for _, name := range []string{
+		"otel.sdk.exporter.payload.uncompressed_size",
+		"otel.sdk.exporter.payload.compressed_size",
+	} {
+		size, ok := got[name].(metricdata.Sum[int64])
+		require.True(t, ok, name)
+		require.Len(t, size.DataPoints, 1, name)
+		assert.Positive(t, size.DataPoints[0].Value, name)
+	}
+	assert.NotContains(t, got, "otel.sdk.exporter.operation.retries", "no retry")
+}
+
+func TestMeterProviderWithGRPCConn(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	conn, err := grpc.NewClient(mc.endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+
+	r := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(r))
+	ctx := context.Background()
+	exp, err := otlptrace.New(ctx, otlptracegrpc.NewClient(
+		otlptracegrpc.WithGRPCConn(conn),
+		otlptracegrpc.WithMeterProvider(mp),
+	))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, r.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m.Data
+	}
+
+	size, ok := got["otel.sdk.exporter.payload.uncompressed_size"].(metricdata.Sum[int64])
+	require.True(t, ok, "uncompressed size measured by the client")
+	require.Len(t, size.DataPoints, 1)
+	assert.Positive(t, size.DataPoints[0].Value)
+	assert.NotContains(t, got, "otel.sdk.exporter.payload.compressed_size", "compressed size unknown")
+}
+
 func TestCustomUserAgent(t *testing.T) {
 	customUserAgent := "custom-user-agent"
 	mc := runMockCollector(t)
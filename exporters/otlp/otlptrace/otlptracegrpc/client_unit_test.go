@@ -120,6 +120,27 @@ func TestRetryable(t *testing.T) {
 	}
 }
 
+func TestRetryableCodes(t *testing.T) {
+	evaluate := retryableCodes([]codes.Code{codes.Internal, codes.ResourceExhausted})
+
+	ok, _ := evaluate(status.Error(codes.Internal, ""))
+	assert.True(t, ok, "configured code")
+	ok, _ = evaluate(status.Error(codes.Unavailable, ""))
+	assert.False(t, ok, "default code not configured")
+
+	delay := 15 * time.Millisecond
+	s, err := status.New(codes.ResourceExhausted, "WithRetryInfo").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)},
+	)
+	require.NoError(t, err)
+	ok, d := evaluate(s.Err())
+	assert.True(t, ok, "configured code with RetryInfo")
+	assert.Equal(t, delay, d)
+
+	ok, _ = retryableCodes(nil)(status.Error(codes.Unavailable, ""))
+	assert.True(t, ok, "default codes")
+}
+
 func TestRetryableGRPCStatusResourceExhaustedWithRetryInfo(t *testing.T) {
 	delay := 15 * time.Millisecond
 	s, err := status.New(codes.ResourceExhausted, "WithRetryInfo").WithDetails(
@@ -0,0 +1,51 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/grpc.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/auth"
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// PerRPCCredentials returns gRPC per-RPC credentials adding the headers
+// returned by a to the metadata of every call. It returns nil if a is nil.
+func PerRPCCredentials(a Authenticator) credentials.PerRPCCredentials {
+	if a == nil {
+		return nil
+	}
+	return perRPCCredentials{auth: a}
+}
+
+type perRPCCredentials struct {
+	auth Authenticator
+}
+
+var _ credentials.PerRPCCredentials = perRPCCredentials{}
+
+func (c perRPCCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	h, err := c.auth.Headers(ctx)
+	if err != nil {
+		// Otherwise, gRPC reports the failure of call credentials with the
+		// Internal code.
+		return nil, status.Errorf(codes.Unauthenticated, "failed to authenticate request: %v", err)
+	}
+	md := make(map[string]string, len(h))
+	for k, v := range h {
+		// gRPC metadata keys are lowercase.
+		md[strings.ToLower(k)] = v
+	}
+	return md, nil
+}
+
+// RequireTransportSecurity returns false so the credentials can be used with
+// the insecure connections of exporters configured with WithInsecure, the
+// same as the headers passed with WithHeaders.
+func (perRPCCredentials) RequireTransportSecurity() bool { return false }
@@ -0,0 +1,304 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/certreload/certreload_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package certreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authority is a certificate authority with a server and client certificate
+// it issued. The certificates are valid for the hosts the authority was
+// created with, localhost and 127.0.0.1 by default.
+type authority struct {
+	caPEM      []byte
+	server     tls.Certificate
+	clientPEM  []byte
+	clientKey  []byte
+	clientPool *x509.CertPool
+}
+
+func newAuthority(t *testing.T, name string, hosts ...string) authority {
+	t.Helper()
+
+	if len(hosts) == 0 {
+		hosts = []string{"localhost", "127.0.0.1"}
+	}
+	var (
+		dnsNames []string
+		ips      []net.IP
+	)
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			ips = append(ips, ip)
+		} else {
+			dnsNames = append(dnsNames, h)
+		}
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	issue := func(serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			DNSNames:     dnsNames,
+			IPAddresses:  ips,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	a := authority{
+		caPEM:      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		clientPool: x509.NewCertPool(),
+	}
+	a.clientPool.AddCert(ca)
+	serverCert, serverKey := issue(2, x509.ExtKeyUsageServerAuth)
+	a.server, err = tls.X509KeyPair(serverCert, serverKey)
+	require.NoError(t, err)
+	a.clientPEM, a.clientKey = issue(3, x509.ExtKeyUsageClientAuth)
+	return a
+}
+
+// write writes the client files of a to files. The modification time is set
+// to mod so changes are detected regardless of the file system precision.
+func (a authority) write(t *testing.T, files Files, mod time.Time) {
+	t.Helper()
+	for path, data := range map[string][]byte{
+		files.CA:   a.caPEM,
+		files.Cert: a.clientPEM,
+		files.Key:  a.clientKey,
+	} {
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		require.NoError(t, os.Chtimes(path, mod, mod))
+	}
+}
+
+func testFiles(t *testing.T) Files {
+	dir := t.TempDir()
+	return Files{
+		CA:   filepath.Join(dir, "ca.pem"),
+		Cert: filepath.Join(dir, "client.pem"),
+		Key:  filepath.Join(dir, "client.key"),
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	files := testFiles(t)
+	_, err := New(files, os.ReadFile)
+	assert.Error(t, err, "missing files")
+
+	a := newAuthority(t, "test")
+	a.write(t, files, time.Now())
+	require.NoError(t, os.WriteFile(files.CA, []byte("invalid"), 0o600))
+	_, err = New(Files{CA: files.CA}, os.ReadFile)
+	assert.EqualError(t, err, "certificate not added")
+
+	_, err = New(Files{Cert: files.Cert, Key: files.CA}, os.ReadFile)
+	assert.Error(t, err, "invalid key")
+}
+
+func TestTLSConfig(t *testing.T) {
+	r, err := New(Files{}, os.ReadFile)
+	require.NoError(t, err)
+	c := r.TLSConfig("localhost:4317")
+	assert.Equal(t, "localhost", c.ServerName)
+	assert.Nil(t, c.RootCAs)
+	assert.Nil(t, c.VerifyConnection)
+	assert.Nil(t, c.GetClientCertificate)
+	assert.False(t, c.InsecureSkipVerify)
+
+	files := testFiles(t)
+	newAuthority(t, "test").write(t, files, time.Now())
+	r, err = New(files, os.ReadFile)
+	require.NoError(t, err)
+	c = r.TLSConfig("localhost:4317")
+	assert.Len(t, c.RootCAs.Subjects(), 1) // nolint:staticcheck // used for testing only
+	assert.Len(t, c.Certificates, 1)
+	assert.NotNil(t, c.VerifyConnection)
+	assert.NotNil(t, c.GetClientCertificate)
+}
+
+func TestServerName(t *testing.T) {
+	for endpoint, want := range map[string]string{
+		"":                       "",
+		"localhost":              "localhost",
+		"localhost:4317":         "localhost",
+		"collector:4317/prefix":  "collector",
+		"https://collector:4318": "collector",
+		"dns:///collector:4317":  "collector",
+		"127.0.0.1:4317":         "127.0.0.1",
+		"[::1]:4317":             "::1",
+		"[::1]":                  "::1",
+	} {
+		assert.Equal(t, want, serverName(endpoint), endpoint)
+	}
+}
+
+func TestVerifyHost(t *testing.T) {
+	testcases := []struct {
+		name  string
+		hosts []string
+		// endpoint returns the endpoint to connect to the server listening
+		// on addr.
+		endpoint func(addr string) string
+		wantErr  bool
+	}{
+		{
+			name:     "IP",
+			hosts:    []string{"127.0.0.1"},
+			endpoint: func(addr string) string { return addr },
+		},
+		{
+			name:     "IPOtherSAN",
+			hosts:    []string{"collector.example", "127.0.0.2"},
+			endpoint: func(addr string) string { return addr },
+			wantErr:  true,
+		},
+		{
+			name:  "Hostname",
+			hosts: []string{"localhost"},
+			endpoint: func(addr string) string {
+				_, port, _ := net.SplitHostPort(addr)
+				return net.JoinHostPort("localhost", port)
+			},
+		},
+		{
+			name:  "HostnameOtherSAN",
+			hosts: []string{"collector.example", "127.0.0.1"},
+			endpoint: func(addr string) string {
+				_, port, _ := net.SplitHostPort(addr)
+				return net.JoinHostPort("localhost", port)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newAuthority(t, "test", tc.hosts...)
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			srv.TLS = &tls.Config{Certificates: []tls.Certificate{a.server}}
+			srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+			srv.StartTLS()
+			t.Cleanup(srv.Close)
+
+			files := testFiles(t)
+			a.write(t, files, time.Now())
+			r, err := New(Files{CA: files.CA}, os.ReadFile)
+			require.NoError(t, err)
+
+			endpoint := tc.endpoint(srv.Listener.Addr().String())
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig:   r.TLSConfig(endpoint),
+				DisableKeepAlives: true,
+			}}
+			resp, err := client.Get("https://" + endpoint)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "certificate is valid for")
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, resp.Body.Close())
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	first, second := newAuthority(t, "first"), newAuthority(t, "second")
+	var current atomic.Pointer[authority]
+	current.Store(&first)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	srv.TLS = &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			a := current.Load()
+			return &tls.Config{
+				Certificates: []tls.Certificate{a.server},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    a.clientPool,
+			}, nil
+		},
+	}
+	// Failed handshakes are expected, do not log them.
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	files := testFiles(t)
+	mod := time.Now().Add(-time.Minute)
+	first.write(t, files, mod)
+	r, err := New(files, os.ReadFile)
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   r.TLSConfig(srv.URL),
+		DisableKeepAlives: true,
+	}}
+	get := func() error {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	require.NoError(t, get(), "initial certificates")
+
+	current.Store(&second)
+	assert.Error(t, get(), "server rotated, client not")
+
+	second.write(t, files, mod.Add(time.Second))
+	assert.NoError(t, get(), "client reloaded rotated certificates")
+
+	// Invalid files are ignored and the previous certificates kept.
+	require.NoError(t, os.WriteFile(files.CA, []byte("invalid"), 0o600))
+	assert.NoError(t, get(), "invalid update ignored")
+}
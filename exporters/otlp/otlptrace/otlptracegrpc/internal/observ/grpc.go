@@ -0,0 +1,45 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/observ/grpc.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package observ // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/observ"
+
+import (
+	"context"
+
+	"google.golang.org/grpc/stats"
+)
+
+// StatsHandler returns a gRPC stats.Handler that records the size of the
+// payloads sent on a connection. It returns nil if i is nil.
+func (i *Instrumentation) StatsHandler() stats.Handler {
+	if i == nil {
+		return nil
+	}
+	return statsHandler{inst: i}
+}
+
+type statsHandler struct {
+	inst *Instrumentation
+}
+
+var _ stats.Handler = statsHandler{}
+
+func (statsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h statsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if p, ok := s.(*stats.OutPayload); ok {
+		// CompressedLength equals Length when no compressor is used.
+		h.inst.RecordPayload(ctx, p.Length, p.CompressedLength)
+	}
+}
+
+func (statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (statsHandler) HandleConn(context.Context, stats.ConnStats) {}
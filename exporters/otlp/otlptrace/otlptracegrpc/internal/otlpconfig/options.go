@@ -17,13 +17,17 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/compress"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/retry"
 	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/metric"
 )
 
 const (
@@ -48,11 +52,26 @@ type (
 		Compression Compression
 		Timeout     time.Duration
 		URLPath     string
+		Marshaler   Marshaler
+
+		// MaxRequestSize is the maximum size, in bytes, and MaxRequestItems
+		// the maximum number of items of an export request. Larger requests
+		// are split. Zero means no limit.
+		MaxRequestSize  int
+		MaxRequestItems int
+
+		// ReloadingTLSCfg returns the TLS configuration for an endpoint
+		// reloading its certificates when the files they are loaded from
+		// change. It is used if neither TLSCfg nor GRPCCredentials are set.
+		ReloadingTLSCfg func(endpoint string) *tls.Config
 
 		// gRPC configurations
 		GRPCCredentials credentials.TransportCredentials
 
 		Proxy HTTPTransportProxyFunc
+		// HTTPClient is the client used to send the HTTP requests. The
+		// client is created from the other HTTP configurations if it is nil.
+		HTTPClient *http.Client
 	}
 
 	Config struct {
@@ -60,12 +79,25 @@ type (
 		Traces SignalConfig
 
 		RetryConfig retry.Config
+		// RetryableGRPCCodes are the codes of the gRPC errors retried and
+		// RetryableHTTPStatusCodes the status codes of the HTTP responses
+		// retried. The default codes are used if they are nil.
+		RetryableGRPCCodes       []codes.Code
+		RetryableHTTPStatusCodes []int
 
 		// gRPC configurations
 		ReconnectionPeriod time.Duration
 		ServiceConfig      string
 		DialOptions        []grpc.DialOption
 		GRPCConn           *grpc.ClientConn
+
+		// MeterProvider is used to report telemetry about the exporter
+		// itself. No telemetry is reported if it is nil.
+		MeterProvider metric.MeterProvider
+
+		// Authenticator provides the headers authenticating every request.
+		// No authentication is done if it is nil.
+		Authenticator auth.Authenticator
 	}
 )
 
@@ -85,6 +117,9 @@ func NewHTTPConfig(opts ...HTTPOption) Config {
 	for _, opt := range opts {
 		cfg = opt.ApplyHTTPOption(cfg)
 	}
+	if cfg.Traces.TLSCfg == nil && cfg.Traces.ReloadingTLSCfg != nil {
+		cfg.Traces.TLSCfg = cfg.Traces.ReloadingTLSCfg(cfg.Traces.Endpoint)
+	}
 	cfg.Traces.URLPath = cleanPath(cfg.Traces.URLPath, DefaultTracesPath)
 	return cfg
 }
@@ -121,6 +156,9 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 	for _, opt := range opts {
 		cfg = opt.ApplyGRPCOption(cfg)
 	}
+	if cfg.Traces.GRPCCredentials == nil && cfg.Traces.ReloadingTLSCfg != nil {
+		cfg.Traces.GRPCCredentials = credentials.NewTLS(cfg.Traces.ReloadingTLSCfg(cfg.Traces.Endpoint))
+	}
 
 	if cfg.ServiceConfig != "" {
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultServiceConfig(cfg.ServiceConfig))
@@ -136,8 +174,13 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 		cfg.Traces.GRPCCredentials = creds
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithTransportCredentials(creds))
 	}
-	if cfg.Traces.Compression == GzipCompression {
+	switch cfg.Traces.Compression {
+	case GzipCompression:
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	case ZstdCompression:
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(compress.ZstdName)))
+	case SnappyCompression:
+		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(compress.SnappyName)))
 	}
 	if cfg.ReconnectionPeriod != 0 {
 		p := grpc.ConnectParams{
@@ -293,6 +336,13 @@ func WithCompression(compression Compression) GenericOption {
 	})
 }
 
+func WithMarshal(m Marshaler) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.Marshaler = m
+		return cfg
+	})
+}
+
 func WithURLPath(urlPath string) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.URLPath = urlPath
@@ -351,3 +401,38 @@ func WithProxy(pf HTTPTransportProxyFunc) GenericOption {
 		return cfg
 	})
 }
+
+func WithHTTPClient(c *http.Client) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.HTTPClient = c
+		return cfg
+	})
+}
+
+func WithMeterProvider(mp metric.MeterProvider) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.MeterProvider = mp
+		return cfg
+	})
+}
+
+func WithAuthenticator(a auth.Authenticator) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Authenticator = a
+		return cfg
+	})
+}
+
+func WithMaxRequestSize(n int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.MaxRequestSize = n
+		return cfg
+	})
+}
+
+func WithMaxRequestItems(n int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.MaxRequestItems = n
+		return cfg
+	})
+}
@@ -0,0 +1,58 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/traces.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/split"
+
+import (
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var spans = splitter[[]*tracepb.ResourceSpans]{
+	count: spanCount,
+	size: func(rss []*tracepb.ResourceSpans, size func(proto.Message) int) int {
+		return size(&coltracepb.ExportTraceServiceRequest{ResourceSpans: rss})
+	},
+	cut: func(rss []*tracepb.ResourceSpans, n int) ([]*tracepb.ResourceSpans, []*tracepb.ResourceSpans) {
+		return cut(rss, n, resourceSpanCount, cutResourceSpans)
+	},
+}
+
+// Spans returns the resource spans of the requests rss is split in to be
+// within l.
+func Spans(rss []*tracepb.ResourceSpans, l Limits) [][]*tracepb.ResourceSpans {
+	return spans.split(nil, rss, l)
+}
+
+func spanCount(rss []*tracepb.ResourceSpans) int {
+	var n int
+	for _, rs := range rss {
+		n += resourceSpanCount(rs)
+	}
+	return n
+}
+
+func resourceSpanCount(rs *tracepb.ResourceSpans) int {
+	var n int
+	for _, ss := range rs.GetScopeSpans() {
+		n += len(ss.Spans)
+	}
+	return n
+}
+
+func cutResourceSpans(rs *tracepb.ResourceSpans, n int) (*tracepb.ResourceSpans, *tracepb.ResourceSpans) {
+	head, tail := cut(rs.ScopeSpans, n, func(ss *tracepb.ScopeSpans) int {
+		return len(ss.Spans)
+	}, cutScopeSpans)
+	return &tracepb.ResourceSpans{Resource: rs.Resource, ScopeSpans: head, SchemaUrl: rs.SchemaUrl},
+		&tracepb.ResourceSpans{Resource: rs.Resource, ScopeSpans: tail, SchemaUrl: rs.SchemaUrl}
+}
+
+func cutScopeSpans(ss *tracepb.ScopeSpans, n int) (*tracepb.ScopeSpans, *tracepb.ScopeSpans) {
+	return &tracepb.ScopeSpans{Scope: ss.Scope, Spans: ss.Spans[:n], SchemaUrl: ss.SchemaUrl},
+		&tracepb.ScopeSpans{Scope: ss.Scope, Spans: ss.Spans[n:], SchemaUrl: ss.SchemaUrl}
+}
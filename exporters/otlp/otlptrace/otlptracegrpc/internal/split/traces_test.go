@@ -0,0 +1,100 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/split/traces_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func resourceSpans(resource, scope string, names ...string) *tracepb.ResourceSpans {
+	spans := make([]*tracepb.Span, len(names))
+	for i, n := range names {
+		spans[i] = &tracepb.Span{Name: n}
+	}
+	return &tracepb.ResourceSpans{
+		Resource: &rpb.Resource{Attributes: []*cpb.KeyValue{
+			{
+				Key:   "service.name",
+				Value: &cpb.AnyValue{Value: &cpb.AnyValue_StringValue{StringValue: resource}},
+			},
+		}},
+		ScopeSpans: []*tracepb.ScopeSpans{
+			{
+				Scope: &cpb.InstrumentationScope{Name: scope},
+				Spans: spans,
+			},
+		},
+		SchemaUrl: "https://opentelemetry.io/schemas/1.26.0",
+	}
+}
+
+func TestSpans(t *testing.T) {
+	a := resourceSpans("a", "scope", "1", "2", "3")
+	b := resourceSpans("b", "scope", "4", "5")
+	rss := []*tracepb.ResourceSpans{a, b}
+
+	assert.Equal(t, [][]*tracepb.ResourceSpans{rss}, Spans(rss, Limits{}))
+
+	got := Spans(rss, Limits{Items: 2})
+	want := [][]*tracepb.ResourceSpans{
+		{resourceSpans("a", "scope", "1", "2")},
+		{resourceSpans("a", "scope", "3"), resourceSpans("b", "scope", "4")},
+		{resourceSpans("b", "scope", "5")},
+	}
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Truef(t, proto.Equal(
+			&coltracepb.ExportTraceServiceRequest{ResourceSpans: want[i]},
+			&coltracepb.ExportTraceServiceRequest{ResourceSpans: got[i]},
+		), "request %d", i)
+	}
+	assert.Equal(t, 5, spanCount(rss), "input not modified")
+}
+
+func TestSpansBytes(t *testing.T) {
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = strings.Repeat("x", 100)
+	}
+	rss := []*tracepb.ResourceSpans{resourceSpans("a", "scope", names...)}
+
+	const limit = 1000
+	got := Spans(rss, Limits{Bytes: limit})
+	require.Greater(t, len(got), 1)
+	var n int
+	for _, req := range got {
+		assert.LessOrEqual(t, spans.size(req, proto.Size), limit)
+		n += spanCount(req)
+	}
+	assert.Equal(t, 100, n, "all spans sent")
+}
+
+func TestSpansSize(t *testing.T) {
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = strings.Repeat("x", 100)
+	}
+	rss := []*tracepb.ResourceSpans{resourceSpans("a", "scope", names...)}
+
+	// An encoding twice the size of the protobuf encoding.
+	size := func(m proto.Message) int { return 2 * proto.Size(m) }
+	const limit = 1000
+	got := Spans(rss, Limits{Bytes: limit, Size: size})
+	require.Greater(t, len(got), len(Spans(rss, Limits{Bytes: limit})))
+	for _, req := range got {
+		assert.LessOrEqual(t, spans.size(req, size), limit)
+	}
+}
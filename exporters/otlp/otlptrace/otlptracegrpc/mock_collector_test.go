@@ -5,6 +5,7 @@ package otlptracegrpc_test
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/otlptracetest"
@@ -102,6 +104,7 @@ type mockConfig struct {
 	errors   []error
 	endpoint string
 	partial  *collectortracepb.ExportTracePartialSuccess
+	tlsCert  *pemCertificate
 }
 
 var _ collectortracepb.TraceServiceServer = (*mockTraceService)(nil)
@@ -162,7 +165,15 @@ func runMockCollectorWithConfig(t *testing.T, mockConfig *mockConfig) *mockColle
 	ln, err := net.Listen("tcp", mockConfig.endpoint)
 	require.NoError(t, err, "net.Listen")
 
-	srv := grpc.NewServer()
+	var opts []grpc.ServerOption
+	if mockConfig.tlsCert != nil {
+		cert, err := tls.X509KeyPair(mockConfig.tlsCert.Certificate, mockConfig.tlsCert.PrivateKey)
+		require.NoError(t, err)
+		opts = append(opts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+		})))
+	}
+	srv := grpc.NewServer(opts...)
 	mc := makeMockCollector(t, mockConfig)
 	collectortracepb.RegisterTraceServiceServer(srv, mc.traceSvc)
 	go func() {
@@ -4,15 +4,18 @@
 package otlptracegrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc/internal/retry"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // Option applies an option to the gRPC driver.
@@ -109,8 +112,13 @@ func WithReconnectionPeriod(rp time.Duration) Option {
 }
 
 func compressorToCompression(compressor string) otlpconfig.Compression {
-	if compressor == "gzip" {
+	switch compressor {
+	case "gzip":
 		return otlpconfig.GzipCompression
+	case "zstd":
+		return otlpconfig.ZstdCompression
+	case "snappy":
+		return otlpconfig.SnappyCompression
 	}
 
 	otel.Handle(fmt.Errorf("invalid compression type: '%s', using no compression as default", compressor))
@@ -118,7 +126,7 @@ func compressorToCompression(compressor string) otlpconfig.Compression {
 }
 
 // WithCompressor sets the compressor for the gRPC client to use when sending
-// requests. Supported compressor values: "gzip".
+// requests. Supported compressor values: "gzip", "zstd", "snappy".
 func WithCompressor(compressor string) Option {
 	return wrappedOption{otlpconfig.WithCompression(compressorToCompression(compressor))}
 }
@@ -208,3 +216,97 @@ func WithTimeout(duration time.Duration) Option {
 func WithRetry(settings RetryConfig) Option {
 	return wrappedOption{otlpconfig.WithRetry(retry.Config(settings))}
 }
+
+// WithRetryableCodes sets the codes of the gRPC errors for which the export
+// of a batch of spans is retried according to the policy set with WithRetry.
+// Errors with any other code are not retried.
+//
+// By default, if this option is not passed, errors with the Canceled,
+// DeadlineExceeded, Aborted, OutOfRange, Unavailable, and DataLoss codes are
+// retried, as well as ResourceExhausted errors including RetryInfo details.
+func WithRetryableCodes(cs ...codes.Code) Option {
+	return wrappedOption{otlpconfig.NewGRPCOption(func(cfg otlpconfig.Config) otlpconfig.Config {
+		cfg.RetryableGRPCCodes = append([]codes.Code{}, cs...)
+		return cfg
+	})}
+}
+
+// WithMeterProvider sets the MeterProvider used to report telemetry about the
+// exporter itself. The following metrics are reported:
+//
+//   - otel.sdk.exporter.span.exported: the number of spans exported. Spans
+//     that failed to be exported, or that were rejected by the collector in
+//     a partial success response, have the error.type attribute set.
+//   - otel.sdk.exporter.operation.duration: the duration of exports.
+//   - otel.sdk.exporter.operation.retries: the number of retried requests.
+//   - otel.sdk.exporter.payload.uncompressed_size and
+//     otel.sdk.exporter.payload.compressed_size: the size of the sent
+//     payloads before and after compression.
+//
+// When the gRPC connection is passed with WithGRPCConn, the payload size is
+// measured by the exporter before the request is sent. Only
+// otel.sdk.exporter.payload.uncompressed_size is reported, the size after
+// the compression configured on the connection is not known.
+//
+// By default, if this option is not passed, no telemetry is reported.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return wrappedOption{otlpconfig.WithMeterProvider(mp)}
+}
+
+// Authenticator provides the headers used to authenticate export requests,
+// e.g. an "Authorization" header holding a bearer token.
+//
+// Headers is called before every request sent, including retries, and the
+// returned headers are added to the request metadata. Header names are
+// converted to lowercase as required by gRPC. The headers are sent in
+// addition to the headers passed with WithHeaders. They are also sent when the
+// gRPC connection is passed with WithGRPCConn.
+// If Headers returns an error, the request is not sent and the export fails.
+//
+// Headers needs to be safe to call concurrently.
+type Authenticator interface {
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// WithAuthenticator sets the Authenticator used to authenticate every
+// request sent by the exporter. Unlike the static headers passed with
+// WithHeaders, it allows credentials that expire, like OAuth 2.0 access
+// tokens, to be refreshed without creating a new exporter.
+//
+// The go.opentelemetry.io/otel/exporters/otlp/otlpauth package provides an
+// Authenticator using the OAuth 2.0 client credentials grant.
+//
+// By default, if this option is not passed, requests are not authenticated
+// other than with the configured headers and TLS credentials.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{otlpconfig.WithAuthenticator(a)}
+}
+
+// WithMaxRequestSize sets the maximum size, in bytes, of the requests sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the spans grouped by their resource and instrumentation
+// scope. The size is the one of the protobuf encoded request, before
+// compression. A single span larger than n is sent in its own request.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by size.
+func WithMaxRequestSize(n int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestSize(n)}
+}
+
+// WithMaxRequestItems sets the maximum number of spans in a request sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the spans grouped by their resource and instrumentation
+// scope.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by their number of spans.
+func WithMaxRequestItems(n int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestItems(n)}
+}
@@ -10,9 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,10 +23,16 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/auth"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/compress"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/observ"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/split"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
@@ -38,6 +46,15 @@ var gzPool = sync.Pool{
 	},
 }
 
+// defaultRetryableStatusCodes are the status codes of the responses retried
+// if no retryable status codes are configured.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
 // Keep it in sync with golang's DefaultTransport from net/http! We
 // have our own copy to avoid handling a situation where the
 // DefaultTransport is overwritten with some different implementation
@@ -60,9 +77,14 @@ type client struct {
 	cfg         otlpconfig.SignalConfig
 	generalCfg  otlpconfig.Config
 	requestFunc retry.RequestFunc
-	client      *http.Client
-	stopCh      chan struct{}
-	stopOnce    sync.Once
+	// retryableStatusCodes are the status codes of the responses retried.
+	retryableStatusCodes []int
+	client               *http.Client
+	stopCh               chan struct{}
+	stopOnce             sync.Once
+	// inst records the telemetry of the client. It is nil if no
+	// MeterProvider is configured.
+	inst *observ.Instrumentation
 }
 
 var _ otlptrace.Client = (*client)(nil)
@@ -71,31 +93,53 @@ var _ otlptrace.Client = (*client)(nil)
 func NewClient(opts ...Option) otlptrace.Client {
 	cfg := otlpconfig.NewHTTPConfig(asHTTPOptions(opts)...)
 
-	httpClient := &http.Client{
-		Transport: ourTransport,
-		Timeout:   cfg.Traces.Timeout,
-	}
+	httpClient := cfg.Traces.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Transport: ourTransport,
+			Timeout:   cfg.Traces.Timeout,
+		}
 
-	if cfg.Traces.TLSCfg != nil || cfg.Traces.Proxy != nil {
-		clonedTransport := ourTransport.Clone()
-		httpClient.Transport = clonedTransport
+		if cfg.Traces.TLSCfg != nil || cfg.Traces.Proxy != nil {
+			clonedTransport := ourTransport.Clone()
+			httpClient.Transport = clonedTransport
 
-		if cfg.Traces.TLSCfg != nil {
-			clonedTransport.TLSClientConfig = cfg.Traces.TLSCfg
-		}
-		if cfg.Traces.Proxy != nil {
-			clonedTransport.Proxy = cfg.Traces.Proxy
+			if cfg.Traces.TLSCfg != nil {
+				clonedTransport.TLSClientConfig = cfg.Traces.TLSCfg
+			}
+			if cfg.Traces.Proxy != nil {
+				clonedTransport.Proxy = cfg.Traces.Proxy
+			}
 		}
 	}
 
+	componentType := "otlp_http_span_exporter"
+	if cfg.Traces.Marshaler == otlpconfig.MarshalJSON {
+		componentType = "otlp_http_json_span_exporter"
+	}
+
+	retryableStatusCodes := cfg.RetryableHTTPStatusCodes
+	if retryableStatusCodes == nil {
+		retryableStatusCodes = defaultRetryableStatusCodes
+	}
+
 	stopCh := make(chan struct{})
 	return &client{
-		name:        "traces",
-		cfg:         cfg.Traces,
-		generalCfg:  cfg,
-		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
-		stopCh:      stopCh,
-		client:      httpClient,
+		name:                 "traces",
+		cfg:                  cfg.Traces,
+		generalCfg:           cfg,
+		requestFunc:          cfg.RetryConfig.RequestFunc(evaluate),
+		retryableStatusCodes: retryableStatusCodes,
+		stopCh:               stopCh,
+		client:               httpClient,
+		inst: observ.New(
+			cfg.MeterProvider,
+			"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp",
+			otlptrace.Version(),
+			observ.Spans,
+			componentType,
+			cfg.Traces.Endpoint,
+		),
 	}
 }
 
@@ -123,12 +167,26 @@ func (d *client) Stop(ctx context.Context) error {
 	return nil
 }
 
-// UploadTraces sends a batch of spans to the collector.
+// UploadTraces sends a batch of spans to the collector. The batch is split
+// into several requests if it exceeds the configured request limits.
 func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	limits := split.Limits{Bytes: d.cfg.MaxRequestSize, Items: d.cfg.MaxRequestItems}
+	if d.cfg.Marshaler == otlpconfig.MarshalJSON {
+		limits.Size = internal.JSONSize
+	}
+	var err error
+	for _, rss := range split.Spans(protoSpans, limits) {
+		err = errors.Join(err, d.uploadTraces(ctx, rss))
+	}
+	return err
+}
+
+// uploadTraces sends protoSpans to the collector in a single request.
+func (d *client) uploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
 	pbRequest := &coltracepb.ExportTraceServiceRequest{
 		ResourceSpans: protoSpans,
 	}
-	rawRequest, err := proto.Marshal(pbRequest)
+	rawRequest, err := d.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -141,14 +199,22 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		return err
 	}
 
-	return d.requestFunc(ctx, func(ctx context.Context) error {
+	start := time.Now()
+	var attempts, rejected int64
+	var statusCode int
+	err = d.requestFunc(ctx, func(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 		}
 
+		attempts++
 		request.reset(ctx)
+		if err := auth.SetHeaders(ctx, d.generalCfg.Authenticator, request.Header); err != nil {
+			return err
+		}
+		d.inst.RecordPayload(ctx, len(rawRequest), request.size)
 		resp, err := d.client.Do(request.Request)
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) && urlErr.Temporary() {
@@ -166,6 +232,7 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 			}()
 		}
 
+		statusCode = resp.StatusCode
 		switch sc := resp.StatusCode; {
 		case sc >= 200 && sc <= 299:
 			// Success, do not retry.
@@ -178,27 +245,23 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 				return nil
 			}
 
-			if resp.Header.Get("Content-Type") == "application/x-protobuf" {
-				var respProto coltracepb.ExportTraceServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
-					return err
-				}
+			var respProto coltracepb.ExportTraceServiceResponse
+			if ok, err := unmarshalResponse(resp.Header, respData.Bytes(), &respProto); !ok || err != nil {
+				return err
+			}
 
-				if respProto.PartialSuccess != nil {
-					msg := respProto.PartialSuccess.GetErrorMessage()
-					n := respProto.PartialSuccess.GetRejectedSpans()
-					if n != 0 || msg != "" {
-						err := internal.TracePartialSuccessError(n, msg)
-						otel.Handle(err)
-					}
+			if respProto.PartialSuccess != nil {
+				msg := respProto.PartialSuccess.GetErrorMessage()
+				n := respProto.PartialSuccess.GetRejectedSpans()
+				if n != 0 || msg != "" {
+					rejected = n
+					err := internal.TracePartialSuccessError(n, msg)
+					otel.Handle(err)
 				}
 			}
 			return nil
 
-		case sc == http.StatusTooManyRequests,
-			sc == http.StatusBadGateway,
-			sc == http.StatusServiceUnavailable,
-			sc == http.StatusGatewayTimeout:
+		case slices.Contains(d.retryableStatusCodes, sc):
 			// Retry-able failures.
 			rErr := newResponseError(resp.Header, nil)
 
@@ -224,6 +287,39 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 			return fmt.Errorf("failed to send to %s: %s", request.URL, resp.Status)
 		}
 	})
+	d.recordExport(ctx, start, protoSpans, attempts, rejected, statusCode, err)
+	return err
+}
+
+// recordExport records the telemetry of an export of protoSpans that started
+// at start and was attempted attempts times. statusCode is the status code of
+// the last response received, or zero if none was.
+func (d *client) recordExport(ctx context.Context, start time.Time, protoSpans []*tracepb.ResourceSpans, attempts, rejected int64, statusCode int, err error) {
+	if d.inst == nil {
+		return
+	}
+	d.inst.RecordRetries(ctx, attempts-1)
+
+	var extra []attribute.KeyValue
+	if statusCode != 0 {
+		extra = append(extra, semconv.HTTPResponseStatusCode(statusCode))
+	}
+	errType := observ.ErrorType(err)
+	if err != nil && statusCode != 0 && (statusCode < 200 || statusCode > 299) {
+		errType = strconv.Itoa(statusCode)
+	}
+	d.inst.RecordExport(ctx, start, spanCount(protoSpans), rejected, errType, extra...)
+}
+
+// spanCount returns the number of spans in rs.
+func spanCount(rs []*tracepb.ResourceSpans) int64 {
+	var n int
+	for _, r := range rs {
+		for _, ss := range r.GetScopeSpans() {
+			n += len(ss.GetSpans())
+		}
+	}
+	return int64(n)
 }
 
 func (d *client) newRequest(body []byte) (request, error) {
@@ -239,13 +335,18 @@ func (d *client) newRequest(body []byte) (request, error) {
 	for k, v := range d.cfg.Headers {
 		r.Header.Set(k, v)
 	}
-	r.Header.Set("Content-Type", contentTypeProto)
+	if d.cfg.Marshaler == otlpconfig.MarshalJSON {
+		r.Header.Set("Content-Type", internal.ContentTypeJSON)
+	} else {
+		r.Header.Set("Content-Type", contentTypeProto)
+	}
 
 	req := request{Request: r}
 	switch Compression(d.cfg.Compression) {
 	case NoCompression:
 		r.ContentLength = (int64)(len(body))
 		req.bodyReader = bodyReader(body)
+		req.size = len(body)
 	case GzipCompression:
 		// Ensure the content length is not used.
 		r.ContentLength = -1
@@ -266,11 +367,53 @@ func (d *client) newRequest(body []byte) (request, error) {
 		}
 
 		req.bodyReader = bodyReader(b.Bytes())
+		req.size = b.Len()
+	case ZstdCompression:
+		b, err := compress.Zstd(nil, body)
+		if err != nil {
+			return req, err
+		}
+		r.ContentLength = int64(len(b))
+		r.Header.Set("Content-Encoding", compress.ZstdName)
+		req.bodyReader = bodyReader(b)
+		req.size = len(b)
+	case SnappyCompression:
+		b := compress.Snappy(body)
+		r.ContentLength = int64(len(b))
+		r.Header.Set("Content-Encoding", compress.SnappyName)
+		req.bodyReader = bodyReader(b)
+		req.size = len(b)
 	}
 
 	return req, nil
 }
 
+// marshal returns the encoding of m configured for d.
+func (d *client) marshal(m proto.Message) ([]byte, error) {
+	if d.cfg.Marshaler == otlpconfig.MarshalJSON {
+		return internal.MarshalJSON(m)
+	}
+	return proto.Marshal(m)
+}
+
+// unmarshalResponse parses the response body data into m based on the
+// Content-Type in header. It returns false if the Content-Type is not a
+// supported OTLP encoding and data was not parsed.
+func unmarshalResponse(header http.Header, data []byte, m proto.Message) (bool, error) {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false, nil
+	}
+	switch mediaType {
+	case contentTypeProto:
+		return true, proto.Unmarshal(data, m)
+	case internal.ContentTypeJSON:
+		return true, internal.UnmarshalJSON(data, m)
+	default:
+		return false, nil
+	}
+}
+
 // MarshalLog is the marshaling function used by the logging system to represent this Client.
 func (d *client) MarshalLog() interface{} {
 	return struct {
@@ -297,6 +440,8 @@ type request struct {
 
 	// bodyReader allows the same body to be used for multiple requests.
 	bodyReader func() io.ReadCloser
+	// size is the size of the body, after compression.
+	size int
 }
 
 // reset reinitializes the request Body and uses ctx for the request.
@@ -7,9 +7,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,9 +25,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlptracetest"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 )
 
@@ -65,6 +77,31 @@ func TestEndToEnd(t *testing.T) {
 				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
 			},
 		},
+		{
+			name: "with zstd compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.ZstdCompression),
+			},
+		},
+		{
+			name: "with snappy compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.SnappyCompression),
+			},
+		},
+		{
+			name: "with JSON encoding",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithEncoding(otlptracehttp.JSONEncoding),
+			},
+		},
+		{
+			name: "with JSON encoding and gzip compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithEncoding(otlptracehttp.JSONEncoding),
+				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+			},
+		},
 		{
 			name: "retry",
 			opts: []otlptracehttp.Option{
@@ -271,6 +308,80 @@ func TestNoRetry(t *testing.T) {
 	assert.Empty(t, mc.GetSpans())
 }
 
+func TestRetryableStatusCodes(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusInternalServerError},
+	})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetryableStatusCodes(http.StatusInternalServerError),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: 1 * time.Nanosecond,
+			MaxInterval:     1 * time.Nanosecond,
+			MaxElapsedTime:  time.Minute,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.NoError(t, err)
+	assert.Len(t, mc.GetSpans(), 1)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	hc, paths := newUnixSocketServer(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint("localhost"),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithHTTPClient(hc),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Equal(t, "/v1/traces", <-paths)
+}
+
+// newUnixSocketServer returns an HTTP client sending all its requests to a
+// server listening on a Unix domain socket. The server sends the path of the
+// requests it receives on the returned channel.
+func newUnixSocketServer(t *testing.T) (*http.Client, <-chan string) {
+	t.Helper()
+	sock := filepath.Join(t.TempDir(), "otlp.sock")
+	ln, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+
+	paths := make(chan string, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.Path
+			w.WriteHeader(http.StatusOK)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", sock)
+			},
+		},
+	}, paths
+}
+
 func TestEmptyData(t *testing.T) {
 	mcCfg := mockCollectorConfig{}
 	mc := runMockCollector(t, mcCfg)
@@ -386,17 +497,235 @@ func TestStopWhileExportingConcurrentSafe(t *testing.T) {
 }
 
 func TestPartialSuccess(t *testing.T) {
-	mcCfg := mockCollectorConfig{
+	for name, encoding := range map[string]otlptracehttp.Encoding{
+		"Protobuf": otlptracehttp.ProtobufEncoding,
+		"JSON":     otlptracehttp.JSONEncoding,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mcCfg := mockCollectorConfig{
+				Partial: &coltracepb.ExportTracePartialSuccess{
+					RejectedSpans: 2,
+					ErrorMessage:  "partially successful",
+				},
+			}
+			mc := runMockCollector(t, mcCfg)
+			defer mc.MustStop(t)
+			driver := otlptracehttp.NewClient(
+				otlptracehttp.WithEndpoint(mc.Endpoint()),
+				otlptracehttp.WithInsecure(),
+				otlptracehttp.WithEncoding(encoding),
+			)
+			ctx := context.Background()
+			exporter, err := otlptrace.New(ctx, driver)
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, exporter.Shutdown(context.Background()))
+			}()
+
+			errs := []error{}
+			otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+				errs = append(errs, err)
+			}))
+			err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+			assert.NoError(t, err)
+
+			require.Equal(t, 1, len(errs))
+			require.Contains(t, errs[0].Error(), "partially successful")
+			require.Contains(t, errs[0].Error(), "2 spans rejected")
+		})
+	}
+}
+
+type countingAuthenticator struct {
+	calls atomic.Int64
+	err   error
+}
+
+func (a *countingAuthenticator) Headers(context.Context) (map[string]string, error) {
+	a.calls.Add(1)
+	if a.err != nil {
+		return nil, a.err
+	}
+	return map[string]string{"Authorization": "Bearer token"}, nil
+}
+
+func TestAuthenticator(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusServiceUnavailable},
+		ExpectedHeaders:  map[string]string{"Authorization": "Bearer token"},
+	})
+	defer mc.MustStop(t)
+
+	auth := &countingAuthenticator{}
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		// Overridden by the Authenticator.
+		otlptracehttp.WithHeaders(map[string]string{"Authorization": "static"}),
+		otlptracehttp.WithAuthenticator(auth),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: time.Nanosecond,
+			MaxInterval:     time.Nanosecond,
+			MaxElapsedTime:  time.Minute,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 1)
+	assert.Equal(t, int64(2), auth.calls.Load(), "called for every request")
+
+	auth.err = errors.New("no token")
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.ErrorIs(t, err, auth.err)
+	assert.Len(t, mc.GetSpans(), 1, "request not sent")
+}
+
+func TestCertificateRotation(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeCA := func(pem *pemCertificate, mod time.Time) {
+		require.NoError(t, os.WriteFile(caFile, pem.Certificate, 0o600))
+		require.NoError(t, os.Chtimes(caFile, mod, mod))
+	}
+
+	first, err := generateWeakCertificate()
+	require.NoError(t, err)
+	mod := time.Now().Add(-time.Minute)
+	writeCA(first, mod)
+	t.Setenv("OTEL_EXPORTER_OTLP_CERTIFICATE", caFile)
+
+	mc := runMockCollector(t, mockCollectorConfig{WithTLS: true, TLSCertificate: first})
+	_, port, err := net.SplitHostPort(mc.Endpoint())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(mc.Endpoint()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 1)
+
+	// The collector restarts with a new certificate, trusted by the updated
+	// CA file.
+	mc.MustStop(t)
+	second, err := generateWeakCertificate()
+	require.NoError(t, err)
+	writeCA(second, mod.Add(time.Second))
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	mc = runMockCollector(t, mockCollectorConfig{Port: p, WithTLS: true, TLSCertificate: second})
+	defer mc.MustStop(t)
+
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 1)
+}
+
+func TestMaxRequestSize(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{http.StatusBadRequest},
+	})
+	defer mc.MustStop(t)
+
+	ctx := context.Background()
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithMaxRequestSize(2500),
+	)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	var stubs tracetest.SpanStubs
+	for i := 1; i <= 4; i++ {
+		stubs = append(stubs, tracetest.SpanStub{Name: strconv.Itoa(i) + strings.Repeat("x", 1000)})
+	}
+	err = exporter.ExportSpans(ctx, stubs.Snapshots())
+	assert.ErrorContains(t, err, "400", "error of the first request")
+
+	// The spans of the first request are rejected, the second is sent.
+	got := mc.GetSpans()
+	require.Len(t, got, 2)
+	assert.True(t, strings.HasPrefix(got[0].Name, "3"))
+	assert.True(t, strings.HasPrefix(got[1].Name, "4"))
+}
+
+func TestMaxRequestSizeJSON(t *testing.T) {
+	var sizes []int
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		sizes = append(sizes, len(body))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	const limit = 2500
+	ctx := context.Background()
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(srv.URL),
+		otlptracehttp.WithEncoding(otlptracehttp.JSONEncoding),
+		otlptracehttp.WithMaxRequestSize(limit),
+	)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	// The attributes are larger encoded with JSON than with protobuf.
+	attrs := make([]attribute.KeyValue, 50)
+	for i := range attrs {
+		attrs[i] = attribute.Int("k"+strconv.Itoa(i), i)
+	}
+	var stubs tracetest.SpanStubs
+	for i := 0; i < 8; i++ {
+		stubs = append(stubs, tracetest.SpanStub{Name: strconv.Itoa(i), Attributes: attrs})
+	}
+	require.NoError(t, exporter.ExportSpans(ctx, stubs.Snapshots()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Greater(t, len(sizes), 1, "request split")
+	for _, size := range sizes {
+		assert.LessOrEqual(t, size, limit, "JSON body size")
+	}
+}
+
+func TestMeterProvider(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{503},
 		Partial: &coltracepb.ExportTracePartialSuccess{
 			RejectedSpans: 2,
 			ErrorMessage:  "partially successful",
 		},
-	}
-	mc := runMockCollector(t, mcCfg)
+	})
 	defer mc.MustStop(t)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {}))
+
+	r := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(r))
 	driver := otlptracehttp.NewClient(
 		otlptracehttp.WithEndpoint(mc.Endpoint()),
 		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: time.Nanosecond,
+			MaxInterval:     time.Nanosecond,
+			MaxElapsedTime:  time.Minute,
+		}),
+		otlptracehttp.WithMeterProvider(mp),
 	)
 	ctx := context.Background()
 	exporter, err := otlptrace.New(ctx, driver)
@@ -405,16 +734,47 @@ func TestPartialSuccess(t *testing.T) {
 		assert.NoError(t, exporter.Shutdown(context.Background()))
 	}()
 
-	errs := []error{}
-	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
-		errs = append(errs, err)
-	}))
-	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
-	assert.NoError(t, err)
+	spans := tracetest.SpanStubs{{Name: "a"}, {Name: "b"}, {Name: "c"}}.Snapshots()
+	require.NoError(t, exporter.ExportSpans(ctx, spans))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, r.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp", rm.ScopeMetrics[0].Scope.Name)
+
+	got := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m.Data
+	}
+
+	exported, ok := got["otel.sdk.exporter.span.exported"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	byErr := make(map[string]int64)
+	for _, dp := range exported.DataPoints {
+		v, _ := dp.Attributes.Value("error.type")
+		byErr[v.AsString()] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"": 1, "rejected": 2}, byErr)
+
+	retries, ok := got["otel.sdk.exporter.operation.retries"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, retries.DataPoints, 1)
+	assert.Equal(t, int64(1), retries.DataPoints[0].Value)
+
+	duration, ok := got["otel.sdk.exporter.operation.duration"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	code, _ := duration.DataPoints[0].Attributes.Value("http.response.status_code")
+	assert.Equal(t, int64(http.StatusOK), code.AsInt64())
 
-	require.Equal(t, 1, len(errs))
-	require.Contains(t, errs[0].Error(), "partially successful")
-	require.Contains(t, errs[0].Error(), "2 spans rejected")
+	uncompressed, ok := got["otel.sdk.exporter.payload.uncompressed_size"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	compressed, ok := got["otel.sdk.exporter.payload.compressed_size"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, uncompressed.DataPoints, 1)
+	require.Len(t, compressed.DataPoints, 1)
+	assert.Positive(t, compressed.DataPoints[0].Value)
+	assert.NotEqual(t, uncompressed.DataPoints[0].Value, compressed.DataPoints[0].Value)
 }
 
 func TestOtherHTTPSuccess(t *testing.T) {
@@ -0,0 +1,46 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/auth/auth_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type authFunc func(context.Context) (map[string]string, error)
+
+func (f authFunc) Headers(ctx context.Context) (map[string]string, error) {
+	return f(ctx)
+}
+
+func TestSetHeaders(t *testing.T) {
+	ctx := context.Background()
+
+	h := http.Header{"Authorization": {"static"}, "Other": {"value"}}
+	require.NoError(t, SetHeaders(ctx, nil, h))
+	assert.Equal(t, "static", h.Get("Authorization"), "nil Authenticator")
+
+	a := authFunc(func(context.Context) (map[string]string, error) {
+		return map[string]string{"authorization": "Bearer token"}, nil
+	})
+	require.NoError(t, SetHeaders(ctx, a, h))
+	assert.Equal(t, http.Header{
+		"Authorization": {"Bearer token"},
+		"Other":         {"value"},
+	}, h)
+
+	errAuth := errors.New("no token")
+	a = authFunc(func(context.Context) (map[string]string, error) {
+		return nil, errAuth
+	})
+	assert.ErrorIs(t, SetHeaders(ctx, a, h), errAuth)
+}
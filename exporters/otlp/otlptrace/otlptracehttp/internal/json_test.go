@@ -0,0 +1,103 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/otlp/json_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var jsonRequest = &coltracepb.ExportTraceServiceRequest{
+	ResourceSpans: []*tracepb.ResourceSpans{
+		{
+			ScopeSpans: []*tracepb.ScopeSpans{
+				{
+					Scope: &commonpb.InstrumentationScope{Name: "scope"},
+					Spans: []*tracepb.Span{
+						{
+							TraceId:           []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+							SpanId:            []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+							ParentSpanId:      []byte{0xff, 0xfe, 0xfd, 0xfc, 0xfb, 0xfa, 0xf9, 0xf8},
+							Name:              "span",
+							Kind:              tracepb.Span_SPAN_KIND_SERVER,
+							StartTimeUnixNano: 1720000000000000001,
+							Attributes: []*commonpb.KeyValue{
+								{
+									Key:   "spanId",
+									Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "<not an ID>"}},
+								},
+							},
+							Links: []*tracepb.Span_Link{
+								{
+									TraceId: []byte{0x10, 0x0f, 0x0e, 0x0d, 0x0c, 0x0b, 0x0a, 0x09, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01},
+									SpanId:  []byte{0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestMarshalJSON(t *testing.T) {
+	b, err := MarshalJSON(jsonRequest)
+	require.NoError(t, err)
+
+	got := string(b)
+	assert.Contains(t, got, `"traceId":"0102030405060708090a0b0c0d0e0f10"`)
+	assert.Contains(t, got, `"spanId":"0102030405060708"`)
+	assert.Contains(t, got, `"parentSpanId":"fffefdfcfbfaf9f8"`)
+	assert.Contains(t, got, `"traceId":"100f0e0d0c0b0a090807060504030201"`)
+	assert.Contains(t, got, `"spanId":"0807060504030201"`)
+	assert.Contains(t, got, `"kind":2`)
+	assert.Contains(t, got, `"startTimeUnixNano":"1720000000000000001"`)
+	assert.Contains(t, got, `"key":"spanId"`)
+	assert.Contains(t, got, `"stringValue":"<not an ID>"`)
+}
+
+func TestJSONSize(t *testing.T) {
+	b, err := MarshalJSON(jsonRequest)
+	require.NoError(t, err)
+	assert.Equal(t, len(b), JSONSize(jsonRequest))
+	assert.Greater(t, JSONSize(jsonRequest), proto.Size(jsonRequest))
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	b, err := MarshalJSON(jsonRequest)
+	require.NoError(t, err)
+
+	var got coltracepb.ExportTraceServiceRequest
+	require.NoError(t, UnmarshalJSON(b, &got))
+	assert.True(t, proto.Equal(jsonRequest, &got), "round trip")
+
+	t.Run("PartialSuccess", func(t *testing.T) {
+		for _, data := range []string{
+			`{"partialSuccess":{"rejectedSpans":"2","errorMessage":"rejected"}}`,
+			`{"partialSuccess":{"rejectedSpans":2,"errorMessage":"rejected"},"unknown":true}`,
+		} {
+			var resp coltracepb.ExportTraceServiceResponse
+			require.NoError(t, UnmarshalJSON([]byte(data), &resp), data)
+			assert.Equal(t, int64(2), resp.GetPartialSuccess().GetRejectedSpans(), data)
+			assert.Equal(t, "rejected", resp.GetPartialSuccess().GetErrorMessage(), data)
+		}
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		var req coltracepb.ExportTraceServiceRequest
+		data := `{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"not hex"}]}]}]}`
+		assert.Error(t, UnmarshalJSON([]byte(data), &req))
+	})
+}
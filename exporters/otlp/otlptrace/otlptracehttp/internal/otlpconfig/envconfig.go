@@ -15,7 +15,9 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/certreload"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/envconfig"
+	"go.opentelemetry.io/otel/internal/global"
 )
 
 // DefaultEnvOptionsReader is the default environments reader.
@@ -46,7 +48,7 @@ func ApplyHTTPEnvConfigs(cfg Config) Config {
 func getOptionsFromEnv() []GenericOption {
 	opts := []GenericOption{}
 
-	tlsConf := &tls.Config{}
+	var tlsFiles certreload.Files
 	DefaultEnvOptionsReader.Apply(
 		envconfig.WithURL("ENDPOINT", func(u *url.URL) {
 			opts = append(opts, withEndpointScheme(u))
@@ -75,17 +77,19 @@ func getOptionsFromEnv() []GenericOption {
 				return cfg
 			}, withEndpointForGRPC(u)))
 		}),
-		envconfig.WithCertPool("CERTIFICATE", func(p *x509.CertPool) { tlsConf.RootCAs = p }),
-		envconfig.WithCertPool("TRACES_CERTIFICATE", func(p *x509.CertPool) { tlsConf.RootCAs = p }),
-		envconfig.WithClientCert("CLIENT_CERTIFICATE", "CLIENT_KEY", func(c tls.Certificate) { tlsConf.Certificates = []tls.Certificate{c} }),
-		envconfig.WithClientCert("TRACES_CLIENT_CERTIFICATE", "TRACES_CLIENT_KEY", func(c tls.Certificate) { tlsConf.Certificates = []tls.Certificate{c} }),
-		withTLSConfig(tlsConf, func(c *tls.Config) { opts = append(opts, WithTLSClientConfig(c)) }),
+		withCAFile("CERTIFICATE", &tlsFiles),
+		withCAFile("TRACES_CERTIFICATE", &tlsFiles),
+		withClientCertFiles("CLIENT_CERTIFICATE", "CLIENT_KEY", &tlsFiles),
+		withClientCertFiles("TRACES_CLIENT_CERTIFICATE", "TRACES_CLIENT_KEY", &tlsFiles),
+		withTLSFiles(&tlsFiles, func(fn func(string) *tls.Config) { opts = append(opts, withReloadingTLSClientConfig(fn)) }),
 		envconfig.WithBool("INSECURE", func(b bool) { opts = append(opts, withInsecure(b)) }),
 		envconfig.WithBool("TRACES_INSECURE", func(b bool) { opts = append(opts, withInsecure(b)) }),
 		envconfig.WithHeaders("HEADERS", func(h map[string]string) { opts = append(opts, WithHeaders(h)) }),
 		envconfig.WithHeaders("TRACES_HEADERS", func(h map[string]string) { opts = append(opts, WithHeaders(h)) }),
 		WithEnvCompression("COMPRESSION", func(c Compression) { opts = append(opts, WithCompression(c)) }),
 		WithEnvCompression("TRACES_COMPRESSION", func(c Compression) { opts = append(opts, WithCompression(c)) }),
+		WithEnvProtocol("PROTOCOL", func(m Marshaler) { opts = append(opts, WithMarshal(m)) }),
+		WithEnvProtocol("TRACES_PROTOCOL", func(m Marshaler) { opts = append(opts, WithMarshal(m)) }),
 		envconfig.WithDuration("TIMEOUT", func(d time.Duration) { opts = append(opts, WithTimeout(d)) }),
 		envconfig.WithDuration("TRACES_TIMEOUT", func(d time.Duration) { opts = append(opts, WithTimeout(d)) }),
 	)
@@ -116,8 +120,13 @@ func WithEnvCompression(n string, fn func(Compression)) func(e *envconfig.EnvOpt
 	return func(e *envconfig.EnvOptionsReader) {
 		if v, ok := e.GetEnvValue(n); ok {
 			cp := NoCompression
-			if v == "gzip" {
+			switch v {
+			case "gzip":
 				cp = GzipCompression
+			case "zstd":
+				cp = ZstdCompression
+			case "snappy":
+				cp = SnappyCompression
 			}
 
 			fn(cp)
@@ -125,6 +134,22 @@ func WithEnvCompression(n string, fn func(Compression)) func(e *envconfig.EnvOpt
 	}
 }
 
+// WithEnvProtocol retrieves the specified config and passes it to ConfigFn as
+// a Marshaler. Only the OTLP/HTTP protocols are mapped to a Marshaler, any
+// other value is ignored.
+func WithEnvProtocol(n string, fn func(Marshaler)) func(e *envconfig.EnvOptionsReader) {
+	return func(e *envconfig.EnvOptionsReader) {
+		if v, ok := e.GetEnvValue(n); ok {
+			switch strings.TrimSpace(v) {
+			case "http/protobuf":
+				fn(MarshalProto)
+			case "http/json":
+				fn(MarshalJSON)
+			}
+		}
+	}
+}
+
 // revive:disable-next-line:flag-parameter
 func withInsecure(b bool) GenericOption {
 	if b {
@@ -133,10 +158,51 @@ func withInsecure(b bool) GenericOption {
 	return WithSecure()
 }
 
-func withTLSConfig(c *tls.Config, fn func(*tls.Config)) func(e *envconfig.EnvOptionsReader) {
+// withCAFile returns a ConfigFn that sets the CA of files to the path in the
+// environment variable n if it contains valid certificate authorities.
+func withCAFile(n string, files *certreload.Files) envconfig.ConfigFn {
 	return func(e *envconfig.EnvOptionsReader) {
-		if c.RootCAs != nil || len(c.Certificates) > 0 {
-			fn(c)
+		envconfig.WithCertPool(n, func(*x509.CertPool) {
+			files.CA, _ = e.GetEnvValue(n)
+		})(e)
+	}
+}
+
+// withClientCertFiles returns a ConfigFn that sets the Cert and Key of files
+// to the paths in the environment variables nc and nk if they contain a valid
+// client certificate and key pair.
+func withClientCertFiles(nc, nk string, files *certreload.Files) envconfig.ConfigFn {
+	return func(e *envconfig.EnvOptionsReader) {
+		envconfig.WithClientCert(nc, nk, func(tls.Certificate) {
+			files.Cert, _ = e.GetEnvValue(nc)
+			files.Key, _ = e.GetEnvValue(nk)
+		})(e)
+	}
+}
+
+// withTLSFiles returns a ConfigFn that passes to fn a function returning the
+// TLS configuration for an endpoint loaded from files, if any is set. The
+// certificates are reloaded when the files change.
+func withTLSFiles(files *certreload.Files, fn func(func(string) *tls.Config)) envconfig.ConfigFn {
+	return func(e *envconfig.EnvOptionsReader) {
+		if files.CA == "" && files.Cert == "" {
+			return
 		}
+		r, err := certreload.New(*files, e.ReadFile)
+		if err != nil {
+			global.Error(err, "load tls certificates")
+			return
+		}
+		fn(r.TLSConfig)
 	}
 }
+
+// withReloadingTLSClientConfig sets the function returning the TLS
+// configuration for an endpoint. It is only used if no other TLS
+// configuration is set, the endpoint is known once all options are applied.
+func withReloadingTLSClientConfig(fn func(string) *tls.Config) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.ReloadingTLSCfg = fn
+		return cfg
+	})
+}
@@ -7,6 +7,7 @@
 package otlpconfig
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/url"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/envconfig"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 const (
@@ -286,6 +288,8 @@ func TestConfigs(t *testing.T) {
 				} else {
 					// nolint:staticcheck // ignoring tlsCert.RootCAs.Subjects is deprecated ERR because cert does not come from SystemCertPool.
 					assert.Equal(t, tlsCert.RootCAs.Subjects(), c.Traces.TLSCfg.RootCAs.Subjects())
+					// The server certificate is verified for the endpoint host.
+					assert.Equal(t, "localhost", c.Traces.TLSCfg.ServerName)
 				}
 			},
 		},
@@ -363,6 +367,60 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		// MeterProvider Tests
+		{
+			name: "Test Without MeterProvider",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Nil(t, c.MeterProvider)
+			},
+		},
+		{
+			name: "Test With MeterProvider",
+			opts: []GenericOption{
+				WithMeterProvider(noop.NewMeterProvider()),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, noop.NewMeterProvider(), c.MeterProvider)
+			},
+		},
+
+		// Authenticator Tests
+		{
+			name: "Test Without Authenticator",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Nil(t, c.Authenticator)
+			},
+		},
+		{
+			name: "Test With Authenticator",
+			opts: []GenericOption{
+				WithAuthenticator(testAuthenticator{}),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, testAuthenticator{}, c.Authenticator)
+			},
+		},
+
+		// Request size Tests
+		{
+			name: "Test Without Request Limits",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Zero(t, c.Traces.MaxRequestSize)
+				assert.Zero(t, c.Traces.MaxRequestItems)
+			},
+		},
+		{
+			name: "Test With Request Limits",
+			opts: []GenericOption{
+				WithMaxRequestSize(4 << 20),
+				WithMaxRequestItems(1000),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, 4<<20, c.Traces.MaxRequestSize)
+				assert.Equal(t, 1000, c.Traces.MaxRequestItems)
+			},
+		},
+
 		// Compression Tests
 		{
 			name: "Test With Compression",
@@ -391,6 +449,25 @@ func TestConfigs(t *testing.T) {
 				assert.Equal(t, GzipCompression, c.Traces.Compression)
 			},
 		},
+		{
+			name: "Test Environment Zstd Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, ZstdCompression, c.Traces.Compression)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Snappy Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION":        "zstd",
+				"OTEL_EXPORTER_OTLP_TRACES_COMPRESSION": "snappy",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, SnappyCompression, c.Traces.Compression)
+			},
+		},
 		{
 			name: "Test Mixed Environment and With Compression",
 			opts: []GenericOption{
@@ -404,6 +481,63 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		// Marshaler Tests
+		{
+			name: "Test Default Marshaler",
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test With Marshaler",
+			opts: []GenericOption{
+				WithMarshal(MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalJSON, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalJSON, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":        "http/json",
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment gRPC Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Mixed Environment and With Marshaler",
+			opts: []GenericOption{
+				WithMarshal(MarshalProto),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *Config, grpcOption bool) {
+				assert.Equal(t, MarshalProto, c.Traces.Marshaler)
+			},
+		},
+
 		// Timeout Tests
 		{
 			name: "Test With Timeout",
@@ -573,3 +707,9 @@ func TestCleanPath(t *testing.T) {
 		})
 	}
 }
+
+type testAuthenticator struct{}
+
+func (testAuthenticator) Headers(context.Context) (map[string]string, error) {
+	return map[string]string{"Authorization": "Bearer token"}, nil
+}
@@ -16,10 +16,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlptracetest"
 	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
@@ -87,14 +90,23 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 	response := collectortracepb.ExportTraceServiceResponse{
 		PartialSuccess: c.partial,
 	}
-	rawResponse, err := proto.Marshal(&response)
+	// Reply with the same encoding as the request.
+	contentType := r.Header.Get("content-type")
+	marshal := proto.Marshal
+	if contentType == internal.ContentTypeJSON {
+		marshal = internal.MarshalJSON
+	}
+	rawResponse, err := marshal(&response)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	if c.injectContentType != "" {
+		contentType = c.injectContentType
+	}
 	h := c.getInjectResponseHeader()
 	if injectedStatus := c.getInjectHTTPStatus(); injectedStatus != 0 {
-		writeReply(w, rawResponse, injectedStatus, c.injectContentType, h)
+		writeReply(w, rawResponse, injectedStatus, contentType, h)
 		return
 	}
 	rawRequest, err := readRequest(r)
@@ -108,7 +120,7 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	writeReply(w, rawResponse, 0, c.injectContentType, h)
+	writeReply(w, rawResponse, 0, contentType, h)
 	c.spanLock.Lock()
 	defer c.spanLock.Unlock()
 	c.spansStorage.AddSpans(request)
@@ -116,10 +128,15 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 
 func unmarshalTraceRequest(rawRequest []byte, contentType string) (*collectortracepb.ExportTraceServiceRequest, error) {
 	request := &collectortracepb.ExportTraceServiceRequest{}
-	if contentType != "application/x-protobuf" {
-		return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf is supported", contentType)
+	var err error
+	switch contentType {
+	case "application/x-protobuf":
+		err = proto.Unmarshal(rawRequest, request)
+	case internal.ContentTypeJSON:
+		err = internal.UnmarshalJSON(rawRequest, request)
+	default:
+		err = fmt.Errorf("invalid content-type: %s, only application/x-protobuf and application/json are supported", contentType)
 	}
-	err := proto.Unmarshal(rawRequest, request)
 	return request, err
 }
 
@@ -157,8 +174,22 @@ func (c *mockCollector) getInjectResponseHeader() (h map[string]string) {
 }
 
 func readRequest(r *http.Request) ([]byte, error) {
-	if r.Header.Get("Content-Encoding") == "gzip" {
+	switch r.Header.Get("Content-Encoding") {
+	case "gzip":
 		return readGzipBody(r.Body)
+	case "zstd":
+		dec, err := zstd.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+		return io.ReadAll(dec)
+	case "snappy":
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		return snappy.Decode(nil, b)
 	}
 	return io.ReadAll(r.Body)
 }
@@ -203,6 +234,7 @@ type mockCollectorConfig struct {
 	Partial              *collectortracepb.ExportTracePartialSuccess
 	Delay                <-chan struct{}
 	WithTLS              bool
+	TLSCertificate       *pemCertificate
 	ExpectedHeaders      map[string]string
 }
 
@@ -236,8 +268,11 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		WriteTimeout: 10 * time.Second,
 	}
 	if cfg.WithTLS {
-		pem, err := generateWeakCertificate()
-		require.NoError(t, err)
+		pem := cfg.TLSCertificate
+		if pem == nil {
+			pem, err = generateWeakCertificate()
+			require.NoError(t, err)
+		}
 		tlsCertificate, err := tls.X509KeyPair(pem.Certificate, pem.PrivateKey)
 		require.NoError(t, err)
 		server.TLSConfig = &tls.Config{
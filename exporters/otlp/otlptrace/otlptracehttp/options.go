@@ -4,6 +4,7 @@
 package otlptracehttp // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
 	"net/url"
@@ -11,6 +12,7 @@ import (
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp/internal/retry"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // Compression describes the compression used for payloads sent to the
@@ -29,6 +31,24 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression = Compression(otlpconfig.GzipCompression)
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression = Compression(otlpconfig.ZstdCompression)
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with snappy.
+	SnappyCompression = Compression(otlpconfig.SnappyCompression)
+)
+
+// Encoding describes the encoding used for payloads sent to the collector.
+type Encoding otlpconfig.Marshaler
+
+const (
+	// ProtobufEncoding tells the driver to send payloads using the binary
+	// Protobuf encoding.
+	ProtobufEncoding = Encoding(otlpconfig.MarshalProto)
+	// JSONEncoding tells the driver to send payloads using the OTLP/JSON
+	// encoding.
+	JSONEncoding = Encoding(otlpconfig.MarshalJSON)
 )
 
 // Option applies an option to the HTTP client.
@@ -106,6 +126,22 @@ func WithCompression(compression Compression) Option {
 	return wrappedOption{otlpconfig.WithCompression(otlpconfig.Compression(compression))}
 }
 
+// WithEncoding sets the encoding used for payloads sent to the collector.
+// Responses from the collector are parsed according to their Content-Type.
+//
+// If the OTEL_EXPORTER_OTLP_PROTOCOL or OTEL_EXPORTER_OTLP_TRACES_PROTOCOL
+// environment variable is set to "http/protobuf" or "http/json", and this
+// option is not passed, that variable value will be used. If both environment
+// variables are set, OTEL_EXPORTER_OTLP_TRACES_PROTOCOL will take precedence.
+// If an environment variable is set, and this option is passed, this option
+// will take precedence.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, ProtobufEncoding will be used.
+func WithEncoding(encoding Encoding) Option {
+	return wrappedOption{otlpconfig.WithMarshal(otlpconfig.Marshaler(encoding))}
+}
+
 // WithURLPath allows one to override the default URL path used
 // for sending traces. If unset, default ("/v1/traces") will be used.
 func WithURLPath(urlPath string) Option {
@@ -147,9 +183,111 @@ func WithRetry(rc RetryConfig) Option {
 	return wrappedOption{otlpconfig.WithRetry(retry.Config(rc))}
 }
 
+// WithRetryableStatusCodes sets the status codes of the HTTP responses for
+// which the export of a batch of spans is retried according to the policy set with
+// WithRetry. Responses with any other error status code are not retried.
+//
+// By default, if this option is not passed, responses with the 429, 502, 503,
+// and 504 status codes are retried.
+func WithRetryableStatusCodes(codes ...int) Option {
+	return wrappedOption{otlpconfig.NewHTTPOption(func(cfg otlpconfig.Config) otlpconfig.Config {
+		cfg.RetryableHTTPStatusCodes = append([]int{}, codes...)
+		return cfg
+	})}
+}
+
 // WithProxy sets the Proxy function the client will use to determine the
 // proxy to use for an HTTP request. If this option is not used, the client
 // will use [http.ProxyFromEnvironment].
 func WithProxy(pf HTTPTransportProxyFunc) Option {
 	return wrappedOption{otlpconfig.WithProxy(otlpconfig.HTTPTransportProxyFunc(pf))}
 }
+
+// WithHTTPClient sets the HTTP client used to send the requests, for example
+// a client whose transport dials a Unix domain socket.
+//
+// This option takes precedence over the WithProxy, WithTimeout, and
+// WithTLSClientConfig options as well as the OTEL_EXPORTER_OTLP_CERTIFICATE,
+// OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE, OTEL_EXPORTER_OTLP_TIMEOUT, and
+// OTEL_EXPORTER_OTLP_TRACES_TIMEOUT environment variables. The passed client
+// is used as is, its Timeout and other fields are not modified.
+//
+// By default, if this option is not passed, a client is created from the
+// other options.
+func WithHTTPClient(c *http.Client) Option {
+	return wrappedOption{otlpconfig.WithHTTPClient(c)}
+}
+
+// WithMeterProvider sets the MeterProvider used to report telemetry about the
+// exporter itself. The following metrics are reported:
+//
+//   - otel.sdk.exporter.span.exported: the number of spans exported. Spans
+//     that failed to be exported, or that were rejected by the collector in
+//     a partial success response, have the error.type attribute set.
+//   - otel.sdk.exporter.operation.duration: the duration of exports.
+//   - otel.sdk.exporter.operation.retries: the number of retried requests.
+//   - otel.sdk.exporter.payload.uncompressed_size and
+//     otel.sdk.exporter.payload.compressed_size: the size of the sent
+//     payloads before and after compression.
+//
+// By default, if this option is not passed, no telemetry is reported.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return wrappedOption{otlpconfig.WithMeterProvider(mp)}
+}
+
+// Authenticator provides the headers used to authenticate export requests,
+// e.g. an "Authorization" header holding a bearer token.
+//
+// Headers is called before every request sent, including retries, and the
+// returned headers are set on the request. They override the headers with
+// the same name passed with WithHeaders.
+// If Headers returns an error, the request is not sent and the export fails.
+//
+// Headers needs to be safe to call concurrently.
+type Authenticator interface {
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// WithAuthenticator sets the Authenticator used to authenticate every
+// request sent by the exporter. Unlike the static headers passed with
+// WithHeaders, it allows credentials that expire, like OAuth 2.0 access
+// tokens, to be refreshed without creating a new exporter.
+//
+// The go.opentelemetry.io/otel/exporters/otlp/otlpauth package provides an
+// Authenticator using the OAuth 2.0 client credentials grant.
+//
+// By default, if this option is not passed, requests are not authenticated
+// other than with the configured headers and TLS credentials.
+func WithAuthenticator(a Authenticator) Option {
+	return wrappedOption{otlpconfig.WithAuthenticator(a)}
+}
+
+// WithMaxRequestSize sets the maximum size, in bytes, of the requests sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the spans grouped by their resource and instrumentation
+// scope. The size is the one of the request encoded with the protobuf or JSON
+// encoding in use, before compression. A single span larger than n is sent
+// in its own request.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by size.
+func WithMaxRequestSize(n int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestSize(n)}
+}
+
+// WithMaxRequestItems sets the maximum number of spans in a request sent by
+// the exporter. An export exceeding it is split into several requests, each
+// containing part of the spans grouped by their resource and instrumentation
+// scope.
+//
+// The errors of all the requests an export is split into are returned by the
+// export.
+//
+// By default, if this option is not passed or n is not positive, requests are
+// not split by their number of spans.
+func WithMaxRequestItems(n int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestItems(n)}
+}
@@ -4,14 +4,24 @@
 package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
 
 import (
+	"net"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/naming"
+	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
 
+const (
+	defaultServerHost = "localhost"
+	defaultServerPort = 9464
+)
+
 // config contains options for the exporter.
 type config struct {
 	registerer               prometheus.Registerer
@@ -22,6 +32,12 @@ type config struct {
 	disableScopeInfo         bool
 	namespace                string
 	resourceAttributesFilter attribute.Filter
+	translationStrategy      TranslationStrategy
+
+	// withServer is true if the metrics are served by an HTTP server
+	// listening on serverAddr.
+	withServer bool
+	serverAddr string
 }
 
 // newConfig creates a validated config configured with options.
@@ -35,9 +51,49 @@ func newConfig(opts ...Option) config {
 		cfg.registerer = prometheus.DefaultRegisterer
 	}
 
+	strategy, err := naming.Strategy(cfg.translationStrategy).Resolve()
+	if err != nil {
+		global.Error(err, "using the default translation strategy")
+	}
+	cfg.translationStrategy = TranslationStrategy(strategy)
+
+	if cfg.withServer && cfg.serverAddr == "" {
+		cfg.serverAddr = serverAddrFromEnv()
+	}
+
+	if cfg.namespace != "" {
+		if cfg.translationStrategy.escapeNames() {
+			cfg.namespace = naming.SanitizeName(cfg.namespace)
+		}
+		if !strings.HasSuffix(cfg.namespace, "_") {
+			// namespace and metric names should be separated with an underscore,
+			// adds a trailing underscore if there is not one already.
+			cfg.namespace += "_"
+		}
+	}
+
 	return cfg
 }
 
+// serverAddrFromEnv returns the server address defined by the
+// OTEL_EXPORTER_PROMETHEUS_HOST and OTEL_EXPORTER_PROMETHEUS_PORT environment
+// variables, or the default host and port if they are not set.
+func serverAddrFromEnv() string {
+	host := defaultServerHost
+	if v := os.Getenv("OTEL_EXPORTER_PROMETHEUS_HOST"); v != "" {
+		host = v
+	}
+	port := strconv.Itoa(defaultServerPort)
+	if v := os.Getenv("OTEL_EXPORTER_PROMETHEUS_PORT"); v != "" {
+		if _, err := strconv.ParseUint(v, 10, 16); err != nil {
+			global.Error(err, "invalid OTEL_EXPORTER_PROMETHEUS_PORT, using the default port", "value", v)
+		} else {
+			port = v
+		}
+	}
+	return net.JoinHostPort(host, port)
+}
+
 // Option sets exporter option values.
 type Option interface {
 	apply(config) config
@@ -131,13 +187,6 @@ func WithoutScopeInfo() Option {
 // have special behavior based on their name.
 func WithNamespace(ns string) Option {
 	return optionFunc(func(cfg config) config {
-		ns = sanitizeName(ns)
-		if !strings.HasSuffix(ns, "_") {
-			// namespace and metric names should be separated with an underscore,
-			// adds a trailing underscore if there is not one already.
-			ns = ns + "_"
-		}
-
 		cfg.namespace = ns
 		return cfg
 	})
@@ -153,3 +202,69 @@ func WithResourceAsConstantLabels(resourceFilter attribute.Filter) Option {
 		return cfg
 	})
 }
+
+// TranslationStrategy defines how the OpenTelemetry metric and attribute
+// names are translated into Prometheus metric and label names.
+type TranslationStrategy string
+
+const (
+	// UnderscoreEscapingWithSuffixes replaces the characters of the metric
+	// and label names not supported by the legacy Prometheus name validation
+	// with underscores. Unit and _total suffixes are added to the metric
+	// names.
+	UnderscoreEscapingWithSuffixes TranslationStrategy = "UnderscoreEscapingWithSuffixes"
+	// NoUTF8EscapingWithSuffixes keeps the UTF-8 characters of the metric and
+	// label names. Unit and _total suffixes are added to the metric names.
+	NoUTF8EscapingWithSuffixes TranslationStrategy = "NoUTF8EscapingWithSuffixes"
+	// NoTranslation keeps the metric and label names as they are, no suffix
+	// is added to the metric names.
+	NoTranslation TranslationStrategy = "NoTranslation"
+)
+
+// escapeNames reports whether the names not supported by the legacy
+// Prometheus name validation are escaped.
+func (s TranslationStrategy) escapeNames() bool {
+	return naming.Strategy(s).EscapeNames()
+}
+
+// WithTranslationStrategy configures how the Exporter translates the
+// OpenTelemetry metric and attribute names into Prometheus metric and label
+// names. This applies to all the exported metrics, including target_info and
+// otel_scope_info, as well as the namespace configured with WithNamespace.
+//
+// The UTF-8 names produced by NoUTF8EscapingWithSuffixes and NoTranslation
+// are only valid if the Prometheus name validation scheme,
+// github.com/prometheus/common/model.NameValidationScheme, is set to
+// model.UTF8Validation. The names are escaped for the scrapers not supporting
+// UTF-8 names when they are exposed.
+//
+// The WithoutUnits and WithoutCounterSuffixes options are still honored by
+// the strategies adding suffixes.
+//
+// By default, if this option is not used or the strategy is unknown,
+// NoUTF8EscapingWithSuffixes is used if the Prometheus name validation scheme
+// is model.UTF8Validation, and UnderscoreEscapingWithSuffixes otherwise. An
+// unknown strategy is reported to the global error handler.
+func WithTranslationStrategy(strategy TranslationStrategy) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.translationStrategy = strategy
+		return cfg
+	})
+}
+
+// WithServer configures the Exporter to serve its metrics at the /metrics
+// path of an HTTP server listening on addr. The server is stopped when the
+// Exporter is shut down. See Exporter.Handler for the exposition formats.
+//
+// If addr is empty, the server listens on the host and port defined by the
+// OTEL_EXPORTER_PROMETHEUS_HOST and OTEL_EXPORTER_PROMETHEUS_PORT environment
+// variables, "localhost" and 9464 by default.
+//
+// By default, if this option is not used, no server is started.
+func WithServer(addr string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.withServer = true
+		cfg.serverAddr = addr
+		return cfg
+	})
+}
@@ -5,11 +5,14 @@ package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 
+	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
@@ -29,7 +32,8 @@ func TestNewConfig(t *testing.T) {
 			name:    "Default",
 			options: nil,
 			wantConfig: config{
-				registerer: prometheus.DefaultRegisterer,
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
 			},
 		},
 		{
@@ -38,7 +42,8 @@ func TestNewConfig(t *testing.T) {
 				WithRegisterer(registry),
 			},
 			wantConfig: config{
-				registerer: registry,
+				registerer:          registry,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
 			},
 		},
 		{
@@ -47,8 +52,9 @@ func TestNewConfig(t *testing.T) {
 				WithAggregationSelector(aggregationSelector),
 			},
 			wantConfig: config{
-				registerer: prometheus.DefaultRegisterer,
-				readerOpts: []metric.ManualReaderOption{metric.WithAggregationSelector(aggregationSelector)},
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
+				readerOpts:          []metric.ManualReaderOption{metric.WithAggregationSelector(aggregationSelector)},
 			},
 		},
 		{
@@ -57,8 +63,9 @@ func TestNewConfig(t *testing.T) {
 				WithProducer(producer),
 			},
 			wantConfig: config{
-				registerer: prometheus.DefaultRegisterer,
-				readerOpts: []metric.ManualReaderOption{metric.WithProducer(producer)},
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
+				readerOpts:          []metric.ManualReaderOption{metric.WithProducer(producer)},
 			},
 		},
 		{
@@ -70,7 +77,8 @@ func TestNewConfig(t *testing.T) {
 			},
 
 			wantConfig: config{
-				registerer: registry,
+				registerer:          registry,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
 				readerOpts: []metric.ManualReaderOption{
 					metric.WithAggregationSelector(aggregationSelector),
 					metric.WithProducer(producer),
@@ -83,7 +91,8 @@ func TestNewConfig(t *testing.T) {
 				WithRegisterer(nil),
 			},
 			wantConfig: config{
-				registerer: prometheus.DefaultRegisterer,
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
 			},
 		},
 		{
@@ -92,8 +101,9 @@ func TestNewConfig(t *testing.T) {
 				WithoutTargetInfo(),
 			},
 			wantConfig: config{
-				registerer:        prometheus.DefaultRegisterer,
-				disableTargetInfo: true,
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
+				disableTargetInfo:   true,
 			},
 		},
 		{
@@ -102,8 +112,9 @@ func TestNewConfig(t *testing.T) {
 				WithoutUnits(),
 			},
 			wantConfig: config{
-				registerer:   prometheus.DefaultRegisterer,
-				withoutUnits: true,
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
+				withoutUnits:        true,
 			},
 		},
 		{
@@ -112,8 +123,9 @@ func TestNewConfig(t *testing.T) {
 				WithNamespace("test"),
 			},
 			wantConfig: config{
-				registerer: prometheus.DefaultRegisterer,
-				namespace:  "test_",
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
+				namespace:           "test_",
 			},
 		},
 		{
@@ -122,8 +134,43 @@ func TestNewConfig(t *testing.T) {
 				WithNamespace("test_"),
 			},
 			wantConfig: config{
-				registerer: prometheus.DefaultRegisterer,
-				namespace:  "test_",
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
+				namespace:           "test_",
+			},
+		},
+		{
+			name: "with translation strategy",
+			options: []Option{
+				WithTranslationStrategy(NoTranslation),
+			},
+			wantConfig: config{
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: NoTranslation,
+			},
+		},
+		{
+			name: "with unknown translation strategy",
+			options: []Option{
+				WithTranslationStrategy("Unknown"),
+				WithNamespace("test/"),
+			},
+			wantConfig: config{
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
+				namespace:           "test_",
+			},
+		},
+		{
+			name: "with UTF-8 namespace",
+			options: []Option{
+				WithTranslationStrategy(NoUTF8EscapingWithSuffixes),
+				WithNamespace("test/"),
+			},
+			wantConfig: config{
+				registerer:          prometheus.DefaultRegisterer,
+				namespace:           "test/_",
+				translationStrategy: NoUTF8EscapingWithSuffixes,
 			},
 		},
 		{
@@ -132,8 +179,9 @@ func TestNewConfig(t *testing.T) {
 				WithNamespace("test/"),
 			},
 			wantConfig: config{
-				registerer: prometheus.DefaultRegisterer,
-				namespace:  "test_",
+				registerer:          prometheus.DefaultRegisterer,
+				translationStrategy: UnderscoreEscapingWithSuffixes,
+				namespace:           "test_",
 			},
 		},
 	}
@@ -150,6 +198,19 @@ func TestNewConfig(t *testing.T) {
 	}
 }
 
+func TestNewConfigUnknownTranslationStrategy(t *testing.T) {
+	orig := global.GetLogger()
+	t.Cleanup(func() { global.SetLogger(orig) })
+	var logs strings.Builder
+	global.SetLogger(funcr.New(func(prefix, args string) {
+		_, _ = logs.WriteString(args)
+	}, funcr.Options{}))
+
+	cfg := newConfig(WithTranslationStrategy("Unknown"))
+	assert.Equal(t, UnderscoreEscapingWithSuffixes, cfg.translationStrategy)
+	assert.Contains(t, logs.String(), `unknown translation strategy: \"Unknown\"`)
+}
+
 type noopProducer struct{}
 
 func (*noopProducer) Produce(ctx context.Context) ([]metricdata.ScopeMetrics, error) {
@@ -4,4 +4,8 @@
 // Package prometheus provides a Prometheus Exporter that converts
 // OTLP metrics into the Prometheus exposition format and implements
 // prometheus.Collector to provide a handler for these metrics.
+//
+// Exponential histograms are exported as Prometheus native histograms. Native
+// histograms are only exposed in the protobuf exposition format, the text
+// format only contains their count and sum.
 package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
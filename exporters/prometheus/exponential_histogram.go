@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Prometheus native histograms support the schemas in the [-4, 8] range. A
+// schema is the equivalent of the scale of an exponential histogram.
+const (
+	minNativeHistogramSchema = -4
+	maxNativeHistogramSchema = 8
+)
+
+// nativeHistogram is a Prometheus native histogram converted from an
+// exponential histogram data point.
+type nativeHistogram struct {
+	desc      *prometheus.Desc
+	labels    []*dto.LabelPair
+	histogram *dto.Histogram
+}
+
+var _ prometheus.Metric = (*nativeHistogram)(nil)
+
+// newNativeHistogram returns the native histogram of dp. Exponential
+// histograms with a scale greater than the maximum native histogram schema
+// are downscaled, an error is returned if the scale is less than the minimum
+// schema.
+func newNativeHistogram[N int64 | float64](desc *prometheus.Desc, dp metricdata.ExponentialHistogramDataPoint[N], labelValues []string) (prometheus.Metric, error) {
+	if dp.Scale < minNativeHistogramSchema {
+		return nil, fmt.Errorf("exponential histogram scale %d is below the minimum native histogram schema %d", dp.Scale, minNativeHistogramSchema)
+	}
+	schema, shift := dp.Scale, int32(0)
+	if schema > maxNativeHistogramSchema {
+		schema, shift = maxNativeHistogramSchema, dp.Scale-maxNativeHistogramSchema
+	}
+
+	h := &dto.Histogram{
+		SampleCount:   proto.Uint64(dp.Count),
+		SampleSum:     proto.Float64(float64(dp.Sum)),
+		Schema:        proto.Int32(schema),
+		ZeroThreshold: proto.Float64(dp.ZeroThreshold),
+		ZeroCount:     proto.Uint64(dp.ZeroCount),
+	}
+	h.PositiveSpan, h.PositiveDelta = nativeBuckets(dp.PositiveBucket, shift)
+	h.NegativeSpan, h.NegativeDelta = nativeBuckets(dp.NegativeBucket, shift)
+	if len(h.PositiveSpan) == 0 && len(h.NegativeSpan) == 0 && dp.ZeroThreshold == 0 && dp.ZeroCount == 0 {
+		// An empty span identifies a native histogram without any bucket, it
+		// would be interpreted as a classic histogram otherwise.
+		h.PositiveSpan = []*dto.BucketSpan{{Offset: proto.Int32(0), Length: proto.Uint32(0)}}
+	}
+	for _, e := range toPromExemplars(dp.Exemplars) {
+		h.Exemplars = append(h.Exemplars, toDTOExemplar(e))
+	}
+
+	return &nativeHistogram{
+		desc:      desc,
+		labels:    prometheus.MakeLabelPairs(desc, labelValues),
+		histogram: h,
+	}, nil
+}
+
+// Desc implements prometheus.Metric.
+func (h *nativeHistogram) Desc() *prometheus.Desc {
+	return h.desc
+}
+
+// Write implements prometheus.Metric.
+func (h *nativeHistogram) Write(m *dto.Metric) error {
+	m.Label = h.labels
+	m.Histogram = h.histogram
+	return nil
+}
+
+// nativeBuckets returns the spans and the delta encoded counts of the native
+// histogram buckets holding the counts of the exponential histogram buckets
+// b, downscaled by shift. Empty buckets are omitted.
+func nativeBuckets(b metricdata.ExponentialBucket, shift int32) ([]*dto.BucketSpan, []int64) {
+	var (
+		spans  []*dto.BucketSpan
+		deltas []int64
+
+		prevCount int64
+		nextIdx   int32
+	)
+	appendBucket := func(idx int32, count uint64) {
+		switch {
+		case len(spans) == 0:
+			spans = append(spans, &dto.BucketSpan{Offset: proto.Int32(idx), Length: proto.Uint32(0)})
+		case idx != nextIdx:
+			spans = append(spans, &dto.BucketSpan{Offset: proto.Int32(idx - nextIdx), Length: proto.Uint32(0)})
+		}
+		*spans[len(spans)-1].Length++
+		deltas = append(deltas, int64(count)-prevCount)
+		prevCount, nextIdx = int64(count), idx+1
+	}
+
+	// Downscaling merges adjacent buckets, their counts are accumulated
+	// before being appended.
+	var (
+		idx   int32
+		count uint64
+	)
+	for i, c := range b.Counts {
+		if c == 0 {
+			continue
+		}
+		// The exponential histogram bucket with the index i holds the values
+		// in (base^i, base^(i+1)], the native histogram one the values in
+		// (base^(i-1), base^i].
+		j := (b.Offset+int32(i))>>shift + 1
+		if count > 0 && j != idx {
+			appendBucket(idx, count)
+			count = 0
+		}
+		idx = j
+		count += c
+	}
+	if count > 0 {
+		appendBucket(idx, count)
+	}
+	return spans, deltas
+}
+
+func toDTOExemplar(e prometheus.Exemplar) *dto.Exemplar {
+	ex := &dto.Exemplar{
+		Value: proto.Float64(e.Value),
+		Label: make([]*dto.LabelPair, 0, len(e.Labels)),
+	}
+	if !e.Timestamp.IsZero() {
+		ex.Timestamp = timestamppb.New(e.Timestamp)
+	}
+	for name, value := range e.Labels {
+		ex.Label = append(ex.Label, &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)})
+	}
+	slices.SortFunc(ex.Label, func(a, b *dto.LabelPair) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return ex
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// span is a comparable dto.BucketSpan.
+type span struct {
+	Offset int32
+	Length uint32
+}
+
+func spans(s []*dto.BucketSpan) []span {
+	if s == nil {
+		return nil
+	}
+	out := make([]span, len(s))
+	for i, v := range s {
+		out[i] = span{Offset: v.GetOffset(), Length: v.GetLength()}
+	}
+	return out
+}
+
+func TestNativeBuckets(t *testing.T) {
+	testCases := []struct {
+		name       string
+		bucket     metricdata.ExponentialBucket
+		shift      int32
+		wantSpans  []span
+		wantDeltas []int64
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:       "Contiguous",
+			bucket:     metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{1, 2, 3}},
+			wantSpans:  []span{{Offset: 1, Length: 3}},
+			wantDeltas: []int64{1, 1, 1},
+		},
+		{
+			name:       "Gaps",
+			bucket:     metricdata.ExponentialBucket{Offset: -2, Counts: []uint64{0, 1, 0, 0, 4, 0}},
+			wantSpans:  []span{{Offset: 0, Length: 1}, {Offset: 2, Length: 1}},
+			wantDeltas: []int64{1, 3},
+		},
+		{
+			name:       "Downscale",
+			bucket:     metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{1, 1, 1, 1}},
+			shift:      1,
+			wantSpans:  []span{{Offset: 1, Length: 2}},
+			wantDeltas: []int64{2, 0},
+		},
+		{
+			name:       "DownscaleNegativeOffset",
+			bucket:     metricdata.ExponentialBucket{Offset: -3, Counts: []uint64{1, 1, 1}},
+			shift:      1,
+			wantSpans:  []span{{Offset: -1, Length: 2}},
+			wantDeltas: []int64{1, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSpans, gotDeltas := nativeBuckets(tc.bucket, tc.shift)
+			assert.Equal(t, tc.wantSpans, spans(gotSpans), "spans")
+			assert.Equal(t, tc.wantDeltas, gotDeltas, "deltas")
+		})
+	}
+}
+
+func TestNewNativeHistogram(t *testing.T) {
+	desc := prometheus.NewDesc("foo", "", []string{"A"}, nil)
+
+	t.Run("Downscale", func(t *testing.T) {
+		m, err := newNativeHistogram(desc, metricdata.ExponentialHistogramDataPoint[float64]{
+			Count:          4,
+			Sum:            10,
+			Scale:          10,
+			ZeroCount:      1,
+			ZeroThreshold:  1e-9,
+			PositiveBucket: metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{1, 1, 1, 1}},
+		}, []string{"B"})
+		require.NoError(t, err)
+
+		var pb dto.Metric
+		require.NoError(t, m.Write(&pb))
+		h := pb.GetHistogram()
+		assert.Equal(t, int32(maxNativeHistogramSchema), h.GetSchema())
+		assert.Equal(t, uint64(4), h.GetSampleCount())
+		assert.Equal(t, 10.0, h.GetSampleSum())
+		assert.Equal(t, uint64(1), h.GetZeroCount())
+		assert.Equal(t, 1e-9, h.GetZeroThreshold())
+		assert.Equal(t, []span{{Offset: 1, Length: 1}}, spans(h.GetPositiveSpan()))
+		assert.Equal(t, []int64{4}, h.GetPositiveDelta())
+		assert.Empty(t, h.GetNegativeSpan())
+		require.Len(t, pb.GetLabel(), 1)
+		assert.Equal(t, "B", pb.GetLabel()[0].GetValue())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		m, err := newNativeHistogram(desc, metricdata.ExponentialHistogramDataPoint[int64]{}, []string{"B"})
+		require.NoError(t, err)
+
+		var pb dto.Metric
+		require.NoError(t, m.Write(&pb))
+		assert.Equal(t, []span{{}}, spans(pb.GetHistogram().GetPositiveSpan()), "native histogram marker")
+	})
+
+	t.Run("ScaleTooLow", func(t *testing.T) {
+		_, err := newNativeHistogram(desc, metricdata.ExponentialHistogramDataPoint[int64]{Scale: -5}, []string{"B"})
+		assert.Error(t, err)
+	})
+}
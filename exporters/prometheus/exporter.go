@@ -8,11 +8,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"slices"
-	"strings"
+	"net"
+	"net/http"
 	"sync"
-	"unicode"
-	"unicode/utf8"
 
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
@@ -20,6 +18,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/naming"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -48,6 +47,13 @@ var (
 // interface for easy instantiation with a MeterProvider.
 type Exporter struct {
 	metric.Reader
+
+	handler http.Handler
+	// server and listener are the HTTP server serving the metrics and its
+	// listener. They are nil if the Exporter is not configured with
+	// WithServer.
+	server   *http.Server
+	listener net.Listener
 }
 
 // MarshalLog returns logging data about the Exporter.
@@ -86,6 +92,7 @@ type collector struct {
 	disableScopeInfo         bool
 	namespace                string
 	resourceAttributesFilter attribute.Filter
+	translationStrategy      TranslationStrategy
 
 	mu                sync.Mutex // mu protects all members below from the concurrent access.
 	disableTargetInfo bool
@@ -96,10 +103,6 @@ type collector struct {
 	resourceKeyVals   keyVals
 }
 
-// prometheus counters MUST have a _total suffix by default:
-// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.20.0/specification/compatibility/prometheus_and_openmetrics.md
-const counterSuffix = "_total"
-
 // New returns a Prometheus Exporter.
 func New(opts ...Option) (*Exporter, error) {
 	cfg := newConfig(opts...)
@@ -120,14 +123,35 @@ func New(opts ...Option) (*Exporter, error) {
 		metricFamilies:           make(map[string]*dto.MetricFamily),
 		namespace:                cfg.namespace,
 		resourceAttributesFilter: cfg.resourceAttributesFilter,
+		translationStrategy:      cfg.translationStrategy,
+	}
+
+	handler, err := newHandler(collector)
+	if err != nil {
+		return nil, err
+	}
+
+	var ln net.Listener
+	if cfg.withServer {
+		ln, err = net.Listen("tcp", cfg.serverAddr)
+		if err != nil {
+			return nil, fmt.Errorf("cannot listen on %s: %w", cfg.serverAddr, err)
+		}
 	}
 
 	if err := cfg.registerer.Register(collector); err != nil {
+		if ln != nil {
+			_ = ln.Close()
+		}
 		return nil, fmt.Errorf("cannot register the collector: %w", err)
 	}
 
 	e := &Exporter{
-		Reader: reader,
+		Reader:  reader,
+		handler: handler,
+	}
+	if ln != nil {
+		e.serve(ln)
 	}
 
 	return e, nil
@@ -167,7 +191,7 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 		defer c.mu.Unlock()
 
 		if c.targetInfo == nil && !c.disableTargetInfo {
-			targetInfo, err := createInfoMetric(targetInfoMetricName, targetInfoDescription, metrics.Resource)
+			targetInfo, err := createInfoMetric(targetInfoMetricName, targetInfoDescription, metrics.Resource, c.translationStrategy)
 			if err != nil {
 				// If the target info metric is invalid, disable sending it.
 				c.disableTargetInfo = true
@@ -225,25 +249,31 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 
 			switch v := m.Data.(type) {
 			case metricdata.Histogram[int64]:
-				addHistogramMetric(ch, v, m, keys, values, name, c.resourceKeyVals)
+				addHistogramMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
 			case metricdata.Histogram[float64]:
-				addHistogramMetric(ch, v, m, keys, values, name, c.resourceKeyVals)
+				addHistogramMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
 			case metricdata.Sum[int64]:
-				addSumMetric(ch, v, m, keys, values, name, c.resourceKeyVals)
+				addSumMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
 			case metricdata.Sum[float64]:
-				addSumMetric(ch, v, m, keys, values, name, c.resourceKeyVals)
+				addSumMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
 			case metricdata.Gauge[int64]:
-				addGaugeMetric(ch, v, m, keys, values, name, c.resourceKeyVals)
+				addGaugeMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
 			case metricdata.Gauge[float64]:
-				addGaugeMetric(ch, v, m, keys, values, name, c.resourceKeyVals)
+				addGaugeMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
+			case metricdata.ExponentialHistogram[int64]:
+				addExponentialHistogramMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
+			case metricdata.ExponentialHistogram[float64]:
+				addExponentialHistogramMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
+			case metricdata.Summary:
+				addSummaryMetric(ch, v, m, keys, values, name, c.resourceKeyVals, c.translationStrategy)
 			}
 		}
 	}
 }
 
-func addHistogramMetric[N int64 | float64](ch chan<- prometheus.Metric, histogram metricdata.Histogram[N], m metricdata.Metrics, ks, vs [2]string, name string, resourceKV keyVals) {
+func addHistogramMetric[N int64 | float64](ch chan<- prometheus.Metric, histogram metricdata.Histogram[N], m metricdata.Metrics, ks, vs [2]string, name string, resourceKV keyVals, strategy TranslationStrategy) {
 	for _, dp := range histogram.DataPoints {
-		keys, values := getAttrs(dp.Attributes, ks, vs, resourceKV)
+		keys, values := getAttrs(dp.Attributes, ks, vs, resourceKV, strategy)
 
 		desc := prometheus.NewDesc(name, m.Description, keys, nil)
 		buckets := make(map[float64]uint64, len(dp.Bounds))
@@ -263,14 +293,46 @@ func addHistogramMetric[N int64 | float64](ch chan<- prometheus.Metric, histogra
 	}
 }
 
-func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata.Sum[N], m metricdata.Metrics, ks, vs [2]string, name string, resourceKV keyVals) {
+func addExponentialHistogramMetric[N int64 | float64](ch chan<- prometheus.Metric, histogram metricdata.ExponentialHistogram[N], m metricdata.Metrics, ks, vs [2]string, name string, resourceKV keyVals, strategy TranslationStrategy) {
+	for _, dp := range histogram.DataPoints {
+		keys, values := getAttrs(dp.Attributes, ks, vs, resourceKV, strategy)
+
+		desc := prometheus.NewDesc(name, m.Description, keys, nil)
+		m, err := newNativeHistogram(desc, dp, values)
+		if err != nil {
+			otel.Handle(err)
+			continue
+		}
+		ch <- m
+	}
+}
+
+func addSummaryMetric(ch chan<- prometheus.Metric, summary metricdata.Summary, m metricdata.Metrics, ks, vs [2]string, name string, resourceKV keyVals, strategy TranslationStrategy) {
+	for _, dp := range summary.DataPoints {
+		keys, values := getAttrs(dp.Attributes, ks, vs, resourceKV, strategy)
+
+		desc := prometheus.NewDesc(name, m.Description, keys, nil)
+		quantiles := make(map[float64]float64, len(dp.QuantileValues))
+		for _, q := range dp.QuantileValues {
+			quantiles[q.Quantile] = q.Value
+		}
+		m, err := prometheus.NewConstSummary(desc, dp.Count, dp.Sum, quantiles, values...)
+		if err != nil {
+			otel.Handle(err)
+			continue
+		}
+		ch <- m
+	}
+}
+
+func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata.Sum[N], m metricdata.Metrics, ks, vs [2]string, name string, resourceKV keyVals, strategy TranslationStrategy) {
 	valueType := prometheus.CounterValue
 	if !sum.IsMonotonic {
 		valueType = prometheus.GaugeValue
 	}
 
 	for _, dp := range sum.DataPoints {
-		keys, values := getAttrs(dp.Attributes, ks, vs, resourceKV)
+		keys, values := getAttrs(dp.Attributes, ks, vs, resourceKV, strategy)
 
 		desc := prometheus.NewDesc(name, m.Description, keys, nil)
 		m, err := prometheus.NewConstMetric(desc, valueType, float64(dp.Value), values...)
@@ -283,9 +345,9 @@ func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata
 	}
 }
 
-func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metricdata.Gauge[N], m metricdata.Metrics, ks, vs [2]string, name string, resourceKV keyVals) {
+func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metricdata.Gauge[N], m metricdata.Metrics, ks, vs [2]string, name string, resourceKV keyVals, strategy TranslationStrategy) {
 	for _, dp := range gauge.DataPoints {
-		keys, values := getAttrs(dp.Attributes, ks, vs, resourceKV)
+		keys, values := getAttrs(dp.Attributes, ks, vs, resourceKV, strategy)
 
 		desc := prometheus.NewDesc(name, m.Description, keys, nil)
 		m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(dp.Value), values...)
@@ -298,29 +360,11 @@ func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metric
 }
 
 // getAttrs parses the attribute.Set to two lists of matching Prometheus-style
-// keys and values. It sanitizes invalid characters and handles duplicate keys
-// (due to sanitization) by sorting and concatenating the values following the spec.
-func getAttrs(attrs attribute.Set, ks, vs [2]string, resourceKV keyVals) ([]string, []string) {
-	keysMap := make(map[string][]string)
-	itr := attrs.Iter()
-	for itr.Next() {
-		kv := itr.Attribute()
-		key := strings.Map(sanitizeRune, string(kv.Key))
-		if _, ok := keysMap[key]; !ok {
-			keysMap[key] = []string{kv.Value.Emit()}
-		} else {
-			// if the sanitized key is a duplicate, append to the list of keys
-			keysMap[key] = append(keysMap[key], kv.Value.Emit())
-		}
-	}
-
-	keys := make([]string, 0, attrs.Len())
-	values := make([]string, 0, attrs.Len())
-	for key, vals := range keysMap {
-		keys = append(keys, key)
-		slices.Sort(vals)
-		values = append(values, strings.Join(vals, ";"))
-	}
+// keys and values. If the strategy escapes names, it sanitizes invalid
+// characters and handles duplicate keys (due to sanitization) by sorting and
+// concatenating the values following the spec.
+func getAttrs(attrs attribute.Set, ks, vs [2]string, resourceKV keyVals, strategy TranslationStrategy) ([]string, []string) {
+	keys, values := naming.Labels(attrs, strategy.escapeNames())
 
 	if ks[0] != "" {
 		keys = append(keys, ks[:]...)
@@ -335,8 +379,8 @@ func getAttrs(attrs attribute.Set, ks, vs [2]string, resourceKV keyVals) ([]stri
 	return keys, values
 }
 
-func createInfoMetric(name, description string, res *resource.Resource) (prometheus.Metric, error) {
-	keys, values := getAttrs(*res.Set(), [2]string{}, [2]string{}, keyVals{})
+func createInfoMetric(name, description string, res *resource.Resource, strategy TranslationStrategy) (prometheus.Metric, error) {
+	keys, values := getAttrs(*res.Set(), [2]string{}, [2]string{}, keyVals{}, strategy)
 	desc := prometheus.NewDesc(name, description, keys, nil)
 	return prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(1), values...)
 }
@@ -347,127 +391,24 @@ func createScopeInfoMetric(scope instrumentation.Scope) (prometheus.Metric, erro
 	return prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(1), scope.Name, scope.Version)
 }
 
-func sanitizeRune(r rune) rune {
-	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ':' || r == '_' {
-		return r
-	}
-	return '_'
-}
-
-var unitSuffixes = map[string]string{
-	// Time
-	"d":   "_days",
-	"h":   "_hours",
-	"min": "_minutes",
-	"s":   "_seconds",
-	"ms":  "_milliseconds",
-	"us":  "_microseconds",
-	"ns":  "_nanoseconds",
-
-	// Bytes
-	"By":   "_bytes",
-	"KiBy": "_kibibytes",
-	"MiBy": "_mebibytes",
-	"GiBy": "_gibibytes",
-	"TiBy": "_tibibytes",
-	"KBy":  "_kilobytes",
-	"MBy":  "_megabytes",
-	"GBy":  "_gigabytes",
-	"TBy":  "_terabytes",
-
-	// SI
-	"m": "_meters",
-	"V": "_volts",
-	"A": "_amperes",
-	"J": "_joules",
-	"W": "_watts",
-	"g": "_grams",
-
-	// Misc
-	"Cel": "_celsius",
-	"Hz":  "_hertz",
-	"1":   "_ratio",
-	"%":   "_percent",
-}
-
-// getName returns the sanitized name, prefixed with the namespace and suffixed with unit.
+// getName returns the name translated with the translation strategy,
+// prefixed with the namespace and suffixed with unit.
 func (c *collector) getName(m metricdata.Metrics, typ *dto.MetricType) string {
-	name := sanitizeName(m.Name)
-	addCounterSuffix := !c.withoutCounterSuffixes && *typ == dto.MetricType_COUNTER
-	if addCounterSuffix {
-		// Remove the _total suffix here, as we will re-add the total suffix
-		// later, and it needs to come after the unit suffix.
-		name = strings.TrimSuffix(name, counterSuffix)
-	}
-	if c.namespace != "" {
-		name = c.namespace + name
-	}
-	if suffix, ok := unitSuffixes[m.Unit]; ok && !c.withoutUnits && !strings.HasSuffix(name, suffix) {
-		name += suffix
-	}
-	if addCounterSuffix {
-		name += counterSuffix
-	}
-	return name
-}
-
-func sanitizeName(n string) string {
-	// This algorithm is based on strings.Map from Go 1.19.
-	const replacement = '_'
-
-	valid := func(i int, r rune) bool {
-		// Taken from
-		// https://github.com/prometheus/common/blob/dfbc25bd00225c70aca0d94c3c4bb7744f28ace0/model/metric.go#L92-L102
-		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r == ':' || (r >= '0' && r <= '9' && i > 0) {
-			return true
-		}
-		return false
-	}
-
-	// This output buffer b is initialized on demand, the first time a
-	// character needs to be replaced.
-	var b strings.Builder
-	for i, c := range n {
-		if valid(i, c) {
-			continue
-		}
-
-		if i == 0 && c >= '0' && c <= '9' {
-			// Prefix leading number with replacement character.
-			b.Grow(len(n) + 1)
-			_ = b.WriteByte(byte(replacement))
-			break
-		}
-		b.Grow(len(n))
-		_, _ = b.WriteString(n[:i])
-		_ = b.WriteByte(byte(replacement))
-		width := utf8.RuneLen(c)
-		n = n[i+width:]
-		break
-	}
-
-	// Fast path for unchanged input.
-	if b.Cap() == 0 { // b.Grow was not called above.
-		return n
-	}
-
-	for _, c := range n {
-		// Due to inlining, it is more performant to invoke WriteByte rather then
-		// WriteRune.
-		if valid(1, c) { // We are guaranteed to not be at the start.
-			_ = b.WriteByte(byte(c))
-		} else {
-			_ = b.WriteByte(byte(replacement))
-		}
-	}
-
-	return b.String()
+	return naming.Namer{
+		Namespace:              c.namespace,
+		Strategy:               naming.Strategy(c.translationStrategy),
+		WithoutUnits:           c.withoutUnits,
+		WithoutCounterSuffixes: c.withoutCounterSuffixes,
+	}.MetricName(m.Name, m.Unit, *typ == dto.MetricType_COUNTER)
 }
 
 func (c *collector) metricType(m metricdata.Metrics) *dto.MetricType {
 	switch v := m.Data.(type) {
-	case metricdata.Histogram[int64], metricdata.Histogram[float64]:
+	case metricdata.Histogram[int64], metricdata.Histogram[float64],
+		metricdata.ExponentialHistogram[int64], metricdata.ExponentialHistogram[float64]:
 		return dto.MetricType_HISTOGRAM.Enum()
+	case metricdata.Summary:
+		return dto.MetricType_SUMMARY.Enum()
 	case metricdata.Sum[float64]:
 		if v.IsMonotonic {
 			return dto.MetricType_COUNTER.Enum()
@@ -489,7 +430,7 @@ func (c *collector) createResourceAttributes(res *resource.Resource) {
 	defer c.mu.Unlock()
 
 	resourceAttrs, _ := res.Set().Filter(c.resourceAttributesFilter)
-	resourceKeys, resourceValues := getAttrs(resourceAttrs, [2]string{}, [2]string{}, keyVals{})
+	resourceKeys, resourceValues := getAttrs(resourceAttrs, [2]string{}, [2]string{}, keyVals{}, c.translationStrategy)
 	c.resourceKeyVals = keyVals{keys: resourceKeys, vals: resourceValues}
 }
 
@@ -559,6 +500,17 @@ func addExemplars[N int64 | float64](m prometheus.Metric, exemplars []metricdata
 	if len(exemplars) == 0 {
 		return m
 	}
+	metricWithExemplar, err := prometheus.NewMetricWithExemplars(m, toPromExemplars(exemplars)...)
+	if err != nil {
+		// If there are errors creating the metric with exemplars, just warn
+		// and return the metric without exemplars.
+		otel.Handle(err)
+		return m
+	}
+	return metricWithExemplar
+}
+
+func toPromExemplars[N int64 | float64](exemplars []metricdata.Exemplar[N]) []prometheus.Exemplar {
 	promExemplars := make([]prometheus.Exemplar, len(exemplars))
 	for i, exemplar := range exemplars {
 		labels := attributesToLabels(exemplar.FilteredAttributes)
@@ -571,14 +523,7 @@ func addExemplars[N int64 | float64](m prometheus.Metric, exemplars []metricdata
 			Labels:    labels,
 		}
 	}
-	metricWithExemplar, err := prometheus.NewMetricWithExemplars(m, promExemplars...)
-	if err != nil {
-		// If there are errors creating the metric with exemplars, just warn
-		// and return the metric without exemplars.
-		otel.Handle(err)
-		return m
-	}
-	return metricWithExemplar
+	return promExemplars
 }
 
 func attributesToLabels(attrs []attribute.KeyValue) prometheus.Labels {
@@ -8,12 +8,14 @@ import (
 	"errors"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -21,6 +23,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	otelmetric "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
@@ -452,36 +455,6 @@ func TestPrometheusExporter(t *testing.T) {
 	}
 }
 
-func TestSantitizeName(t *testing.T) {
-	tests := []struct {
-		input string
-		want  string
-	}{
-		{"name€_with_4_width_rune", "name__with_4_width_rune"},
-		{"`", "_"},
-		{
-			`! "#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWKYZ[]\^_abcdefghijklmnopqrstuvwkyz{|}~`,
-			`________________0123456789:______ABCDEFGHIJKLMNOPQRSTUVWKYZ_____abcdefghijklmnopqrstuvwkyz____`,
-		},
-
-		// Test cases taken from
-		// https://github.com/prometheus/common/blob/dfbc25bd00225c70aca0d94c3c4bb7744f28ace0/model/metric_test.go#L85-L136
-		{"Avalid_23name", "Avalid_23name"},
-		{"_Avalid_23name", "_Avalid_23name"},
-		{"1valid_23name", "_1valid_23name"},
-		{"avalid_23name", "avalid_23name"},
-		{"Ava:lid_23name", "Ava:lid_23name"},
-		{"a lid_23name", "a_lid_23name"},
-		{":leading_colon", ":leading_colon"},
-		{"colon:in:the:middle", "colon:in:the:middle"},
-		{"", ""},
-	}
-
-	for _, test := range tests {
-		require.Equalf(t, test.want, sanitizeName(test.input), "input: %q", test.input)
-	}
-}
-
 func TestMultiScopes(t *testing.T) {
 	ctx := context.Background()
 	registry := prometheus.NewRegistry()
@@ -1015,3 +988,165 @@ func TestExemplars(t *testing.T) {
 		})
 	}
 }
+
+func TestExponentialHistogram(t *testing.T) {
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	exporter, err := New(WithRegisterer(registry), WithoutTargetInfo(), WithoutScopeInfo())
+	require.NoError(t, err)
+
+	provider := metric.NewMeterProvider(
+		metric.WithReader(exporter),
+		metric.WithView(metric.NewView(
+			metric.Instrument{Name: "*"},
+			metric.Stream{Aggregation: metric.AggregationBase2ExponentialHistogram{
+				MaxSize:  160,
+				MaxScale: 20,
+			}},
+		)),
+	)
+	hist, err := provider.Meter("meter").Float64Histogram(
+		"foo",
+		otelmetric.WithDescription("an exponential histogram"),
+		otelmetric.WithUnit("s"),
+	)
+	require.NoError(t, err)
+	opt := otelmetric.WithAttributes(attribute.Key("A").String("B"))
+	for _, v := range []float64{0, 1, 2, 4} {
+		hist.Record(ctx, v, opt)
+	}
+
+	got, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	family := got[0]
+	assert.Equal(t, "foo_seconds", family.GetName())
+	assert.Equal(t, "an exponential histogram", family.GetHelp())
+	assert.Equal(t, dto.MetricType_HISTOGRAM, family.GetType())
+	require.Len(t, family.GetMetric(), 1)
+
+	h := family.GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(4), h.GetSampleCount())
+	assert.Equal(t, 7.0, h.GetSampleSum())
+	assert.Equal(t, uint64(1), h.GetZeroCount())
+	assert.Empty(t, h.GetBucket(), "classic buckets")
+	// 160 buckets at scale 6 hold the values in (1/2, 4].
+	assert.Equal(t, int32(6), h.GetSchema())
+	assert.Equal(t, []span{
+		{Offset: 0, Length: 1},
+		{Offset: 63, Length: 1},
+		{Offset: 63, Length: 1},
+	}, spans(h.GetPositiveSpan()))
+	assert.Equal(t, []int64{1, 0, 0}, h.GetPositiveDelta())
+}
+
+// staticProducer produces the same scope metrics on every call.
+type staticProducer struct {
+	scopeMetrics []metricdata.ScopeMetrics
+}
+
+func (p *staticProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	return p.scopeMetrics, nil
+}
+
+func TestSummary(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	producer := &staticProducer{scopeMetrics: []metricdata.ScopeMetrics{{
+		Metrics: []metricdata.Metrics{{
+			Name:        "foo",
+			Description: "a summary",
+			Unit:        "s",
+			Data: metricdata.Summary{
+				DataPoints: []metricdata.SummaryDataPoint{{
+					Attributes: attribute.NewSet(attribute.Key("A").String("B")),
+					Count:      3,
+					Sum:        6,
+					QuantileValues: []metricdata.QuantileValue{
+						{Quantile: 0.5, Value: 2},
+						{Quantile: 1, Value: 3},
+					},
+				}},
+			},
+		}},
+	}}}
+	exporter, err := New(
+		WithRegisterer(registry),
+		WithoutTargetInfo(),
+		WithoutScopeInfo(),
+		WithProducer(producer),
+	)
+	require.NoError(t, err)
+	_ = metric.NewMeterProvider(metric.WithReader(exporter))
+
+	expected := `# HELP foo_seconds a summary
+# TYPE foo_seconds summary
+foo_seconds{A="B",quantile="0.5"} 2
+foo_seconds{A="B",quantile="1"} 3
+foo_seconds_sum{A="B"} 6
+foo_seconds_count{A="B"} 3
+`
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected))
+	assert.NoError(t, err)
+}
+
+func TestTranslationStrategy(t *testing.T) {
+	testCases := []struct {
+		strategy       TranslationStrategy
+		wantCounter    string
+		wantLabel      string
+		wantTargetInfo string
+	}{
+		{
+			strategy:       UnderscoreEscapingWithSuffixes,
+			wantCounter:    "foo_bar_seconds_total",
+			wantLabel:      "A_B",
+			wantTargetInfo: "service_name",
+		},
+		{
+			strategy:       NoUTF8EscapingWithSuffixes,
+			wantCounter:    "foo.bar_seconds_total",
+			wantLabel:      "A.B",
+			wantTargetInfo: "service.name",
+		},
+		{
+			strategy:       NoTranslation,
+			wantCounter:    "foo.bar",
+			wantLabel:      "A.B",
+			wantTargetInfo: "service.name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			scheme := model.NameValidationScheme
+			model.NameValidationScheme = model.UTF8Validation
+			t.Cleanup(func() { model.NameValidationScheme = scheme })
+
+			ctx := context.Background()
+			registry := prometheus.NewRegistry()
+			exporter, err := New(WithRegisterer(registry), WithTranslationStrategy(tc.strategy))
+			require.NoError(t, err)
+
+			res := resource.NewSchemaless(semconv.ServiceName("prometheus_test"))
+			provider := metric.NewMeterProvider(metric.WithReader(exporter), metric.WithResource(res))
+			counter, err := provider.Meter("meter").Float64Counter("foo.bar", otelmetric.WithUnit("s"))
+			require.NoError(t, err)
+			counter.Add(ctx, 1, otelmetric.WithAttributes(attribute.Key("A.B").String("C")))
+
+			got, err := registry.Gather()
+			require.NoError(t, err)
+			labels := make(map[string][]string)
+			for _, family := range got {
+				for _, m := range family.GetMetric() {
+					for _, l := range m.GetLabel() {
+						labels[family.GetName()] = append(labels[family.GetName()], l.GetName())
+					}
+				}
+			}
+			assert.Contains(t, labels[targetInfoMetricName], tc.wantTargetInfo)
+			assert.ElementsMatch(t, scopeInfoKeys[:], labels[scopeInfoMetricName])
+			require.Contains(t, labels, tc.wantCounter)
+			assert.Contains(t, labels[tc.wantCounter], tc.wantLabel)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package naming provides the rules used to translate OpenTelemetry metric
+// and attribute names into Prometheus names.
+package naming // import "go.opentelemetry.io/otel/exporters/prometheus/internal/naming"
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/prometheus/common/model"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CounterSuffix is the suffix of Prometheus counters. Prometheus counters MUST
+// have a _total suffix by default:
+// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.20.0/specification/compatibility/prometheus_and_openmetrics.md
+const CounterSuffix = "_total"
+
+// SanitizeLabelName returns n with the characters that are not valid in a
+// Prometheus label name replaced by an underscore.
+func SanitizeLabelName(n string) string {
+	return strings.Map(sanitizeRune, n)
+}
+
+func sanitizeRune(r rune) rune {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ':' || r == '_' {
+		return r
+	}
+	return '_'
+}
+
+var unitSuffixes = map[string]string{
+	// Time
+	"d":   "_days",
+	"h":   "_hours",
+	"min": "_minutes",
+	"s":   "_seconds",
+	"ms":  "_milliseconds",
+	"us":  "_microseconds",
+	"ns":  "_nanoseconds",
+
+	// Bytes
+	"By":   "_bytes",
+	"KiBy": "_kibibytes",
+	"MiBy": "_mebibytes",
+	"GiBy": "_gibibytes",
+	"TiBy": "_tibibytes",
+	"KBy":  "_kilobytes",
+	"MBy":  "_megabytes",
+	"GBy":  "_gigabytes",
+	"TBy":  "_terabytes",
+
+	// SI
+	"m": "_meters",
+	"V": "_volts",
+	"A": "_amperes",
+	"J": "_joules",
+	"W": "_watts",
+	"g": "_grams",
+
+	// Misc
+	"Cel": "_celsius",
+	"Hz":  "_hertz",
+	"1":   "_ratio",
+	"%":   "_percent",
+}
+
+// Labels returns the Prometheus label names and values of attrs. If escape is
+// true, the invalid characters of the names are sanitized and the values of
+// the duplicate names (due to sanitization) are sorted and concatenated
+// following the specification.
+func Labels(attrs attribute.Set, escape bool) ([]string, []string) {
+	keysMap := make(map[string][]string)
+	itr := attrs.Iter()
+	for itr.Next() {
+		kv := itr.Attribute()
+		key := string(kv.Key)
+		if escape {
+			key = SanitizeLabelName(key)
+		}
+		// if the sanitized key is a duplicate, append to the list of keys
+		keysMap[key] = append(keysMap[key], kv.Value.Emit())
+	}
+
+	keys := make([]string, 0, attrs.Len())
+	values := make([]string, 0, attrs.Len())
+	for key, vals := range keysMap {
+		keys = append(keys, key)
+		slices.Sort(vals)
+		values = append(values, strings.Join(vals, ";"))
+	}
+	return keys, values
+}
+
+// UnitSuffix returns the Prometheus suffix of the OpenTelemetry unit, and
+// whether the unit has one.
+func UnitSuffix(unit string) (string, bool) {
+	s, ok := unitSuffixes[unit]
+	return s, ok
+}
+
+// SanitizeName returns n with the characters that are not valid in a
+// Prometheus metric name replaced by an underscore. A leading digit is
+// prefixed with an underscore.
+func SanitizeName(n string) string {
+	// This algorithm is based on strings.Map from Go 1.19.
+	const replacement = '_'
+
+	valid := func(i int, r rune) bool {
+		// Taken from
+		// https://github.com/prometheus/common/blob/dfbc25bd00225c70aca0d94c3c4bb7744f28ace0/model/metric.go#L92-L102
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r == ':' || (r >= '0' && r <= '9' && i > 0) {
+			return true
+		}
+		return false
+	}
+
+	// This output buffer b is initialized on demand, the first time a
+	// character needs to be replaced.
+	var b strings.Builder
+	for i, c := range n {
+		if valid(i, c) {
+			continue
+		}
+
+		if i == 0 && c >= '0' && c <= '9' {
+			// Prefix leading number with replacement character.
+			b.Grow(len(n) + 1)
+			_ = b.WriteByte(byte(replacement))
+			break
+		}
+		b.Grow(len(n))
+		_, _ = b.WriteString(n[:i])
+		_ = b.WriteByte(byte(replacement))
+		width := utf8.RuneLen(c)
+		n = n[i+width:]
+		break
+	}
+
+	// Fast path for unchanged input.
+	if b.Cap() == 0 { // b.Grow was not called above.
+		return n
+	}
+
+	for _, c := range n {
+		// Due to inlining, it is more performant to invoke WriteByte rather then
+		// WriteRune.
+		if valid(1, c) { // We are guaranteed to not be at the start.
+			_ = b.WriteByte(byte(c))
+		} else {
+			_ = b.WriteByte(byte(replacement))
+		}
+	}
+
+	return b.String()
+}
+
+// Strategy defines how the OpenTelemetry metric and attribute names are
+// translated into Prometheus metric and label names. Its values are the ones
+// of the TranslationStrategy of the exporters.
+type Strategy string
+
+const (
+	// UnderscoreEscapingWithSuffixes escapes the names and adds the suffixes.
+	UnderscoreEscapingWithSuffixes Strategy = "UnderscoreEscapingWithSuffixes"
+	// NoUTF8EscapingWithSuffixes keeps the UTF-8 names and adds the suffixes.
+	NoUTF8EscapingWithSuffixes Strategy = "NoUTF8EscapingWithSuffixes"
+	// NoTranslation keeps the names as they are.
+	NoTranslation Strategy = "NoTranslation"
+)
+
+// DefaultStrategy returns NoUTF8EscapingWithSuffixes if the Prometheus name
+// validation scheme is model.UTF8Validation, and
+// UnderscoreEscapingWithSuffixes otherwise.
+func DefaultStrategy() Strategy {
+	if model.NameValidationScheme == model.UTF8Validation {
+		return NoUTF8EscapingWithSuffixes
+	}
+	return UnderscoreEscapingWithSuffixes
+}
+
+// Resolve returns s, or DefaultStrategy if s is empty. If s is unknown,
+// DefaultStrategy is returned along with an error.
+func (s Strategy) Resolve() (Strategy, error) {
+	switch s {
+	case UnderscoreEscapingWithSuffixes, NoUTF8EscapingWithSuffixes, NoTranslation:
+		return s, nil
+	case "":
+		return DefaultStrategy(), nil
+	default:
+		return DefaultStrategy(), fmt.Errorf("unknown translation strategy: %q", string(s))
+	}
+}
+
+// EscapeNames reports whether the names not supported by the legacy
+// Prometheus name validation are escaped.
+func (s Strategy) EscapeNames() bool {
+	return s == UnderscoreEscapingWithSuffixes
+}
+
+// AddSuffixes reports whether the unit and _total suffixes are added to the
+// metric names.
+func (s Strategy) AddSuffixes() bool {
+	return s == UnderscoreEscapingWithSuffixes || s == NoUTF8EscapingWithSuffixes
+}
+
+// Namer translates OpenTelemetry metric names into Prometheus metric names.
+type Namer struct {
+	// Namespace is prepended to the names. It is expected to already be
+	// translated and to end with an underscore.
+	Namespace string
+	// Strategy is the translation strategy of the names. The names are kept
+	// as they are if it is empty.
+	Strategy Strategy
+	// WithoutUnits disables the unit suffixes.
+	WithoutUnits bool
+	// WithoutCounterSuffixes disables the counter suffix.
+	WithoutCounterSuffixes bool
+}
+
+// MetricName returns the Prometheus name of the metric with the name and
+// unit. The counter suffix is only appended if counter is true.
+func (n Namer) MetricName(name, unit string, counter bool) string {
+	if n.Strategy.EscapeNames() {
+		name = SanitizeName(name)
+	}
+	if !n.Strategy.AddSuffixes() {
+		return n.Namespace + name
+	}
+	addCounterSuffix := !n.WithoutCounterSuffixes && counter
+	if addCounterSuffix {
+		// Remove the _total suffix here, as we will re-add the total suffix
+		// later, and it needs to come after the unit suffix.
+		name = strings.TrimSuffix(name, CounterSuffix)
+	}
+	name = n.Namespace + name
+	if suffix, ok := UnitSuffix(unit); ok && !n.WithoutUnits && !strings.HasSuffix(name, suffix) {
+		name += suffix
+	}
+	if addCounterSuffix {
+		name += CounterSuffix
+	}
+	return name
+}
+
+// Labels returns the Prometheus label names and values of attrs translated
+// with the strategy of n.
+func (n Namer) Labels(attrs attribute.Set) ([]string, []string) {
+	return Labels(attrs, n.Strategy.EscapeNames())
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package naming
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSanitizeName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"name€_with_4_width_rune", "name__with_4_width_rune"},
+		{"`", "_"},
+		{
+			`! "#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWKYZ[]\^_abcdefghijklmnopqrstuvwkyz{|}~`,
+			`________________0123456789:______ABCDEFGHIJKLMNOPQRSTUVWKYZ_____abcdefghijklmnopqrstuvwkyz____`,
+		},
+
+		// Test cases taken from
+		// https://github.com/prometheus/common/blob/dfbc25bd00225c70aca0d94c3c4bb7744f28ace0/model/metric_test.go#L85-L136
+		{"Avalid_23name", "Avalid_23name"},
+		{"_Avalid_23name", "_Avalid_23name"},
+		{"1valid_23name", "_1valid_23name"},
+		{"avalid_23name", "avalid_23name"},
+		{"Ava:lid_23name", "Ava:lid_23name"},
+		{"a lid_23name", "a_lid_23name"},
+		{":leading_colon", ":leading_colon"},
+		{"colon:in:the:middle", "colon:in:the:middle"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		require.Equalf(t, test.want, SanitizeName(test.input), "input: %q", test.input)
+	}
+}
+
+func TestSanitizeLabelName(t *testing.T) {
+	assert.Equal(t, "a_b_c", SanitizeLabelName("a.b-c"))
+	assert.Equal(t, "1_ünicode", SanitizeLabelName("1 ünicode"))
+}
+
+func TestNamerMetricName(t *testing.T) {
+	suffixes := Namer{Strategy: UnderscoreEscapingWithSuffixes}
+	testCases := []struct {
+		name    string
+		namer   Namer
+		metric  string
+		unit    string
+		counter bool
+		want    string
+	}{
+		{name: "Unit", namer: suffixes, metric: "foo", unit: "s", want: "foo_seconds"},
+		{name: "UnitAlreadySuffixed", namer: suffixes, metric: "foo_seconds", unit: "s", want: "foo_seconds"},
+		{name: "Counter", namer: suffixes, metric: "foo", unit: "By", counter: true, want: "foo_bytes_total"},
+		{name: "CounterAlreadySuffixed", namer: suffixes, metric: "foo_total", unit: "By", counter: true, want: "foo_bytes_total"},
+		{name: "Namespace", namer: Namer{Namespace: "ns_", Strategy: UnderscoreEscapingWithSuffixes}, metric: "foo.bar", want: "ns_foo_bar"},
+		{name: "WithoutUnits", namer: Namer{Strategy: NoUTF8EscapingWithSuffixes, WithoutUnits: true}, metric: "foo", unit: "s", counter: true, want: "foo_total"},
+		{name: "WithoutCounterSuffixes", namer: Namer{Strategy: NoUTF8EscapingWithSuffixes, WithoutCounterSuffixes: true}, metric: "foo", unit: "s", counter: true, want: "foo_seconds"},
+		{name: "NoUTF8Escaping", namer: Namer{Strategy: NoUTF8EscapingWithSuffixes}, metric: "foo.bar", unit: "s", want: "foo.bar_seconds"},
+		{name: "NoTranslation", namer: Namer{Strategy: NoTranslation}, metric: "foo.bar", unit: "s", counter: true, want: "foo.bar"},
+		{name: "NoSuffixes", namer: Namer{}, metric: "foo.bar", unit: "s", counter: true, want: "foo.bar"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.namer.MetricName(tc.metric, tc.unit, tc.counter))
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	attrs := attribute.NewSet(
+		attribute.String("a.b", "2"),
+		attribute.String("a_b", "1"),
+		attribute.Int("c", 3),
+	)
+
+	keys, values := Labels(attrs, true)
+	assert.ElementsMatch(t, []string{"a_b", "c"}, keys)
+	assert.Equal(t, map[string]string{"a_b": "1;2", "c": "3"}, toMap(keys, values))
+
+	keys, values = Labels(attrs, false)
+	assert.Equal(t, map[string]string{"a.b": "2", "a_b": "1", "c": "3"}, toMap(keys, values))
+}
+
+func TestStrategyResolve(t *testing.T) {
+	for _, s := range []Strategy{UnderscoreEscapingWithSuffixes, NoUTF8EscapingWithSuffixes, NoTranslation} {
+		got, err := s.Resolve()
+		assert.NoError(t, err)
+		assert.Equal(t, s, got)
+	}
+
+	got, err := Strategy("").Resolve()
+	assert.NoError(t, err)
+	assert.Equal(t, UnderscoreEscapingWithSuffixes, got)
+
+	got, err = Strategy("Unknown").Resolve()
+	assert.ErrorContains(t, err, `"Unknown"`)
+	assert.Equal(t, UnderscoreEscapingWithSuffixes, got)
+}
+
+func TestStrategyDefaultUTF8(t *testing.T) {
+	scheme := model.NameValidationScheme
+	t.Cleanup(func() { model.NameValidationScheme = scheme })
+	model.NameValidationScheme = model.UTF8Validation
+
+	got, err := Strategy("").Resolve()
+	assert.NoError(t, err)
+	assert.Equal(t, NoUTF8EscapingWithSuffixes, got)
+}
+
+func toMap(keys, values []string) map[string]string {
+	m := make(map[string]string, len(keys))
+	for i, k := range keys {
+		m[k] = values[i]
+	}
+	return m
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/remotewrite"
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/naming"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/retry"
+	"go.opentelemetry.io/otel/internal/global"
+)
+
+const (
+	defaultTimeout             = 10 * time.Second
+	defaultMaxSeriesPerRequest = 500
+)
+
+// config contains the options for the exporter.
+type config struct {
+	endpoint               string
+	headers                map[string]string
+	client                 *http.Client
+	timeout                time.Duration
+	retry                  RetryConfig
+	maxSeriesPerRequest    int
+	namespace              string
+	withoutUnits           bool
+	withoutCounterSuffixes bool
+	disableTargetInfo      bool
+	translationStrategy    naming.Strategy
+}
+
+// newConfig creates a validated config configured with options.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		timeout:             defaultTimeout,
+		retry:               DefaultRetryConfig,
+		maxSeriesPerRequest: defaultMaxSeriesPerRequest,
+	}
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+
+	if cfg.client == nil {
+		cfg.client = http.DefaultClient
+	}
+	strategy, err := cfg.translationStrategy.Resolve()
+	if err != nil {
+		global.Error(err, "using the default translation strategy")
+	}
+	cfg.translationStrategy = strategy
+	if cfg.namespace != "" {
+		if cfg.translationStrategy.EscapeNames() {
+			cfg.namespace = naming.SanitizeName(cfg.namespace)
+		}
+		if !strings.HasSuffix(cfg.namespace, "_") {
+			cfg.namespace += "_"
+		}
+	}
+	return cfg
+}
+
+// Option sets exporter option values.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithEndpoint sets the URL of the remote-write endpoint the metrics are sent
+// to, e.g. "http://localhost:9090/api/v1/write". It is required.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.endpoint = endpoint
+		return cfg
+	})
+}
+
+// WithHeaders sets additional HTTP headers sent with each request, e.g. the
+// tenant header of a multi-tenant endpoint.
+func WithHeaders(headers map[string]string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.headers = headers
+		return cfg
+	})
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests. It allows
+// configuring the transport, e.g. TLS or a proxy.
+//
+// By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.client = client
+		return cfg
+	})
+}
+
+// WithTimeout sets the maximum amount of time an export, including its
+// retries, can take.
+//
+// By default, the timeout is 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.timeout = timeout
+		return cfg
+	})
+}
+
+// WithRetry sets the retry policy of the requests failing with a transient
+// error, i.e. a 429 or 5xx status code or a network error.
+//
+// By default, DefaultRetryConfig is used.
+func WithRetry(rc RetryConfig) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.retry = rc
+		return cfg
+	})
+}
+
+// WithMaxSeriesPerRequest sets the maximum number of time series sent in a
+// single request. The time series of an export exceeding it are split into
+// several requests. A value less than or equal to zero disables batching.
+//
+// By default, up to 500 time series are sent per request.
+func WithMaxSeriesPerRequest(n int) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.maxSeriesPerRequest = n
+		return cfg
+	})
+}
+
+// WithNamespace configures the exporter to prefix the metric names with the
+// namespace. The target_info metric is not prefixed.
+func WithNamespace(ns string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.namespace = ns
+		return cfg
+	})
+}
+
+// WithoutUnits disables the unit suffixes of the metric names.
+//
+// By default, metric names include a unit suffix to follow Prometheus naming
+// conventions. For example, the counter metric request.duration, with unit
+// milliseconds would become request_duration_milliseconds_total.
+func WithoutUnits() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.withoutUnits = true
+		return cfg
+	})
+}
+
+// WithoutCounterSuffixes disables the _total suffix of the counter names.
+func WithoutCounterSuffixes() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.withoutCounterSuffixes = true
+		return cfg
+	})
+}
+
+// WithTranslationStrategy configures how the exporter translates the
+// OpenTelemetry metric and attribute names into Prometheus metric and label
+// names, including the namespace configured with WithNamespace. The
+// strategies follow the same rules as the ones of the
+// go.opentelemetry.io/otel/exporters/prometheus exporter.
+//
+// By default, if this option is not used or the strategy is unknown, the
+// default strategy of the go.opentelemetry.io/otel/exporters/prometheus
+// exporter is used. An unknown strategy is reported to the global error
+// handler.
+func WithTranslationStrategy(strategy prometheus.TranslationStrategy) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.translationStrategy = naming.Strategy(strategy)
+		return cfg
+	})
+}
+
+// WithoutTargetInfo disables the target_info metric holding the resource
+// attributes.
+func WithoutTargetInfo() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.disableTargetInfo = true
+		return cfg
+	})
+}
+
+// RetryConfig defines the retry policy of the requests failing with a
+// transient error using an exponential backoff. The delay of a Retry-After
+// header sent by the endpoint is honored.
+//
+// If retries are enabled and InitialInterval or MaxInterval is zero, the value
+// of DefaultRetryConfig is used instead.
+type RetryConfig retry.Config
+
+// DefaultRetryConfig is the default retry policy.
+var DefaultRetryConfig = RetryConfig(retry.DefaultConfig)
+
+// config returns the retry configuration of rc, with the zero intervals
+// replaced by the ones of DefaultRetryConfig so failed requests are not
+// retried back-to-back.
+func (rc RetryConfig) config() retry.Config {
+	c := retry.Config(rc)
+	if c.InitialInterval <= 0 {
+		c.InitialInterval = DefaultRetryConfig.InitialInterval
+	}
+	if c.MaxInterval <= 0 {
+		c.MaxInterval = DefaultRetryConfig.MaxInterval
+	}
+	return c
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package remotewrite provides a metric exporter sending metrics to a
+// Prometheus remote-write endpoint, such as Prometheus, Mimir, Thanos or
+// Cortex.
+//
+// The metrics are sent using the [remote-write 1.0] protocol: the time series
+// are encoded in a protobuf WriteRequest compressed with snappy. The metric
+// and label names are translated with the same strategies as the
+// go.opentelemetry.io/otel/exporters/prometheus exporter, see
+// WithTranslationStrategy.
+//
+// Only the cumulative temporality is supported. Sums and histograms with a
+// delta temporality, as well as exponential histograms, are dropped and
+// reported as an error by the Export method.
+//
+// [remote-write 1.0]: https://prometheus.io/docs/concepts/remote_write_spec/
+package remotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/remotewrite"
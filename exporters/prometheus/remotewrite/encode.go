@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/remotewrite"
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The field numbers of the remote-write 1.0 protobuf messages.
+const (
+	writeRequestTimeseries = 1
+	writeRequestMetadata   = 3
+
+	timeSeriesLabels  = 1
+	timeSeriesSamples = 2
+
+	labelName  = 1
+	labelValue = 2
+
+	sampleValue     = 1
+	sampleTimestamp = 2
+
+	metadataType   = 1
+	metadataFamily = 2
+	metadataHelp   = 4
+	metadataUnit   = 5
+)
+
+// encodeWriteRequest returns the protobuf encoding of the remote-write
+// WriteRequest holding series and md.
+func encodeWriteRequest(series []timeSeries, md []metadata) []byte {
+	var b []byte
+	for _, ts := range series {
+		b = protowire.AppendTag(b, writeRequestTimeseries, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeTimeSeries(ts))
+	}
+	for _, m := range md {
+		b = protowire.AppendTag(b, writeRequestMetadata, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeMetadata(m))
+	}
+	return b
+}
+
+func encodeTimeSeries(ts timeSeries) []byte {
+	var b []byte
+	for _, l := range ts.labels {
+		var lb []byte
+		lb = appendString(lb, labelName, l.name)
+		lb = appendString(lb, labelValue, l.value)
+		b = protowire.AppendTag(b, timeSeriesLabels, protowire.BytesType)
+		b = protowire.AppendBytes(b, lb)
+	}
+	for _, s := range ts.samples {
+		var sb []byte
+		sb = protowire.AppendTag(sb, sampleValue, protowire.Fixed64Type)
+		sb = protowire.AppendFixed64(sb, math.Float64bits(s.value))
+		sb = protowire.AppendTag(sb, sampleTimestamp, protowire.VarintType)
+		sb = protowire.AppendVarint(sb, uint64(s.timestamp))
+		b = protowire.AppendTag(b, timeSeriesSamples, protowire.BytesType)
+		b = protowire.AppendBytes(b, sb)
+	}
+	return b
+}
+
+func encodeMetadata(m metadata) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, metadataType, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(m.typ))
+	b = appendString(b, metadataFamily, m.family)
+	b = appendString(b, metadataHelp, m.help)
+	b = appendString(b, metadataUnit, m.unit)
+	return b
+}
+
+// appendString appends the string field num to b, unless s is empty.
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
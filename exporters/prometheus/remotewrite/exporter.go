@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/remotewrite"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/naming"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/retry"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	remoteWriteVersion = "0.1.0"
+
+	// maxErrorBodySize is the maximum number of bytes of a response body
+	// included in an error.
+	maxErrorBodySize = 1024
+)
+
+var (
+	errShutdown   = errors.New("exporter is shutdown")
+	errNoEndpoint = errors.New("remote-write endpoint is not configured")
+	userAgent     = "OTel Go Prometheus remote-write exporter/" + otel.Version()
+)
+
+// Exporter is a metric exporter sending the metrics to a Prometheus
+// remote-write endpoint.
+type Exporter struct {
+	cfg config
+
+	// mu serializes the exports, the transformer is not safe for concurrent
+	// use.
+	mu          sync.Mutex
+	transformer transformer
+	now         func() time.Time
+	requestFunc retry.RequestFunc
+
+	stoppedMu sync.RWMutex
+	stopped   bool
+}
+
+var _ metric.Exporter = (*Exporter)(nil)
+
+// New returns a new Exporter sending the metrics to the remote-write endpoint
+// configured with WithEndpoint.
+func New(opts ...Option) (*Exporter, error) {
+	cfg := newConfig(opts...)
+	if cfg.endpoint == "" {
+		return nil, errNoEndpoint
+	}
+	return &Exporter{
+		cfg: cfg,
+		transformer: transformer{
+			namer: naming.Namer{
+				Namespace:              cfg.namespace,
+				Strategy:               cfg.translationStrategy,
+				WithoutUnits:           cfg.withoutUnits,
+				WithoutCounterSuffixes: cfg.withoutCounterSuffixes,
+			},
+			disableTargetInfo: cfg.disableTargetInfo,
+		},
+		now:         time.Now,
+		requestFunc: cfg.retry.config().RequestFunc(evaluate),
+	}, nil
+}
+
+// Temporality returns the cumulative temporality, the only one supported by
+// Prometheus.
+func (e *Exporter) Temporality(metric.InstrumentKind) metricdata.Temporality {
+	return metricdata.CumulativeTemporality
+}
+
+// Aggregation returns the default aggregation of the instrument kind.
+func (e *Exporter) Aggregation(k metric.InstrumentKind) metric.Aggregation {
+	return metric.DefaultAggregationSelector(k)
+}
+
+// Export sends the metrics of rm to the remote-write endpoint. The time series
+// are split into requests of at most the number of series configured with
+// WithMaxSeriesPerRequest. The metrics that cannot be converted are dropped
+// and reported in the returned error, the other ones are still sent.
+func (e *Exporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	e.stoppedMu.RLock()
+	stopped := e.stopped
+	e.stoppedMu.RUnlock()
+	if stopped {
+		return errShutdown
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	series, md, err := e.transformer.transform(rm, e.now())
+	if len(series) == 0 {
+		return err
+	}
+
+	if e.cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.cfg.timeout)
+		defer cancel()
+	}
+
+	size := e.cfg.maxSeriesPerRequest
+	if size <= 0 {
+		size = len(series)
+	}
+	for start := 0; start < len(series); start += size {
+		end := min(start+size, len(series))
+		// The metadata is only sent with the first request.
+		body := encodeWriteRequest(series[start:end], md)
+		md = nil
+		if sendErr := e.send(ctx, snappy.Encode(nil, body)); sendErr != nil {
+			return errors.Join(err, sendErr)
+		}
+	}
+	return err
+}
+
+// send sends the compressed WriteRequest body, retrying on transient errors.
+func (e *Exporter) send(ctx context.Context, body []byte) error {
+	return e.requestFunc(ctx, func(ctx context.Context) error {
+		return e.do(ctx, body)
+	})
+}
+
+// do sends a single request.
+func (e *Exporter) do(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.cfg.headers {
+		if strings.EqualFold(k, "host") {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+
+	resp, err := e.cfg.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		// Network errors are transient.
+		return &retryableError{err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		// Read the body to allow the reuse of the connection.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("remote-write request failed with status %s: %s", resp.Status, bytes.TrimSpace(msg))
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5 {
+		return &retryableError{err: err, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+	return err
+}
+
+// ForceFlush does nothing, the Exporter holds no state.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown stops the Exporter, the following exports fail.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.stoppedMu.Lock()
+	e.stopped = true
+	e.stoppedMu.Unlock()
+	return ctx.Err()
+}
+
+// MarshalLog returns logging data about the Exporter.
+func (e *Exporter) MarshalLog() interface{} {
+	return struct {
+		Type     string
+		Endpoint string
+	}{
+		Type:     "prometheus-remote-write",
+		Endpoint: e.cfg.endpoint,
+	}
+}
+
+// retryableError is a transient error of a request that can be retried.
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// evaluate returns if err is retry-able. If it is and it includes an explicit
+// throttling delay, that delay is also returned.
+func evaluate(err error) (bool, time.Duration) {
+	var rErr *retryableError
+	if !errors.As(err, &rErr) {
+		return false, 0
+	}
+	return true, rErr.retryAfter
+}
+
+// parseRetryAfter returns the delay of the Retry-After header value v, either
+// a number of seconds or an HTTP date. It returns zero if v is invalid.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if s, err := strconv.Atoi(v); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
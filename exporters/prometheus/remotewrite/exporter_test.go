@@ -0,0 +1,379 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/naming"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// writeRequest is a decoded remote-write WriteRequest.
+type writeRequest struct {
+	Series   []timeSeries
+	Metadata []metadata
+	Header   http.Header
+}
+
+// receiver is a fake remote-write endpoint.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []writeRequest
+	// statuses are the status codes of the next responses, 204 is used once
+	// they are consumed.
+	statuses []int
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if len(r.statuses) > 0 {
+			status := r.statuses[0]
+			r.statuses = r.statuses[1:]
+			if status != http.StatusNoContent {
+				w.Header().Set("Retry-After", "0")
+				http.Error(w, http.StatusText(status), status)
+				return
+			}
+		}
+
+		compressed, err := io.ReadAll(req.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		body, err := snappy.Decode(nil, compressed)
+		if !assert.NoError(t, err) {
+			return
+		}
+		wr := decodeWriteRequest(t, body)
+		wr.Header = req.Header
+		r.requests = append(r.requests, wr)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) Requests() []writeRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+func decodeWriteRequest(t *testing.T, b []byte) writeRequest {
+	var wr writeRequest
+	walk(t, b, func(num protowire.Number, v []byte, _ uint64) {
+		switch num {
+		case writeRequestTimeseries:
+			var ts timeSeries
+			walk(t, v, func(num protowire.Number, v []byte, _ uint64) {
+				switch num {
+				case timeSeriesLabels:
+					var l label
+					walk(t, v, func(num protowire.Number, v []byte, _ uint64) {
+						if num == labelName {
+							l.name = string(v)
+						} else {
+							l.value = string(v)
+						}
+					})
+					ts.labels = append(ts.labels, l)
+				case timeSeriesSamples:
+					var s sample
+					walk(t, v, func(num protowire.Number, _ []byte, n uint64) {
+						if num == sampleValue {
+							s.value = math.Float64frombits(n)
+						} else {
+							s.timestamp = int64(n)
+						}
+					})
+					ts.samples = append(ts.samples, s)
+				}
+			})
+			wr.Series = append(wr.Series, ts)
+		case writeRequestMetadata:
+			var m metadata
+			walk(t, v, func(num protowire.Number, v []byte, n uint64) {
+				switch num {
+				case metadataType:
+					m.typ = metricType(n)
+				case metadataFamily:
+					m.family = string(v)
+				case metadataHelp:
+					m.help = string(v)
+				case metadataUnit:
+					m.unit = string(v)
+				}
+			})
+			wr.Metadata = append(wr.Metadata, m)
+		}
+	})
+	return wr
+}
+
+// walk calls fn with the fields of the protobuf message b. The value of the
+// bytes fields is passed as v, the one of the numeric fields as n.
+func walk(t *testing.T, b []byte, fn func(num protowire.Number, v []byte, n uint64)) {
+	for len(b) > 0 {
+		num, typ, l := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, l, 0, "invalid tag")
+		b = b[l:]
+		switch typ {
+		case protowire.BytesType:
+			v, l := protowire.ConsumeBytes(b)
+			require.GreaterOrEqual(t, l, 0, "invalid bytes")
+			fn(num, v, 0)
+			b = b[l:]
+		case protowire.VarintType:
+			n, l := protowire.ConsumeVarint(b)
+			require.GreaterOrEqual(t, l, 0, "invalid varint")
+			fn(num, nil, n)
+			b = b[l:]
+		case protowire.Fixed64Type:
+			n, l := protowire.ConsumeFixed64(b)
+			require.GreaterOrEqual(t, l, 0, "invalid fixed64")
+			fn(num, nil, n)
+			b = b[l:]
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+	}
+}
+
+var (
+	now = time.Unix(1700000000, 0)
+
+	testResourceMetrics = &metricdata.ResourceMetrics{
+		Resource: resource.NewSchemaless(attribute.String("service.name", "svc")),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "scope"},
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "requests",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						IsMonotonic: true,
+						DataPoints: []metricdata.DataPoint[int64]{
+							{Attributes: attribute.NewSet(attribute.String("code", "200")), Time: now, Value: 3},
+							{Attributes: attribute.NewSet(attribute.String("code", "500")), Time: now, Value: 1},
+						},
+					},
+				},
+			},
+		}},
+	}
+)
+
+func TestNew(t *testing.T) {
+	_, err := New()
+	assert.ErrorIs(t, err, errNoEndpoint)
+
+	exp, err := New(WithEndpoint("http://localhost:9090/api/v1/write"))
+	require.NoError(t, err)
+	assert.Equal(t, metricdata.CumulativeTemporality, exp.Temporality(metric.InstrumentKindUpDownCounter))
+	assert.Equal(t, metric.AggregationSum{}, exp.Aggregation(metric.InstrumentKindCounter))
+}
+
+func TestTranslationStrategy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		strategy      prometheus.TranslationStrategy
+		wantStrategy  naming.Strategy
+		wantNamespace string
+	}{
+		{name: "Default", wantStrategy: naming.UnderscoreEscapingWithSuffixes, wantNamespace: "my_ns_"},
+		{name: "NoUTF8EscapingWithSuffixes", strategy: prometheus.NoUTF8EscapingWithSuffixes, wantStrategy: naming.NoUTF8EscapingWithSuffixes, wantNamespace: "my.ns_"},
+		{name: "NoTranslation", strategy: prometheus.NoTranslation, wantStrategy: naming.NoTranslation, wantNamespace: "my.ns_"},
+		{name: "Unknown", strategy: "Unknown", wantStrategy: naming.UnderscoreEscapingWithSuffixes, wantNamespace: "my_ns_"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exp, err := New(
+				WithEndpoint("http://localhost:9090/api/v1/write"),
+				WithNamespace("my.ns"),
+				WithTranslationStrategy(tc.strategy),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStrategy, exp.transformer.namer.Strategy)
+			assert.Equal(t, tc.wantNamespace, exp.transformer.namer.Namespace)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	r := newReceiver(t)
+	exp, err := New(
+		WithEndpoint(r.URL),
+		WithHeaders(map[string]string{"X-Scope-OrgID": "tenant"}),
+	)
+	require.NoError(t, err)
+	exp.now = func() time.Time { return now }
+
+	require.NoError(t, exp.Export(context.Background(), testResourceMetrics))
+
+	reqs := r.Requests()
+	require.Len(t, reqs, 1)
+	h := reqs[0].Header
+	assert.Equal(t, "snappy", h.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-protobuf", h.Get("Content-Type"))
+	assert.Equal(t, remoteWriteVersion, h.Get("X-Prometheus-Remote-Write-Version"))
+	assert.Equal(t, userAgent, h.Get("User-Agent"))
+	assert.Equal(t, "tenant", h.Get("X-Scope-OrgID"))
+
+	ms := now.UnixMilli()
+	assert.Equal(t, []timeSeries{
+		{
+			labels:  []label{{nameLabel, "target_info"}, {jobLabel, "svc"}, {"service_name", "svc"}},
+			samples: []sample{{1, ms}},
+		},
+		{
+			labels:  []label{{nameLabel, "requests_total"}, {"code", "200"}, {jobLabel, "svc"}, {scopeNameLabel, "scope"}},
+			samples: []sample{{3, ms}},
+		},
+		{
+			labels:  []label{{nameLabel, "requests_total"}, {"code", "500"}, {jobLabel, "svc"}, {scopeNameLabel, "scope"}},
+			samples: []sample{{1, ms}},
+		},
+	}, reqs[0].Series)
+	assert.Equal(t, []metadata{
+		{typ: metricTypeInfo, family: "target_info", help: targetInfoDescription},
+		{typ: metricTypeCounter, family: "requests_total"},
+	}, reqs[0].Metadata)
+}
+
+func TestExportBatching(t *testing.T) {
+	r := newReceiver(t)
+	exp, err := New(WithEndpoint(r.URL), WithMaxSeriesPerRequest(2))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Export(context.Background(), testResourceMetrics))
+
+	reqs := r.Requests()
+	require.Len(t, reqs, 2)
+	assert.Len(t, reqs[0].Series, 2)
+	assert.Len(t, reqs[0].Metadata, 2, "metadata sent with the first request")
+	assert.Len(t, reqs[1].Series, 1)
+	assert.Empty(t, reqs[1].Metadata)
+}
+
+func TestExportRetry(t *testing.T) {
+	rc := RetryConfig{
+		Enabled:         true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Minute,
+	}
+
+	t.Run("Retryable", func(t *testing.T) {
+		r := newReceiver(t, http.StatusTooManyRequests, http.StatusServiceUnavailable)
+		exp, err := New(WithEndpoint(r.URL), WithRetry(rc))
+		require.NoError(t, err)
+
+		require.NoError(t, exp.Export(context.Background(), testResourceMetrics))
+		assert.Len(t, r.Requests(), 1)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		r := newReceiver(t, http.StatusServiceUnavailable)
+		exp, err := New(WithEndpoint(r.URL), WithRetry(RetryConfig{}))
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, exp.Export(context.Background(), testResourceMetrics), "503")
+		assert.Empty(t, r.Requests())
+	})
+
+	t.Run("NonRetryable", func(t *testing.T) {
+		r := newReceiver(t, http.StatusBadRequest)
+		exp, err := New(WithEndpoint(r.URL), WithRetry(rc))
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, exp.Export(context.Background(), testResourceMetrics), "400")
+		assert.Empty(t, r.Requests())
+	})
+}
+
+func TestRetryConfigDefaultIntervals(t *testing.T) {
+	c := RetryConfig{Enabled: true, MaxElapsedTime: time.Second}.config()
+	assert.Equal(t, DefaultRetryConfig.InitialInterval, c.InitialInterval)
+	assert.Equal(t, DefaultRetryConfig.MaxInterval, c.MaxInterval)
+	assert.Equal(t, time.Second, c.MaxElapsedTime)
+
+	rc := RetryConfig{InitialInterval: time.Millisecond, MaxInterval: time.Second}
+	c = rc.config()
+	assert.Equal(t, time.Millisecond, c.InitialInterval)
+	assert.Equal(t, time.Second, c.MaxInterval)
+}
+
+func TestExportPartialError(t *testing.T) {
+	r := newReceiver(t)
+	exp, err := New(WithEndpoint(r.URL), WithoutTargetInfo())
+	require.NoError(t, err)
+
+	rm := &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "delta",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints:  []metricdata.DataPoint[int64]{{Value: 1}},
+					},
+				},
+				{
+					Name: "gauge",
+					Data: metricdata.Gauge[float64]{
+						DataPoints: []metricdata.DataPoint[float64]{{Value: 1}},
+					},
+				},
+			},
+		}},
+	}
+	assert.ErrorIs(t, exp.Export(context.Background(), rm), errDeltaTemporality)
+	reqs := r.Requests()
+	require.Len(t, reqs, 1)
+	require.Len(t, reqs[0].Series, 1)
+	assert.Equal(t, []label{{nameLabel, "gauge"}}, reqs[0].Series[0].labels)
+}
+
+func TestShutdown(t *testing.T) {
+	r := newReceiver(t)
+	exp, err := New(WithEndpoint(r.URL))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.ForceFlush(context.Background()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.ErrorIs(t, exp.Export(context.Background(), testResourceMetrics), errShutdown)
+	assert.Empty(t, r.Requests())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("invalid"))
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2"))
+	d := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.Greater(t, d, 59*time.Minute)
+}
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/remotewrite"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/naming"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	nameLabel         = "__name__"
+	jobLabel          = "job"
+	instanceLabel     = "instance"
+	bucketLabel       = "le"
+	quantileLabel     = "quantile"
+	scopeNameLabel    = "otel_scope_name"
+	scopeVersionLabel = "otel_scope_version"
+
+	targetInfoMetricName  = "target_info"
+	targetInfoDescription = "Target metadata"
+)
+
+var (
+	errDeltaTemporality = errors.New("delta temporality is not supported")
+	errUnsupportedType  = errors.New("unsupported metric type")
+)
+
+// metricType is the type of a metric family in the remote-write metadata.
+type metricType int32
+
+// The values of the MetricMetadata.MetricType enum of the remote-write
+// protocol.
+const (
+	metricTypeCounter   metricType = 1
+	metricTypeGauge     metricType = 2
+	metricTypeHistogram metricType = 3
+	metricTypeSummary   metricType = 5
+	metricTypeInfo      metricType = 6
+)
+
+type label struct {
+	name, value string
+}
+
+type sample struct {
+	value     float64
+	timestamp int64 // Milliseconds since the Unix epoch.
+}
+
+type timeSeries struct {
+	labels  []label // Sorted by name.
+	samples []sample
+}
+
+type metadata struct {
+	typ    metricType
+	family string
+	help   string
+	unit   string
+}
+
+// transformer converts metricdata into remote-write time series.
+type transformer struct {
+	namer             naming.Namer
+	disableTargetInfo bool
+
+	series   []timeSeries
+	metadata []metadata
+	errs     []error
+}
+
+// transform returns the time series and the metadata of rm, as well as the
+// joined errors of the metrics that could not be converted.
+func (t *transformer) transform(rm *metricdata.ResourceMetrics, now time.Time) ([]timeSeries, []metadata, error) {
+	t.series, t.metadata, t.errs = nil, nil, nil
+
+	resLabels := resourceLabels(rm.Resource)
+	if !t.disableTargetInfo && rm.Resource != nil && rm.Resource.Len() > 0 {
+		keys, values := t.namer.Labels(*rm.Resource.Set())
+		t.addMetadata(metricTypeInfo, targetInfoMetricName, targetInfoDescription, "")
+		t.addSeries(targetInfoMetricName, keys, values, resLabels, 1, now)
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		extra := append(scopeLabels(sm.Scope), resLabels...)
+		for _, m := range sm.Metrics {
+			t.addMetric(m, extra)
+		}
+	}
+	return t.series, t.metadata, errors.Join(t.errs...)
+}
+
+func (t *transformer) addMetric(m metricdata.Metrics, extra []label) {
+	switch v := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		addGauge(t, m, v.DataPoints, extra)
+	case metricdata.Gauge[float64]:
+		addGauge(t, m, v.DataPoints, extra)
+	case metricdata.Sum[int64]:
+		addSum(t, m, v, extra)
+	case metricdata.Sum[float64]:
+		addSum(t, m, v, extra)
+	case metricdata.Histogram[int64]:
+		addHistogram(t, m, v, extra)
+	case metricdata.Histogram[float64]:
+		addHistogram(t, m, v, extra)
+	case metricdata.Summary:
+		t.addSummary(m, v, extra)
+	default:
+		t.errs = append(t.errs, fmt.Errorf("%w: %s (%T)", errUnsupportedType, m.Name, m.Data))
+	}
+}
+
+func addGauge[N int64 | float64](t *transformer, m metricdata.Metrics, dps []metricdata.DataPoint[N], extra []label) {
+	name := t.namer.MetricName(m.Name, m.Unit, false)
+	t.addMetadata(metricTypeGauge, name, m.Description, m.Unit)
+	for _, dp := range dps {
+		keys, values := t.namer.Labels(dp.Attributes)
+		t.addSeries(name, keys, values, extra, float64(dp.Value), dp.Time)
+	}
+}
+
+func addSum[N int64 | float64](t *transformer, m metricdata.Metrics, sum metricdata.Sum[N], extra []label) {
+	if sum.Temporality == metricdata.DeltaTemporality {
+		t.errs = append(t.errs, fmt.Errorf("%w: %s", errDeltaTemporality, m.Name))
+		return
+	}
+	typ := metricTypeGauge
+	if sum.IsMonotonic {
+		typ = metricTypeCounter
+	}
+	name := t.namer.MetricName(m.Name, m.Unit, sum.IsMonotonic)
+	t.addMetadata(typ, name, m.Description, m.Unit)
+	for _, dp := range sum.DataPoints {
+		keys, values := t.namer.Labels(dp.Attributes)
+		t.addSeries(name, keys, values, extra, float64(dp.Value), dp.Time)
+	}
+}
+
+func addHistogram[N int64 | float64](t *transformer, m metricdata.Metrics, h metricdata.Histogram[N], extra []label) {
+	if h.Temporality == metricdata.DeltaTemporality {
+		t.errs = append(t.errs, fmt.Errorf("%w: %s", errDeltaTemporality, m.Name))
+		return
+	}
+	name := t.namer.MetricName(m.Name, m.Unit, false)
+	t.addMetadata(metricTypeHistogram, name, m.Description, m.Unit)
+	for _, dp := range h.DataPoints {
+		keys, values := t.namer.Labels(dp.Attributes)
+		var cumulative uint64
+		for i, bound := range dp.Bounds {
+			cumulative += dp.BucketCounts[i]
+			bucket := append(slices.Clip(extra), label{bucketLabel, formatFloat(bound)})
+			t.addSeries(name+"_bucket", keys, values, bucket, float64(cumulative), dp.Time)
+		}
+		inf := append(slices.Clip(extra), label{bucketLabel, formatFloat(math.Inf(1))})
+		t.addSeries(name+"_bucket", keys, values, inf, float64(dp.Count), dp.Time)
+		t.addSeries(name+"_sum", keys, values, extra, float64(dp.Sum), dp.Time)
+		t.addSeries(name+"_count", keys, values, extra, float64(dp.Count), dp.Time)
+	}
+}
+
+func (t *transformer) addSummary(m metricdata.Metrics, s metricdata.Summary, extra []label) {
+	name := t.namer.MetricName(m.Name, m.Unit, false)
+	t.addMetadata(metricTypeSummary, name, m.Description, m.Unit)
+	for _, dp := range s.DataPoints {
+		keys, values := t.namer.Labels(dp.Attributes)
+		for _, q := range dp.QuantileValues {
+			quantile := append(slices.Clip(extra), label{quantileLabel, formatFloat(q.Quantile)})
+			t.addSeries(name, keys, values, quantile, q.Value, dp.Time)
+		}
+		t.addSeries(name+"_sum", keys, values, extra, dp.Sum, dp.Time)
+		t.addSeries(name+"_count", keys, values, extra, float64(dp.Count), dp.Time)
+	}
+}
+
+func (t *transformer) addMetadata(typ metricType, family, help, unit string) {
+	t.metadata = append(t.metadata, metadata{typ: typ, family: family, help: help, unit: unit})
+}
+
+// addSeries adds a time series with a single sample. The extra labels take
+// precedence over the attribute labels with the same name. Labels with an
+// empty value are dropped, as Prometheus does.
+func (t *transformer) addSeries(name string, keys, values []string, extra []label, value float64, ts time.Time) {
+	labels := make([]label, 0, len(keys)+len(extra)+1)
+	labels = append(labels, label{nameLabel, name})
+	for i, k := range keys {
+		if k != nameLabel && values[i] != "" {
+			labels = append(labels, label{k, values[i]})
+		}
+	}
+	for _, l := range extra {
+		i := slices.IndexFunc(labels, func(o label) bool { return o.name == l.name })
+		switch {
+		case i >= 0 && l.value == "":
+			labels = slices.Delete(labels, i, i+1)
+		case i >= 0:
+			labels[i] = l
+		case l.value != "":
+			labels = append(labels, l)
+		}
+	}
+	slices.SortFunc(labels, func(a, b label) int { return strings.Compare(a.name, b.name) })
+
+	t.series = append(t.series, timeSeries{
+		labels:  labels,
+		samples: []sample{{value: value, timestamp: ts.UnixMilli()}},
+	})
+}
+
+// resourceLabels returns the job and instance labels identifying the resource.
+func resourceLabels(res *resource.Resource) []label {
+	if res == nil {
+		return nil
+	}
+	var labels []label
+	set := res.Set()
+	if name, ok := set.Value(semconv.ServiceNameKey); ok {
+		job := name.Emit()
+		if ns, ok := set.Value(semconv.ServiceNamespaceKey); ok {
+			job = ns.Emit() + "/" + job
+		}
+		labels = append(labels, label{jobLabel, job})
+	}
+	if id, ok := set.Value(semconv.ServiceInstanceIDKey); ok {
+		labels = append(labels, label{instanceLabel, id.Emit()})
+	}
+	return labels
+}
+
+// scopeLabels returns the labels identifying the instrumentation scope.
+func scopeLabels(scope instrumentation.Scope) []label {
+	if scope.Name == "" {
+		return nil
+	}
+	return []label{{scopeNameLabel, scope.Name}, {scopeVersionLabel, scope.Version}}
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
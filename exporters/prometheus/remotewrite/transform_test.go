@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remotewrite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/prometheus/internal/naming"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func transformMetric(t *testing.T, namer naming.Namer, m metricdata.Metrics) ([]timeSeries, []metadata, error) {
+	t.Helper()
+	tr := transformer{namer: namer, disableTargetInfo: true}
+	return tr.transform(&metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{Metrics: []metricdata.Metrics{m}}},
+	}, now)
+}
+
+func TestTransformHistogram(t *testing.T) {
+	series, md, err := transformMetric(t, naming.Namer{Strategy: naming.UnderscoreEscapingWithSuffixes}, metricdata.Metrics{
+		Name:        "http.duration",
+		Description: "Request duration",
+		Unit:        "s",
+		Data: metricdata.Histogram[float64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.HistogramDataPoint[float64]{{
+				Time:         now,
+				Count:        6,
+				Sum:          12.5,
+				Bounds:       []float64{0.5, 5},
+				BucketCounts: []uint64{1, 2, 3},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	ms := now.UnixMilli()
+	assert.Equal(t, []timeSeries{
+		{labels: []label{{nameLabel, "http_duration_seconds_bucket"}, {bucketLabel, "0.5"}}, samples: []sample{{1, ms}}},
+		{labels: []label{{nameLabel, "http_duration_seconds_bucket"}, {bucketLabel, "5"}}, samples: []sample{{3, ms}}},
+		{labels: []label{{nameLabel, "http_duration_seconds_bucket"}, {bucketLabel, "+Inf"}}, samples: []sample{{6, ms}}},
+		{labels: []label{{nameLabel, "http_duration_seconds_sum"}}, samples: []sample{{12.5, ms}}},
+		{labels: []label{{nameLabel, "http_duration_seconds_count"}}, samples: []sample{{6, ms}}},
+	}, series)
+	assert.Equal(t, []metadata{
+		{typ: metricTypeHistogram, family: "http_duration_seconds", help: "Request duration", unit: "s"},
+	}, md)
+}
+
+func TestTransformSummary(t *testing.T) {
+	series, md, err := transformMetric(t, naming.Namer{Strategy: naming.UnderscoreEscapingWithSuffixes}, metricdata.Metrics{
+		Name: "latency",
+		Data: metricdata.Summary{
+			DataPoints: []metricdata.SummaryDataPoint{{
+				Time:           now,
+				Count:          10,
+				Sum:            20,
+				QuantileValues: []metricdata.QuantileValue{{Quantile: 0.5, Value: 1}, {Quantile: 0.99, Value: 4}},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	ms := now.UnixMilli()
+	assert.Equal(t, []timeSeries{
+		{labels: []label{{nameLabel, "latency"}, {quantileLabel, "0.5"}}, samples: []sample{{1, ms}}},
+		{labels: []label{{nameLabel, "latency"}, {quantileLabel, "0.99"}}, samples: []sample{{4, ms}}},
+		{labels: []label{{nameLabel, "latency_sum"}}, samples: []sample{{20, ms}}},
+		{labels: []label{{nameLabel, "latency_count"}}, samples: []sample{{10, ms}}},
+	}, series)
+	assert.Equal(t, []metadata{{typ: metricTypeSummary, family: "latency"}}, md)
+}
+
+func TestTransformSum(t *testing.T) {
+	namer := naming.Namer{Namespace: "ns_", Strategy: naming.UnderscoreEscapingWithSuffixes}
+	series, md, err := transformMetric(t, namer, metricdata.Metrics{
+		Name: "queue.size",
+		Unit: "By",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.DataPoint[int64]{{
+				Attributes: attribute.NewSet(attribute.String("queue.name", "a"), attribute.String("empty", "")),
+				Time:       now,
+				Value:      -2,
+			}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []timeSeries{{
+		labels:  []label{{nameLabel, "ns_queue_size_bytes"}, {"queue_name", "a"}},
+		samples: []sample{{-2, now.UnixMilli()}},
+	}}, series, "non-monotonic sum exported as a gauge")
+	assert.Equal(t, []metadata{{typ: metricTypeGauge, family: "ns_queue_size_bytes", unit: "By"}}, md)
+}
+
+func TestTransformNoUTF8Escaping(t *testing.T) {
+	namer := naming.Namer{Strategy: naming.NoUTF8EscapingWithSuffixes}
+	series, md, err := transformMetric(t, namer, metricdata.Metrics{
+		Name: "queue.size",
+		Unit: "By",
+		Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{{
+			Attributes: attribute.NewSet(attribute.String("queue.name", "a")),
+			Time:       now,
+			Value:      2,
+		}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []timeSeries{{
+		labels:  []label{{nameLabel, "queue.size_bytes"}, {"queue.name", "a"}},
+		samples: []sample{{2, now.UnixMilli()}},
+	}}, series)
+	assert.Equal(t, []metadata{{typ: metricTypeGauge, family: "queue.size_bytes", unit: "By"}}, md)
+}
+
+func TestTransformUnsupported(t *testing.T) {
+	_, _, err := transformMetric(t, naming.Namer{}, metricdata.Metrics{
+		Name: "exp",
+		Data: metricdata.ExponentialHistogram[int64]{},
+	})
+	assert.ErrorIs(t, err, errUnsupportedType)
+
+	_, _, err = transformMetric(t, naming.Namer{}, metricdata.Metrics{
+		Name: "delta",
+		Data: metricdata.Histogram[int64]{Temporality: metricdata.DeltaTemporality},
+	})
+	assert.ErrorIs(t, err, errDeltaTemporality)
+}
+
+func TestTransformResourceAndScope(t *testing.T) {
+	tr := transformer{namer: naming.Namer{Strategy: naming.UnderscoreEscapingWithSuffixes}}
+	series, _, err := tr.transform(&metricdata.ResourceMetrics{
+		Resource: resource.NewSchemaless(
+			attribute.String("service.name", "svc"),
+			attribute.String("service.namespace", "ns"),
+			attribute.String("service.instance.id", "id"),
+		),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "scope", Version: "v1"},
+			Metrics: []metricdata.Metrics{{
+				Name: "gauge",
+				Data: metricdata.Gauge[int64]{DataPoints: []metricdata.DataPoint[int64]{{
+					// The labels identifying the target take precedence.
+					Attributes: attribute.NewSet(attribute.String("job", "other")),
+					Time:       now,
+					Value:      1,
+				}}},
+			}},
+		}},
+	}, now)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+
+	assert.Equal(t, []label{
+		{nameLabel, "target_info"},
+		{instanceLabel, "id"},
+		{jobLabel, "ns/svc"},
+		{"service_instance_id", "id"},
+		{"service_name", "svc"},
+		{"service_namespace", "ns"},
+	}, series[0].labels)
+	assert.Equal(t, []label{
+		{nameLabel, "gauge"},
+		{instanceLabel, "id"},
+		{jobLabel, "ns/svc"},
+		{scopeNameLabel, "scope"},
+		{scopeVersionLabel, "v1"},
+	}, series[1].labels)
+}
+
+func TestFormatFloat(t *testing.T) {
+	assert.Equal(t, "+Inf", formatFloat(math.Inf(1)))
+	assert.Equal(t, "-Inf", formatFloat(math.Inf(-1)))
+	assert.Equal(t, "0.005", formatFloat(0.005))
+	assert.Equal(t, "100", formatFloat(100))
+}
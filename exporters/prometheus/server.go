@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"go.opentelemetry.io/otel"
+)
+
+const serverPath = "/metrics"
+
+// errorLogger passes the errors of the handler to the OpenTelemetry error
+// handler.
+type errorLogger struct{}
+
+func (errorLogger) Println(v ...interface{}) {
+	otel.Handle(errors.New(fmt.Sprintln(v...)))
+}
+
+// newHandler returns an http.Handler serving the metrics of collector from a
+// dedicated registry.
+//
+// The collector is registered with a registry of its own, rather than encoded
+// directly, so the handler is the one of promhttp used to serve the
+// Registerer: it negotiates the same formats, including OpenMetrics with
+// exemplars, compresses the responses and validates the collected metrics
+// the same way. The collector is safe to be collected concurrently by both
+// registries.
+func newHandler(collector prometheus.Collector) (http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		return nil, fmt.Errorf("cannot register the collector: %w", err)
+	}
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorLog:          errorLogger{},
+		ErrorHandling:     promhttp.ContinueOnError,
+		EnableOpenMetrics: true,
+	}), nil
+}
+
+// Handler returns an http.Handler serving the metrics of the Exporter. It
+// does not depend on the Registerer the Exporter is registered with.
+//
+// The metrics are served in the Prometheus text format, or in the
+// OpenMetrics format, including exemplars, if the scraper accepts it.
+func (e *Exporter) Handler() http.Handler {
+	return e.handler
+}
+
+// serve starts an HTTP server serving the metrics of e on the listener ln.
+func (e *Exporter) serve(ln net.Listener) {
+	mux := http.NewServeMux()
+	mux.Handle(serverPath, e.handler)
+	e.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	e.listener = ln
+	go func() {
+		if err := e.server.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			otel.Handle(err)
+		}
+	}()
+}
+
+// Shutdown shuts down the Exporter and stops its HTTP server if it is
+// configured with WithServer.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	err := e.Reader.Shutdown(ctx)
+	if e.server != nil {
+		err = errors.Join(err, e.server.Shutdown(ctx))
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func get(t *testing.T, url, accept string) (string, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	require.NoError(t, err)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.Header.Get("Content-Type"), string(body)
+}
+
+func TestHandler(t *testing.T) {
+	t.Setenv("OTEL_GO_X_EXEMPLAR", "true")
+
+	// The handler does not depend on the registerer.
+	exporter, err := New(WithRegisterer(prometheus.NewRegistry()), WithoutTargetInfo(), WithoutScopeInfo())
+	require.NoError(t, err)
+	provider := metric.NewMeterProvider(metric.WithReader(exporter))
+	counter, err := provider.Meter("meter").Float64Counter("foo")
+	require.NoError(t, err)
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		SpanID:     trace.SpanID{0o1},
+		TraceID:    trace.TraceID{0o1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	counter.Add(ctx, 5, otelmetric.WithAttributes(attribute.Key("A").String("B")))
+
+	srv := httptest.NewServer(exporter.Handler())
+	t.Cleanup(srv.Close)
+
+	contentType, body := get(t, srv.URL, "")
+	assert.Contains(t, contentType, "text/plain")
+	assert.Contains(t, body, `foo_total{A="B"} 5`)
+	assert.NotContains(t, body, "trace_id")
+
+	contentType, body = get(t, srv.URL, "application/openmetrics-text; version=1.0.0")
+	assert.Contains(t, contentType, "application/openmetrics-text")
+	assert.Contains(t, body, `foo_total{A="B"} 5.0 # {`, "exemplar")
+	assert.Contains(t, body, `trace_id="01000000000000000000000000000000"`)
+	assert.Contains(t, body, `span_id="0100000000000000"`)
+	assert.Contains(t, body, "# EOF")
+}
+
+func TestHandlerAndRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	exporter, err := New(WithRegisterer(registry), WithoutTargetInfo(), WithoutScopeInfo())
+	require.NoError(t, err)
+	provider := metric.NewMeterProvider(metric.WithReader(exporter))
+	counter, err := provider.Meter("meter").Int64Counter("foo")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 3)
+
+	regSrv := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	t.Cleanup(regSrv.Close)
+	srv := httptest.NewServer(exporter.Handler())
+	t.Cleanup(srv.Close)
+
+	// Scraping one does not affect the other.
+	for i := 0; i < 2; i++ {
+		_, body := get(t, regSrv.URL, "")
+		assert.Contains(t, body, "foo_total 3")
+		_, body = get(t, srv.URL, "")
+		assert.Contains(t, body, "foo_total 3")
+	}
+
+	counter.Add(context.Background(), 2)
+	_, body := get(t, srv.URL, "")
+	assert.Contains(t, body, "foo_total 5")
+	_, body = get(t, regSrv.URL, "")
+	assert.Contains(t, body, "foo_total 5")
+}
+
+func TestServer(t *testing.T) {
+	exporter, err := New(WithRegisterer(prometheus.NewRegistry()), WithServer("localhost:0"))
+	require.NoError(t, err)
+	provider := metric.NewMeterProvider(metric.WithReader(exporter))
+	counter, err := provider.Meter("meter").Int64Counter("foo")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1)
+
+	url := "http://" + exporter.listener.Addr().String() + serverPath
+	_, body := get(t, url, "")
+	assert.Contains(t, body, "foo_total")
+	assert.Contains(t, body, targetInfoMetricName)
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+	_, err = net.Dial("tcp", exporter.listener.Addr().String())
+	assert.Error(t, err, "server stopped")
+}
+
+func TestServerListenError(t *testing.T) {
+	_, err := New(WithRegisterer(prometheus.NewRegistry()), WithServer("invalid address"))
+	assert.Error(t, err)
+}
+
+func TestServerAddrFromEnv(t *testing.T) {
+	testCases := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "Default",
+			want: "localhost:9464",
+		},
+		{
+			name: "HostAndPort",
+			env: map[string]string{
+				"OTEL_EXPORTER_PROMETHEUS_HOST": "0.0.0.0",
+				"OTEL_EXPORTER_PROMETHEUS_PORT": "8080",
+			},
+			want: "0.0.0.0:8080",
+		},
+		{
+			name: "IPv6",
+			env:  map[string]string{"OTEL_EXPORTER_PROMETHEUS_HOST": "::1"},
+			want: "[::1]:9464",
+		},
+		{
+			name: "InvalidPort",
+			env:  map[string]string{"OTEL_EXPORTER_PROMETHEUS_PORT": "invalid"},
+			want: "localhost:9464",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			assert.Equal(t, tc.want, serverAddrFromEnv())
+			assert.Equal(t, tc.want, newConfig(WithServer("")).serverAddr)
+		})
+	}
+}
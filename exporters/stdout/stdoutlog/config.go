@@ -12,6 +12,8 @@ var (
 	defaultWriter      io.Writer = os.Stdout
 	defaultPrettyPrint           = false
 	defaultTimestamps            = true
+	defaultFormat                = FormatJSON
+	defaultColors                = true
 )
 
 // config contains options for the STDOUT exporter.
@@ -26,6 +28,13 @@ type config struct {
 	// Timestamps specifies if timestamps should be printed. Default is
 	// true.
 	Timestamps bool
+
+	// Format is the encoding format of the output. Default is FormatJSON.
+	Format Format
+
+	// Colors specifies if FormatConsole output should be colored. Default
+	// is true.
+	Colors bool
 }
 
 // newConfig creates a validated Config configured with options.
@@ -34,6 +43,8 @@ func newConfig(options []Option) config {
 		Writer:      defaultWriter,
 		PrettyPrint: defaultPrettyPrint,
 		Timestamps:  defaultTimestamps,
+		Format:      defaultFormat,
+		Colors:      defaultColors,
 	}
 	for _, opt := range options {
 		cfg = opt.apply(cfg)
@@ -83,3 +94,29 @@ func (o timestampsOption) apply(cfg config) config {
 	cfg.Timestamps = bool(o)
 	return cfg
 }
+
+// WithFormat sets the encoding format of the emitted output.
+//
+// By default, if this option is not used, FormatJSON is used.
+func WithFormat(f Format) Option {
+	return formatOption(f)
+}
+
+type formatOption Format
+
+func (o formatOption) apply(cfg config) config {
+	cfg.Format = Format(o)
+	return cfg
+}
+
+// WithoutColors disables ANSI colors in the FormatConsole output.
+func WithoutColors() Option {
+	return colorsOption(false)
+}
+
+type colorsOption bool
+
+func (o colorsOption) apply(cfg config) config {
+	cfg.Colors = bool(o)
+	return cfg
+}
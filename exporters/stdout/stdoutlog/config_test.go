@@ -22,6 +22,8 @@ func TestNewConfig(t *testing.T) {
 				Writer:      os.Stdout,
 				PrettyPrint: false,
 				Timestamps:  true,
+				Format:      FormatJSON,
+				Colors:      true,
 			},
 		},
 		{
@@ -31,6 +33,8 @@ func TestNewConfig(t *testing.T) {
 				Writer:      os.Stderr,
 				PrettyPrint: false,
 				Timestamps:  true,
+				Format:      FormatJSON,
+				Colors:      true,
 			},
 		},
 		{
@@ -40,6 +44,8 @@ func TestNewConfig(t *testing.T) {
 				Writer:      os.Stdout,
 				PrettyPrint: true,
 				Timestamps:  true,
+				Format:      FormatJSON,
+				Colors:      true,
 			},
 		},
 		{
@@ -49,6 +55,30 @@ func TestNewConfig(t *testing.T) {
 				Writer:      os.Stdout,
 				PrettyPrint: false,
 				Timestamps:  false,
+				Format:      FormatJSON,
+				Colors:      true,
+			},
+		},
+		{
+			name:    "WithFormat",
+			options: []Option{WithFormat(FormatLogfmt)},
+			expected: config{
+				Writer:      os.Stdout,
+				PrettyPrint: false,
+				Timestamps:  true,
+				Format:      FormatLogfmt,
+				Colors:      true,
+			},
+		},
+		{
+			name:    "WithoutColors",
+			options: []Option{WithFormat(FormatConsole), WithoutColors()},
+			expected: config{
+				Writer:      os.Stdout,
+				PrettyPrint: false,
+				Timestamps:  true,
+				Format:      FormatConsole,
+				Colors:      false,
 			},
 		},
 	}
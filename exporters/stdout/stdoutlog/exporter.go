@@ -6,6 +6,7 @@ package stdoutlog // import "go.opentelemetry.io/otel/exporters/stdout/stdoutlog
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"sync/atomic"
 
 	"go.opentelemetry.io/otel/sdk/log"
@@ -13,13 +14,22 @@ import (
 
 var _ log.Exporter = &Exporter{}
 
-// Exporter writes JSON-encoded log records to an [io.Writer] ([os.Stdout] by default).
+// Exporter writes encoded log records to an [io.Writer] ([os.Stdout] by default).
+// The records are JSON-encoded unless another [Format] is used.
 // Exporter must be created with [New].
 type Exporter struct {
-	encoder    atomic.Pointer[json.Encoder]
+	encoder    atomic.Pointer[encoder]
 	timestamps bool
 }
 
+// encoder writes log records to an io.Writer in a Format.
+type encoder struct {
+	format Format
+	w      io.Writer
+	json   *json.Encoder
+	colors bool
+}
+
 // New creates an [Exporter].
 func New(options ...Option) (*Exporter, error) {
 	cfg := newConfig(options)
@@ -32,7 +42,12 @@ func New(options ...Option) (*Exporter, error) {
 	e := Exporter{
 		timestamps: cfg.Timestamps,
 	}
-	e.encoder.Store(enc)
+	e.encoder.Store(&encoder{
+		format: cfg.Format,
+		w:      cfg.Writer,
+		json:   enc,
+		colors: cfg.Colors,
+	})
 
 	return &e, nil
 }
@@ -51,8 +66,7 @@ func (e *Exporter) Export(ctx context.Context, records []log.Record) error {
 		}
 
 		// Encode record, one by one.
-		recordJSON := e.newRecordJSON(record)
-		if err := enc.Encode(recordJSON); err != nil {
+		if err := e.encode(enc, record); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,466 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdoutlog // import "go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// Format is the encoding format of the records written by an Exporter.
+type Format uint8
+
+const (
+	// FormatJSON encodes each record as a JSON object describing all of
+	// its fields, including its Resource and instrumentation scope. This is
+	// the default format.
+	FormatJSON Format = iota
+	// FormatLogfmt encodes each record as a single line of logfmt key=value
+	// pairs.
+	FormatLogfmt
+	// FormatConsole encodes each record as a single human-readable line
+	// starting with the timestamp and severity, followed by the body and the
+	// attributes. The severity is colored using ANSI escape codes unless the
+	// WithoutColors option is used.
+	FormatConsole
+	// FormatECS encodes each record as a single line JSON object using the
+	// Elastic Common Schema (ECS) field names.
+	FormatECS
+	// FormatGCP encodes each record as a single line JSON object using the
+	// field names of the Google Cloud structured logging agent.
+	FormatGCP
+)
+
+// String returns the name of the Format.
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatLogfmt:
+		return "logfmt"
+	case FormatConsole:
+		return "console"
+	case FormatECS:
+		return "ecs"
+	case FormatGCP:
+		return "gcp"
+	default:
+		return "Format(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+// encode writes r to the writer of enc in the format of enc.
+func (e *Exporter) encode(enc *encoder, r sdklog.Record) error {
+	var buf bytes.Buffer
+	switch enc.format {
+	case FormatJSON:
+		return enc.json.Encode(e.newRecordJSON(r))
+	case FormatLogfmt:
+		e.encodeLogfmt(&buf, r)
+	case FormatConsole:
+		e.encodeConsole(&buf, r, enc.colors)
+	case FormatECS:
+		if err := e.encodeECS(&buf, r); err != nil {
+			return err
+		}
+	case FormatGCP:
+		if err := e.encodeGCP(&buf, r); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format: %s", enc.format)
+	}
+	buf.WriteByte('\n')
+	_, err := enc.w.Write(buf.Bytes())
+	return err
+}
+
+func (e *Exporter) encodeLogfmt(buf *bytes.Buffer, r sdklog.Record) {
+	w := logfmtWriter{buf: buf}
+	if e.timestamps {
+		w.add("time", timestamp(r).Format(time.RFC3339Nano))
+	}
+	w.add("level", severity(r))
+	w.add("msg", valueString(r.Body()))
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		w.add(kv.Key, valueString(kv.Value))
+		return true
+	})
+	if r.TraceID().IsValid() {
+		w.add("trace_id", r.TraceID().String())
+	}
+	if r.SpanID().IsValid() {
+		w.add("span_id", r.SpanID().String())
+	}
+	if s := r.InstrumentationScope(); s.Name != "" {
+		w.add("scope", s.Name)
+	}
+}
+
+// logfmtWriter writes logfmt key=value pairs to buf.
+type logfmtWriter struct {
+	buf *bytes.Buffer
+}
+
+func (w logfmtWriter) add(key, value string) {
+	if w.buf.Len() > 0 {
+		w.buf.WriteByte(' ')
+	}
+	w.buf.WriteString(logfmtKey(key))
+	w.buf.WriteByte('=')
+	w.buf.WriteString(logfmtValue(value))
+}
+
+// logfmtKey returns key with all characters not allowed in a logfmt key
+// replaced with an underscore.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// logfmtValue returns value quoted if it is empty or contains characters
+// that are not allowed in an unquoted logfmt value.
+func logfmtValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if strings.IndexFunc(value, needsQuote) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func needsQuote(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r)
+}
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiGray    = "\x1b[90m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+)
+
+// severityColor returns the ANSI color code used for s.
+func severityColor(s log.Severity) string {
+	switch {
+	case s >= log.SeverityFatal1:
+		return ansiMagenta
+	case s >= log.SeverityError1:
+		return ansiRed
+	case s >= log.SeverityWarn1:
+		return ansiYellow
+	case s >= log.SeverityInfo1:
+		return ansiGreen
+	case s >= log.SeverityDebug1:
+		return ansiBlue
+	default:
+		return ansiGray
+	}
+}
+
+func (e *Exporter) encodeConsole(buf *bytes.Buffer, r sdklog.Record, colors bool) {
+	if e.timestamps {
+		buf.WriteString(timestamp(r).Format("2006-01-02T15:04:05.000Z07:00"))
+		buf.WriteByte(' ')
+	}
+
+	// Pad the severity to align the body of common severities.
+	sev := fmt.Sprintf("%-5s", severity(r))
+	if colors {
+		buf.WriteString(severityColor(r.Severity()))
+		buf.WriteString(sev)
+		buf.WriteString(ansiReset)
+	} else {
+		buf.WriteString(sev)
+	}
+
+	if s := r.InstrumentationScope(); s.Name != "" {
+		buf.WriteString(" [")
+		buf.WriteString(s.Name)
+		buf.WriteByte(']')
+	}
+
+	// Keep the output on a single line.
+	body := strings.NewReplacer("\r", `\r`, "\n", `\n`).Replace(valueString(r.Body()))
+	buf.WriteByte(' ')
+	buf.WriteString(body)
+
+	w := logfmtWriter{buf: buf}
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		w.add(kv.Key, valueString(kv.Value))
+		return true
+	})
+	if r.TraceID().IsValid() {
+		w.add("trace_id", r.TraceID().String())
+	}
+	if r.SpanID().IsValid() {
+		w.add("span_id", r.SpanID().String())
+	}
+}
+
+// ecsVersion is the version of the Elastic Common Schema used by FormatECS.
+const ecsVersion = "8.11.0"
+
+func (e *Exporter) encodeECS(buf *bytes.Buffer, r sdklog.Record) error {
+	w := newJSONObjectWriter(buf)
+	if e.timestamps {
+		w.add("@timestamp", timestamp(r).Format(time.RFC3339Nano))
+	}
+	w.add("log.level", strings.ToLower(severity(r)))
+	w.add("message", bodyValue(r.Body()))
+	w.add("ecs.version", ecsVersion)
+	if s := r.InstrumentationScope(); s.Name != "" {
+		w.add("log.logger", s.Name)
+	}
+	if r.TraceID().IsValid() {
+		w.add("trace.id", r.TraceID().String())
+	}
+	if r.SpanID().IsValid() {
+		w.add("span.id", r.SpanID().String())
+	}
+	// Resource attributes, like service.name and host.name, use the same
+	// dotted names as the ECS fields they map to.
+	res := r.Resource()
+	addResource(w, &res)
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		w.add(kv.Key, valueToAny(kv.Value))
+		return true
+	})
+	return w.close()
+}
+
+// gcpSeverity returns the Google Cloud LogSeverity name for s.
+func gcpSeverity(s log.Severity) string {
+	switch {
+	case s >= log.SeverityFatal4:
+		return "EMERGENCY"
+	case s >= log.SeverityFatal3:
+		return "ALERT"
+	case s >= log.SeverityFatal1:
+		return "CRITICAL"
+	case s >= log.SeverityError1:
+		return "ERROR"
+	case s >= log.SeverityWarn1:
+		return "WARNING"
+	case s >= log.SeverityInfo2:
+		return "NOTICE"
+	case s >= log.SeverityInfo1:
+		return "INFO"
+	case s >= log.SeverityTrace1:
+		return "DEBUG"
+	default:
+		return "DEFAULT"
+	}
+}
+
+func (e *Exporter) encodeGCP(buf *bytes.Buffer, r sdklog.Record) error {
+	w := newJSONObjectWriter(buf)
+	if e.timestamps {
+		w.add("timestamp", timestamp(r).Format(time.RFC3339Nano))
+	}
+	w.add("severity", gcpSeverity(r.Severity()))
+	w.add("message", bodyValue(r.Body()))
+	if r.TraceID().IsValid() {
+		w.add("logging.googleapis.com/trace", r.TraceID().String())
+	}
+	if r.SpanID().IsValid() {
+		w.add("logging.googleapis.com/spanId", r.SpanID().String())
+	}
+	if r.TraceID().IsValid() {
+		w.add("logging.googleapis.com/trace_sampled", r.TraceFlags().IsSampled())
+	}
+
+	// Labels are indexed by Cloud Logging and need to be string values.
+	labels := make(map[string]string)
+	if s := r.InstrumentationScope(); s.Name != "" {
+		labels["otel.scope.name"] = s.Name
+	}
+	res := r.Resource()
+	for iter := res.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		labels[string(kv.Key)] = kv.Value.Emit()
+	}
+	if len(labels) > 0 {
+		w.add("logging.googleapis.com/labels", labels)
+	}
+
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		w.add(kv.Key, valueToAny(kv.Value))
+		return true
+	})
+	return w.close()
+}
+
+// jsonObjectWriter writes a single JSON object to buf with its members in
+// the order they are added. Members with a key already added are ignored.
+type jsonObjectWriter struct {
+	buf  *bytes.Buffer
+	seen map[string]struct{}
+	err  error
+}
+
+func newJSONObjectWriter(buf *bytes.Buffer) *jsonObjectWriter {
+	buf.WriteByte('{')
+	return &jsonObjectWriter{buf: buf, seen: make(map[string]struct{})}
+}
+
+func (w *jsonObjectWriter) add(key string, value any) {
+	if w.err != nil {
+		return
+	}
+	if _, ok := w.seen[key]; ok {
+		return
+	}
+
+	k, err := json.Marshal(key)
+	if err != nil {
+		w.err = err
+		return
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		w.err = err
+		return
+	}
+
+	if len(w.seen) > 0 {
+		w.buf.WriteByte(',')
+	}
+	w.seen[key] = struct{}{}
+	w.buf.Write(k)
+	w.buf.WriteByte(':')
+	w.buf.Write(v)
+}
+
+func (w *jsonObjectWriter) close() error {
+	w.buf.WriteByte('}')
+	return w.err
+}
+
+func addResource(w *jsonObjectWriter, res *resource.Resource) {
+	for iter := res.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		w.add(string(kv.Key), attributeToAny(kv.Value))
+	}
+}
+
+// timestamp returns the timestamp of r, or its observed timestamp if unset.
+func timestamp(r sdklog.Record) time.Time {
+	if ts := r.Timestamp(); !ts.IsZero() {
+		return ts
+	}
+	return r.ObservedTimestamp()
+}
+
+// severity returns the severity text of r, or the name of its severity
+// number if unset.
+func severity(r sdklog.Record) string {
+	if txt := r.SeverityText(); txt != "" {
+		return txt
+	}
+	return r.Severity().String()
+}
+
+// bodyValue returns the body v as a string if it is a string value.
+// Otherwise, the native Go representation of v is returned.
+func bodyValue(v log.Value) any {
+	if v.Kind() == log.KindString {
+		return v.AsString()
+	}
+	return valueToAny(v)
+}
+
+// valueString returns the string representation of v. String values are
+// returned as is. Maps and slices are returned JSON-encoded.
+func valueString(v log.Value) string {
+	switch v.Kind() {
+	case log.KindString:
+		return v.AsString()
+	case log.KindMap, log.KindSlice, log.KindBytes:
+		b, err := json.Marshal(valueToAny(v))
+		if err != nil {
+			return v.String()
+		}
+		return string(b)
+	case log.KindEmpty:
+		return ""
+	default:
+		return v.String()
+	}
+}
+
+// valueToAny returns the native Go representation of v.
+func valueToAny(v log.Value) any {
+	switch v.Kind() {
+	case log.KindString:
+		return v.AsString()
+	case log.KindInt64:
+		return v.AsInt64()
+	case log.KindFloat64:
+		return jsonFloat(v.AsFloat64())
+	case log.KindBool:
+		return v.AsBool()
+	case log.KindBytes:
+		return v.AsBytes()
+	case log.KindMap:
+		kvs := v.AsMap()
+		m := make(map[string]any, len(kvs))
+		for _, kv := range kvs {
+			m[kv.Key] = valueToAny(kv.Value)
+		}
+		return m
+	case log.KindSlice:
+		vals := v.AsSlice()
+		s := make([]any, 0, len(vals))
+		for _, e := range vals {
+			s = append(s, valueToAny(e))
+		}
+		return s
+	default:
+		return nil
+	}
+}
+
+// attributeToAny returns the native Go representation of v.
+func attributeToAny(v attribute.Value) any {
+	if v.Type() == attribute.FLOAT64 {
+		return jsonFloat(v.AsFloat64())
+	}
+	return v.AsInterface()
+}
+
+// jsonFloat is a float64 that is JSON-encoded as a string if it is not a
+// finite number, which JSON does not support.
+type jsonFloat float64
+
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return json.Marshal(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return json.Marshal(v)
+}
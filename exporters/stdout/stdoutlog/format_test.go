@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdoutlog // import "go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+)
+
+func TestExporterFormat(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 30, 15, 123456789, time.UTC)
+	record := getRecord(now)
+
+	testCases := []struct {
+		name    string
+		options []Option
+		want    string
+	}{
+		{
+			name:    "Logfmt",
+			options: []Option{WithFormat(FormatLogfmt)},
+			want:    "time=2024-07-01T12:30:15.123456789Z level=INFO msg=test key=value key2=value key3=value key4=value key5=value bool=true trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708 scope=name\n",
+		},
+		{
+			name:    "LogfmtWithoutTimestamps",
+			options: []Option{WithFormat(FormatLogfmt), WithoutTimestamps()},
+			want:    "level=INFO msg=test key=value key2=value key3=value key4=value key5=value bool=true trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708 scope=name\n",
+		},
+		{
+			name:    "Console",
+			options: []Option{WithFormat(FormatConsole)},
+			want:    "2024-07-01T12:30:15.123Z \x1b[32mINFO \x1b[0m [name] test key=value key2=value key3=value key4=value key5=value bool=true trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708\n",
+		},
+		{
+			name:    "ConsoleWithoutColors",
+			options: []Option{WithFormat(FormatConsole), WithoutColors()},
+			want:    "2024-07-01T12:30:15.123Z INFO  [name] test key=value key2=value key3=value key4=value key5=value bool=true trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708\n",
+		},
+		{
+			name:    "ECS",
+			options: []Option{WithFormat(FormatECS)},
+			want:    `{"@timestamp":"2024-07-01T12:30:15.123456789Z","log.level":"info","message":"test","ecs.version":"8.11.0","log.logger":"name","trace.id":"0102030405060708090a0b0c0d0e0f10","span.id":"0102030405060708","foo":"bar","key":"value","key2":"value","key3":"value","key4":"value","key5":"value","bool":true}` + "\n",
+		},
+		{
+			name:    "GCP",
+			options: []Option{WithFormat(FormatGCP)},
+			want:    `{"timestamp":"2024-07-01T12:30:15.123456789Z","severity":"INFO","message":"test","logging.googleapis.com/trace":"0102030405060708090a0b0c0d0e0f10","logging.googleapis.com/spanId":"0102030405060708","logging.googleapis.com/trace_sampled":true,"logging.googleapis.com/labels":{"foo":"bar","otel.scope.name":"name"},"key":"value","key2":"value","key3":"value","key4":"value","key5":"value","bool":true}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			exporter, err := New(append(tc.options, WithWriter(&buf))...)
+			require.NoError(t, err)
+
+			err = exporter.Export(context.Background(), []sdklog.Record{record})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestExporterFormatValues(t *testing.T) {
+	rf := logtest.RecordFactory{
+		Severity: log.SeverityWarn1,
+		Body:     log.StringValue("multi\nline message"),
+		Attributes: []log.KeyValue{
+			log.String("empty", ""),
+			log.String("with space", "a b"),
+			log.Float64("nan", math.NaN()),
+			log.Slice("slice", log.Int64Value(1), log.StringValue("two")),
+			log.Map("map", log.Bool("b", false)),
+		},
+	}
+	record := rf.NewRecord()
+
+	testCases := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "Logfmt",
+			format: FormatLogfmt,
+			want:   `level=WARN msg="multi\nline message" empty="" with_space="a b" nan=NaN slice="[1,\"two\"]" map="{\"b\":false}"` + "\n",
+		},
+		{
+			name:   "Console",
+			format: FormatConsole,
+			want:   `WARN  multi\nline message empty="" with_space="a b" nan=NaN slice="[1,\"two\"]" map="{\"b\":false}"` + "\n",
+		},
+		{
+			name:   "ECS",
+			format: FormatECS,
+			want:   `{"log.level":"warn","message":"multi\nline message","ecs.version":"8.11.0","empty":"","with space":"a b","nan":"NaN","slice":[1,"two"],"map":{"b":false}}` + "\n",
+		},
+		{
+			name:   "GCP",
+			format: FormatGCP,
+			want:   `{"severity":"WARNING","message":"multi\nline message","empty":"","with space":"a b","nan":"NaN","slice":[1,"two"],"map":{"b":false}}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			exporter, err := New(
+				WithWriter(&buf),
+				WithFormat(tc.format),
+				WithoutTimestamps(),
+				WithoutColors(),
+			)
+			require.NoError(t, err)
+
+			err = exporter.Export(context.Background(), []sdklog.Record{record})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestGCPSeverity(t *testing.T) {
+	assert.Equal(t, "DEFAULT", gcpSeverity(log.SeverityUndefined))
+	assert.Equal(t, "DEBUG", gcpSeverity(log.SeverityTrace))
+	assert.Equal(t, "DEBUG", gcpSeverity(log.SeverityDebug))
+	assert.Equal(t, "INFO", gcpSeverity(log.SeverityInfo))
+	assert.Equal(t, "NOTICE", gcpSeverity(log.SeverityInfo2))
+	assert.Equal(t, "WARNING", gcpSeverity(log.SeverityWarn))
+	assert.Equal(t, "ERROR", gcpSeverity(log.SeverityError))
+	assert.Equal(t, "CRITICAL", gcpSeverity(log.SeverityFatal))
+	assert.Equal(t, "ALERT", gcpSeverity(log.SeverityFatal3))
+	assert.Equal(t, "EMERGENCY", gcpSeverity(log.SeverityFatal4))
+}
@@ -9,4 +9,9 @@
 // format for OpenTelemetry that is supported with any stability or
 // compatibility guarantees. If these are needed features, please use the OTLP
 // exporter instead.
+//
+// By default, metrics are written as JSON. Use [WithEncoder] with
+// [NewTextEncoder] to write them as a human-readable table, or with
+// [NewPrometheusEncoder] to write them in the Prometheus text exposition
+// format.
 package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
@@ -0,0 +1,330 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	counterSuffix         = "_total"
+	targetInfoMetricName  = "target_info"
+	targetInfoDescription = "Target metadata"
+)
+
+var (
+	errExponentialHistogram = errors.New("exponential histograms cannot be encoded in the Prometheus text format")
+	errTypeConflict         = errors.New("metric type conflict")
+)
+
+// unitSuffixes are the Prometheus suffixes of the units, the same as the
+// ones of the go.opentelemetry.io/otel/exporters/prometheus exporter.
+var unitSuffixes = map[string]string{
+	// Time
+	"d":   "_days",
+	"h":   "_hours",
+	"min": "_minutes",
+	"s":   "_seconds",
+	"ms":  "_milliseconds",
+	"us":  "_microseconds",
+	"ns":  "_nanoseconds",
+
+	// Bytes
+	"By":   "_bytes",
+	"KiBy": "_kibibytes",
+	"MiBy": "_mebibytes",
+	"GiBy": "_gibibytes",
+	"TiBy": "_tibibytes",
+	"KBy":  "_kilobytes",
+	"MBy":  "_megabytes",
+	"GBy":  "_gigabytes",
+	"TBy":  "_terabytes",
+
+	// SI
+	"m": "_meters",
+	"V": "_volts",
+	"A": "_amperes",
+	"J": "_joules",
+	"W": "_watts",
+	"g": "_grams",
+
+	// Misc
+	"Cel": "_celsius",
+	"Hz":  "_hertz",
+	"1":   "_ratio",
+	"%":   "_percent",
+}
+
+// prometheusEncoder encodes metric data in the Prometheus text exposition
+// format.
+type prometheusEncoder struct {
+	w io.Writer
+}
+
+// NewPrometheusEncoder returns an Encoder writing the metric data to w in the
+// Prometheus text exposition format, without depending on the Prometheus
+// client library. The metric and label names are translated following the
+// same rules as the go.opentelemetry.io/otel/exporters/prometheus exporter:
+// invalid characters are replaced by underscores, unit suffixes are added,
+// and monotonic cumulative sums are counters with a _total suffix. The
+// resource is encoded as a target_info metric, the instrumentation scope as
+// otel_scope_name and otel_scope_version labels.
+//
+// Exponential histograms, which have no text representation, are skipped and
+// reported in the returned error. So are the metrics with the same name as a
+// previous metric of a different type. The Encoder only encodes
+// *metricdata.ResourceMetrics values.
+func NewPrometheusEncoder(w io.Writer) Encoder {
+	return &prometheusEncoder{w: w}
+}
+
+// family is a Prometheus metric family.
+type family struct {
+	name, help, typ string
+	lines           []string
+}
+
+// Encode writes the metric families of v.
+func (e *prometheusEncoder) Encode(v any) error {
+	rm, ok := v.(*metricdata.ResourceMetrics)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnsupportedValue, v)
+	}
+
+	var (
+		families []*family
+		errs     []error
+	)
+	// Metrics with the same name in several scopes belong to the same family.
+	// The data points of a metric conflicting with the type of the family
+	// are dropped, the family keeps its existing type.
+	get := func(name, help, typ string) *family {
+		for _, f := range families {
+			if f.name != name {
+				continue
+			}
+			if f.typ != typ {
+				errs = append(errs, fmt.Errorf("%w: %s: using existing type %s, dropped %s", errTypeConflict, name, f.typ, typ))
+				return &family{name: name, help: help, typ: typ}
+			}
+			return f
+		}
+		f := &family{name: name, help: help, typ: typ}
+		families = append(families, f)
+		return f
+	}
+
+	if rm.Resource != nil && rm.Resource.Len() > 0 {
+		f := get(targetInfoMetricName, targetInfoDescription, "gauge")
+		f.lines = append(f.lines, promSample(targetInfoMetricName, promLabels(*rm.Resource.Set(), instrumentation.Scope{}), "1"))
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch d := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				addPromDataPoints(get(promName(m, false), m.Description, "gauge"), sm.Scope, d.DataPoints)
+			case metricdata.Gauge[float64]:
+				addPromDataPoints(get(promName(m, false), m.Description, "gauge"), sm.Scope, d.DataPoints)
+			case metricdata.Sum[int64]:
+				addPromSum(get, m, sm.Scope, d)
+			case metricdata.Sum[float64]:
+				addPromSum(get, m, sm.Scope, d)
+			case metricdata.Histogram[int64]:
+				addPromHistogram(get(promName(m, false), m.Description, "histogram"), sm.Scope, d.DataPoints)
+			case metricdata.Histogram[float64]:
+				addPromHistogram(get(promName(m, false), m.Description, "histogram"), sm.Scope, d.DataPoints)
+			case metricdata.Summary:
+				addPromSummary(get(promName(m, false), m.Description, "summary"), sm.Scope, d.DataPoints)
+			case metricdata.ExponentialHistogram[int64], metricdata.ExponentialHistogram[float64]:
+				errs = append(errs, fmt.Errorf("%w: %s", errExponentialHistogram, m.Name))
+			default:
+				errs = append(errs, fmt.Errorf("unsupported metric data type %T: %s", m.Data, m.Name))
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, f := range families {
+		if f.help != "" {
+			fmt.Fprintf(&b, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		}
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.typ)
+		for _, l := range f.lines {
+			b.WriteString(l)
+		}
+	}
+	if _, err := io.WriteString(e.w, b.String()); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func addPromSum[N int64 | float64](get func(name, help, typ string) *family, m metricdata.Metrics, scope instrumentation.Scope, sum metricdata.Sum[N]) {
+	if sum.IsMonotonic && sum.Temporality == metricdata.CumulativeTemporality {
+		addPromDataPoints(get(promName(m, true), m.Description, "counter"), scope, sum.DataPoints)
+		return
+	}
+	addPromDataPoints(get(promName(m, false), m.Description, "gauge"), scope, sum.DataPoints)
+}
+
+func addPromDataPoints[N int64 | float64](f *family, scope instrumentation.Scope, dps []metricdata.DataPoint[N]) {
+	for _, dp := range dps {
+		f.lines = append(f.lines, promSample(f.name, promLabels(dp.Attributes, scope), formatNumber(dp.Value)))
+	}
+}
+
+func addPromHistogram[N int64 | float64](f *family, scope instrumentation.Scope, dps []metricdata.HistogramDataPoint[N]) {
+	for _, dp := range dps {
+		labels := promLabels(dp.Attributes, scope)
+		var cumulative uint64
+		for i, bound := range dp.Bounds {
+			if i < len(dp.BucketCounts) {
+				cumulative += dp.BucketCounts[i]
+			}
+			le := append(slices.Clip(labels), [2]string{"le", formatFloat(bound)})
+			f.lines = append(f.lines, promSample(f.name+"_bucket", le, strconv.FormatUint(cumulative, 10)))
+		}
+		inf := append(slices.Clip(labels), [2]string{"le", formatFloat(math.Inf(1))})
+		f.lines = append(f.lines,
+			promSample(f.name+"_bucket", inf, strconv.FormatUint(dp.Count, 10)),
+			promSample(f.name+"_sum", labels, formatNumber(dp.Sum)),
+			promSample(f.name+"_count", labels, strconv.FormatUint(dp.Count, 10)),
+		)
+	}
+}
+
+func addPromSummary(f *family, scope instrumentation.Scope, dps []metricdata.SummaryDataPoint) {
+	for _, dp := range dps {
+		labels := promLabels(dp.Attributes, scope)
+		for _, q := range dp.QuantileValues {
+			quantile := append(slices.Clip(labels), [2]string{"quantile", formatFloat(q.Quantile)})
+			f.lines = append(f.lines, promSample(f.name, quantile, formatFloat(q.Value)))
+		}
+		f.lines = append(f.lines,
+			promSample(f.name+"_sum", labels, formatFloat(dp.Sum)),
+			promSample(f.name+"_count", labels, strconv.FormatUint(dp.Count, 10)),
+		)
+	}
+}
+
+// promName returns the Prometheus name of the metric.
+func promName(m metricdata.Metrics, counter bool) string {
+	name := sanitizeName(m.Name)
+	if counter {
+		// The _total suffix needs to come after the unit suffix.
+		name = strings.TrimSuffix(name, counterSuffix)
+	}
+	if suffix, ok := unitSuffixes[m.Unit]; ok && !strings.HasSuffix(name, suffix) {
+		name += suffix
+	}
+	if counter {
+		name += counterSuffix
+	}
+	return name
+}
+
+// promLabels returns the label pairs of the attributes and the scope. The
+// values of the attributes with the same sanitized name are sorted and
+// joined with semicolons.
+func promLabels(attrs attribute.Set, scope instrumentation.Scope) [][2]string {
+	values := make(map[string][]string, attrs.Len())
+	var names []string
+	for iter := attrs.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		name := sanitizeLabelName(string(kv.Key))
+		if _, ok := values[name]; !ok {
+			names = append(names, name)
+		}
+		values[name] = append(values[name], kv.Value.Emit())
+	}
+
+	labels := make([][2]string, 0, len(names)+2)
+	for _, name := range names {
+		vals := values[name]
+		slices.Sort(vals)
+		labels = append(labels, [2]string{name, strings.Join(vals, ";")})
+	}
+	if scope.Name != "" {
+		labels = append(labels, [2]string{"otel_scope_name", scope.Name}, [2]string{"otel_scope_version", scope.Version})
+	}
+	return labels
+}
+
+// promSample returns the exposition line of a sample.
+func promSample(name string, labels [][2]string, value string) string {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(l[0])
+			b.WriteString(`="`)
+			b.WriteString(escapeLabelValue(l[1]))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(value)
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// sanitizeName replaces the characters that are not valid in a Prometheus
+// metric name with underscores, and prefixes a leading digit with one.
+func sanitizeName(n string) string {
+	return sanitize(n, true)
+}
+
+// sanitizeLabelName replaces the characters that are not valid in a
+// Prometheus label name with underscores, and prefixes a leading digit with
+// one. Unlike metric names, label names cannot contain colons.
+func sanitizeLabelName(n string) string {
+	return sanitize(n, false)
+}
+
+// sanitize replaces the characters of n that are not letters, digits,
+// underscores, or colons if colon is true, with underscores. A leading digit
+// is prefixed with an underscore.
+func sanitize(n string, colon bool) string {
+	var b strings.Builder
+	b.Grow(len(n) + 1)
+	for i, r := range n {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || (colon && r == ':'):
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+var (
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string { return helpReplacer.Replace(s) }
+
+func escapeLabelValue(s string) string { return labelValueReplacer.Replace(s) }
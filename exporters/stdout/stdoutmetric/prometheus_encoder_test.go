@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdoutmetric_test // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestPrometheusEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := stdoutmetric.New(stdoutmetric.WithEncoder(stdoutmetric.NewPrometheusEncoder(&buf)))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Export(context.Background(), encoderTestData()))
+	want := `# HELP target_info Target metadata
+# TYPE target_info gauge
+target_info{service_name="svc"} 1
+# HELP http_requests_total Number of requests
+# TYPE http_requests_total counter
+http_requests_total{code="200",method="GET",otel_scope_name="scope",otel_scope_version="v1"} 3
+http_requests_total{code="500",otel_scope_name="scope",otel_scope_version="v1"} 1
+# TYPE queue_size_bytes gauge
+queue_size_bytes{otel_scope_name="scope",otel_scope_version="v1"} 1.5
+# HELP latency_seconds Request latency
+# TYPE latency_seconds histogram
+latency_seconds_bucket{path="/a\"b",otel_scope_name="scope",otel_scope_version="v1",le="0.5"} 1
+latency_seconds_bucket{path="/a\"b",otel_scope_name="scope",otel_scope_version="v1",le="5"} 3
+latency_seconds_bucket{path="/a\"b",otel_scope_name="scope",otel_scope_version="v1",le="+Inf"} 6
+latency_seconds_sum{path="/a\"b",otel_scope_name="scope",otel_scope_version="v1"} 12.5
+latency_seconds_count{path="/a\"b",otel_scope_name="scope",otel_scope_version="v1"} 6
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestPrometheusEncoderNaming(t *testing.T) {
+	var buf bytes.Buffer
+	err := stdoutmetric.NewPrometheusEncoder(&buf).Encode(&metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{
+				{
+					Name:        "0bytes_total",
+					Unit:        "By",
+					Description: "multi\nline",
+					Data: metricdata.Sum[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						IsMonotonic: true,
+						DataPoints: []metricdata.DataPoint[float64]{{
+							Attributes: attribute.NewSet(
+								attribute.String("a.b", "2"),
+								attribute.String("a_b", "1"),
+								attribute.String("http:route", "/"),
+							),
+							Value: 2.5,
+						}},
+					},
+				},
+				{
+					Name: "delta",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.DeltaTemporality,
+						IsMonotonic: true,
+						DataPoints:  []metricdata.DataPoint[int64]{{Value: 1}},
+					},
+				},
+				{
+					Name: "exponential",
+					Data: metricdata.ExponentialHistogram[int64]{},
+				},
+			},
+		}},
+	})
+	assert.ErrorContains(t, err, "exponential")
+
+	want := `# HELP _0bytes_bytes_total multi\nline
+# TYPE _0bytes_bytes_total counter
+_0bytes_bytes_total{a_b="1;2",http_route="/"} 2.5
+# TYPE delta gauge
+delta 1
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestPrometheusEncoderTypeConflict(t *testing.T) {
+	var buf bytes.Buffer
+	err := stdoutmetric.NewPrometheusEncoder(&buf).Encode(&metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{
+			{
+				Scope: instrumentation.Scope{Name: "a"},
+				Metrics: []metricdata.Metrics{{
+					Name: "foo",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{{Value: 1}},
+					},
+				}},
+			},
+			{
+				Scope: instrumentation.Scope{Name: "b"},
+				Metrics: []metricdata.Metrics{
+					{
+						Name: "foo",
+						Data: metricdata.Histogram[float64]{
+							Temporality: metricdata.CumulativeTemporality,
+							DataPoints:  []metricdata.HistogramDataPoint[float64]{{Count: 1, Sum: 2}},
+						},
+					},
+					{
+						Name: "foo",
+						Data: metricdata.Gauge[float64]{
+							DataPoints: []metricdata.DataPoint[float64]{{Value: 2}},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.ErrorContains(t, err, "metric type conflict: foo: using existing type gauge, dropped histogram")
+
+	want := `# TYPE foo gauge
+foo{otel_scope_name="a",otel_scope_version=""} 1
+foo{otel_scope_name="b",otel_scope_version=""} 2
+`
+	assert.Equal(t, want, buf.String())
+}
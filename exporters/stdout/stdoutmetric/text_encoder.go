@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var errUnsupportedValue = errors.New("unsupported value, *metricdata.ResourceMetrics expected")
+
+// textEncoder encodes metric data as an aligned text table.
+type textEncoder struct {
+	w io.Writer
+}
+
+// NewTextEncoder returns an Encoder writing the metric data to w as a
+// compact aligned text table. Each row holds a data point: the metric name
+// and unit, the attributes, and the value. The value of a histogram data
+// point is summarized by its count, sum, min, max and bucket counts.
+//
+// The Encoder only encodes *metricdata.ResourceMetrics values.
+func NewTextEncoder(w io.Writer) Encoder {
+	return &textEncoder{w: w}
+}
+
+// Encode writes the table of the data points of v.
+func (e *textEncoder) Encode(v any) error {
+	rm, ok := v.(*metricdata.ResourceMetrics)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnsupportedValue, v)
+	}
+
+	tw := tabwriter.NewWriter(e.w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "METRIC\tATTRIBUTES\tVALUE")
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			name := m.Name
+			if m.Unit != "" {
+				name += " [" + m.Unit + "]"
+			}
+			for _, row := range textRows(m.Data) {
+				_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", name, row[0], row[1])
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// textRows returns the attributes and value cells of the data points of
+// data.
+func textRows(data metricdata.Aggregation) [][2]string {
+	switch d := data.(type) {
+	case metricdata.Gauge[int64]:
+		return dataPointRows(d.DataPoints)
+	case metricdata.Gauge[float64]:
+		return dataPointRows(d.DataPoints)
+	case metricdata.Sum[int64]:
+		return dataPointRows(d.DataPoints)
+	case metricdata.Sum[float64]:
+		return dataPointRows(d.DataPoints)
+	case metricdata.Histogram[int64]:
+		return histogramRows(d.DataPoints)
+	case metricdata.Histogram[float64]:
+		return histogramRows(d.DataPoints)
+	case metricdata.ExponentialHistogram[int64]:
+		return exponentialHistogramRows(d.DataPoints)
+	case metricdata.ExponentialHistogram[float64]:
+		return exponentialHistogramRows(d.DataPoints)
+	case metricdata.Summary:
+		rows := make([][2]string, 0, len(d.DataPoints))
+		for _, dp := range d.DataPoints {
+			var b strings.Builder
+			fmt.Fprintf(&b, "count=%d sum=%s", dp.Count, formatFloat(dp.Sum))
+			for _, q := range dp.QuantileValues {
+				fmt.Fprintf(&b, " q%s=%s", formatFloat(q.Quantile), formatFloat(q.Value))
+			}
+			rows = append(rows, [2]string{textAttributes(dp.Attributes), b.String()})
+		}
+		return rows
+	}
+	return [][2]string{{"-", fmt.Sprintf("unsupported %T", data)}}
+}
+
+func dataPointRows[N int64 | float64](dps []metricdata.DataPoint[N]) [][2]string {
+	rows := make([][2]string, 0, len(dps))
+	for _, dp := range dps {
+		rows = append(rows, [2]string{textAttributes(dp.Attributes), formatNumber(dp.Value)})
+	}
+	return rows
+}
+
+func histogramRows[N int64 | float64](dps []metricdata.HistogramDataPoint[N]) [][2]string {
+	rows := make([][2]string, 0, len(dps))
+	for _, dp := range dps {
+		var b strings.Builder
+		fmt.Fprintf(&b, "count=%d sum=%s", dp.Count, formatNumber(dp.Sum))
+		if v, ok := dp.Min.Value(); ok {
+			fmt.Fprintf(&b, " min=%s", formatNumber(v))
+		}
+		if v, ok := dp.Max.Value(); ok {
+			fmt.Fprintf(&b, " max=%s", formatNumber(v))
+		}
+		if len(dp.BucketCounts) > 0 {
+			b.WriteString(" buckets=[")
+			for i, c := range dp.BucketCounts {
+				if i > 0 {
+					b.WriteByte(' ')
+				}
+				bound := math.Inf(1)
+				if i < len(dp.Bounds) {
+					bound = dp.Bounds[i]
+				}
+				fmt.Fprintf(&b, "%s:%d", formatFloat(bound), c)
+			}
+			b.WriteByte(']')
+		}
+		rows = append(rows, [2]string{textAttributes(dp.Attributes), b.String()})
+	}
+	return rows
+}
+
+func exponentialHistogramRows[N int64 | float64](dps []metricdata.ExponentialHistogramDataPoint[N]) [][2]string {
+	rows := make([][2]string, 0, len(dps))
+	for _, dp := range dps {
+		var b strings.Builder
+		fmt.Fprintf(&b, "count=%d sum=%s", dp.Count, formatNumber(dp.Sum))
+		if v, ok := dp.Min.Value(); ok {
+			fmt.Fprintf(&b, " min=%s", formatNumber(v))
+		}
+		if v, ok := dp.Max.Value(); ok {
+			fmt.Fprintf(&b, " max=%s", formatNumber(v))
+		}
+		fmt.Fprintf(&b, " scale=%d zero=%d", dp.Scale, dp.ZeroCount)
+		rows = append(rows, [2]string{textAttributes(dp.Attributes), b.String()})
+	}
+	return rows
+}
+
+// textAttributes returns the attributes as comma separated key=value pairs,
+// or "-" if there are none.
+func textAttributes(attrs attribute.Set) string {
+	if attrs.Len() == 0 {
+		return "-"
+	}
+	return attrs.Encoded(attribute.DefaultEncoder())
+}
+
+func formatNumber[N int64 | float64](v N) string {
+	switch v := any(v).(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return formatFloat(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// formatFloat formats f as the Prometheus text format does.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
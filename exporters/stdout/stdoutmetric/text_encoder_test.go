@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdoutmetric_test // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// encoderTestData is the metric data encoded by the encoder tests.
+func encoderTestData() *metricdata.ResourceMetrics {
+	return &metricdata.ResourceMetrics{
+		Resource: resource.NewSchemaless(attribute.String("service.name", "svc")),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "scope", Version: "v1"},
+			Metrics: []metricdata.Metrics{
+				{
+					Name:        "http.requests",
+					Description: "Number of requests",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						IsMonotonic: true,
+						DataPoints: []metricdata.DataPoint[int64]{
+							{Attributes: attribute.NewSet(attribute.String("code", "200"), attribute.String("method", "GET")), Value: 3},
+							{Attributes: attribute.NewSet(attribute.String("code", "500")), Value: 1},
+						},
+					},
+				},
+				{
+					Name: "queue.size",
+					Unit: "By",
+					Data: metricdata.Gauge[float64]{
+						DataPoints: []metricdata.DataPoint[float64]{{Value: 1.5}},
+					},
+				},
+				{
+					Name:        "latency",
+					Description: "Request latency",
+					Unit:        "s",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{{
+							Attributes:   attribute.NewSet(attribute.String("path", `/a"b`)),
+							Count:        6,
+							Sum:          12.5,
+							Min:          metricdata.NewExtrema(0.25),
+							Max:          metricdata.NewExtrema(7.0),
+							Bounds:       []float64{0.5, 5},
+							BucketCounts: []uint64{1, 2, 3},
+						}},
+					},
+				},
+			},
+		}},
+	}
+}
+
+func TestTextEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := stdoutmetric.New(stdoutmetric.WithEncoder(stdoutmetric.NewTextEncoder(&buf)))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Export(context.Background(), encoderTestData()))
+	want := `METRIC           ATTRIBUTES           VALUE
+http.requests    code=200,method=GET  3
+http.requests    code=500             1
+queue.size [By]  -                    1.5
+latency [s]      path=/a"b            count=6 sum=12.5 min=0.25 max=7 buckets=[0.5:1 5:2 +Inf:3]
+`
+	assert.Equal(t, want, buf.String())
+
+	assert.Error(t, stdoutmetric.NewTextEncoder(&buf).Encode("invalid"))
+}
+
+func TestTextEncoderSummary(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, stdoutmetric.NewTextEncoder(&buf).Encode(&metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "summary",
+				Data: metricdata.Summary{DataPoints: []metricdata.SummaryDataPoint{{
+					Count:          10,
+					Sum:            20,
+					QuantileValues: []metricdata.QuantileValue{{Quantile: 0.5, Value: 1}, {Quantile: 0.99, Value: 4}},
+				}}},
+			}, {
+				Name: "exponential",
+				Data: metricdata.ExponentialHistogram[int64]{DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{
+					Count:     3,
+					Sum:       6,
+					Scale:     2,
+					ZeroCount: 1,
+				}}},
+			}},
+		}},
+	}))
+	want := `METRIC       ATTRIBUTES  VALUE
+summary      -           count=10 sum=20 q0.5=1 q0.99=4
+exponential  -           count=3 sum=6 scale=2 zero=1
+`
+	assert.Equal(t, want, buf.String())
+}
@@ -6,12 +6,14 @@ package stdouttrace // import "go.opentelemetry.io/otel/exporters/stdout/stdoutt
 import (
 	"io"
 	"os"
+	"time"
 )
 
 var (
-	defaultWriter      = os.Stdout
-	defaultPrettyPrint = false
-	defaultTimestamps  = true
+	defaultWriter           = os.Stdout
+	defaultPrettyPrint      = false
+	defaultTimestamps       = true
+	defaultTreeFlushTimeout = 5 * time.Second
 )
 
 // config contains options for the STDOUT exporter.
@@ -26,14 +28,23 @@ type config struct {
 	// Timestamps specifies if timestamps should be printed. Default is
 	// true.
 	Timestamps bool
+
+	// Tree prints the spans of each trace as an indented tree instead of
+	// JSON. Default is false.
+	Tree bool
+
+	// TreeFlushTimeout is the maximum time the spans of a trace are buffered
+	// waiting for their parent before being printed. Default is 5 seconds.
+	TreeFlushTimeout time.Duration
 }
 
 // newConfig creates a validated Config configured with options.
 func newConfig(options ...Option) config {
 	cfg := config{
-		Writer:      defaultWriter,
-		PrettyPrint: defaultPrettyPrint,
-		Timestamps:  defaultTimestamps,
+		Writer:           defaultWriter,
+		PrettyPrint:      defaultPrettyPrint,
+		Timestamps:       defaultTimestamps,
+		TreeFlushTimeout: defaultTreeFlushTimeout,
 	}
 	for _, opt := range options {
 		cfg = opt.apply(cfg)
@@ -83,3 +94,38 @@ func (o timestampsOption) apply(cfg config) config {
 	cfg.Timestamps = bool(o)
 	return cfg
 }
+
+// WithTreeOutput sets the export stream to print the spans of each trace as
+// an indented tree, instead of one JSON object per span. The tree shows the
+// duration, status, attributes and events of the spans, the children of a
+// span are ordered by start time.
+//
+// The spans of a trace are buffered until its root span ends. Spans ending
+// after their parent was printed, e.g. asynchronous work, are printed under a
+// line naming the parent if they are received within the timeout set with
+// WithTreeFlushTimeout. Spans whose parent is not received within the
+// timeout are printed without it.
+func WithTreeOutput() Option {
+	return treeOption(true)
+}
+
+type treeOption bool
+
+func (o treeOption) apply(cfg config) config {
+	cfg.Tree = bool(o)
+	return cfg
+}
+
+// WithTreeFlushTimeout sets the maximum time the spans of a trace are
+// buffered waiting for their parent when WithTreeOutput is used. Default is 5
+// seconds.
+func WithTreeFlushTimeout(timeout time.Duration) Option {
+	return treeFlushTimeoutOption(timeout)
+}
+
+type treeFlushTimeoutOption time.Duration
+
+func (o treeFlushTimeoutOption) apply(cfg config) config {
+	cfg.TreeFlushTimeout = time.Duration(o)
+	return cfg
+}
@@ -2,5 +2,6 @@
 // SPDX-License-Identifier: Apache-2.0
 
 // Package stdouttrace contains an OpenTelemetry exporter for tracing
-// telemetry to be written to an output destination as JSON.
+// telemetry to be written to an output destination as JSON, or as an indented
+// tree of the spans of each trace.
 package stdouttrace // import "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
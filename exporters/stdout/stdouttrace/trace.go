@@ -26,10 +26,14 @@ func New(options ...Option) (*Exporter, error) {
 		enc.SetIndent("", "\t")
 	}
 
-	return &Exporter{
+	exp := &Exporter{
 		encoder:    enc,
 		timestamps: cfg.Timestamps,
-	}, nil
+	}
+	if cfg.Tree {
+		exp.tree = newTreePrinter(cfg.Writer, cfg.TreeFlushTimeout, cfg.Timestamps)
+	}
+	return exp, nil
 }
 
 // Exporter is an implementation of trace.SpanSyncer that writes spans to stdout.
@@ -37,6 +41,7 @@ type Exporter struct {
 	encoder    *json.Encoder
 	encoderMu  sync.Mutex
 	timestamps bool
+	tree       *treePrinter
 
 	stoppedMu sync.RWMutex
 	stopped   bool
@@ -59,6 +64,9 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan)
 	}
 
 	stubs := tracetest.SpanStubsFromReadOnlySpans(spans)
+	if e.tree != nil {
+		return e.tree.add(stubs)
+	}
 
 	e.encoderMu.Lock()
 	defer e.encoderMu.Unlock()
@@ -82,12 +90,17 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan)
 	return nil
 }
 
-// Shutdown is called to stop the exporter, it performs no action.
+// Shutdown is called to stop the exporter. If the exporter prints trees, the
+// spans still waiting for their parent are printed.
 func (e *Exporter) Shutdown(ctx context.Context) error {
 	e.stoppedMu.Lock()
+	stopped := e.stopped
 	e.stopped = true
 	e.stoppedMu.Unlock()
 
+	if e.tree != nil && !stopped {
+		return e.tree.flush()
+	}
 	return nil
 }
 
@@ -0,0 +1,359 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdouttrace // import "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// treePrinter buffers the spans of each trace and prints them as an indented
+// tree.
+type treePrinter struct {
+	w          io.Writer
+	timeout    time.Duration
+	timestamps bool
+
+	mu     sync.Mutex
+	traces map[trace.TraceID]*traceBuffer
+}
+
+// traceBuffer holds the spans of a trace waiting for their parent, and the
+// names of the spans of the trace already printed. The printed spans are
+// kept until the timeout expires so their children ending after them are
+// printed under them.
+type traceBuffer struct {
+	spans   []tracetest.SpanStub
+	printed map[trace.SpanID]string
+	timer   *time.Timer
+}
+
+func newTreePrinter(w io.Writer, timeout time.Duration, timestamps bool) *treePrinter {
+	return &treePrinter{
+		w:          w,
+		timeout:    timeout,
+		timestamps: timestamps,
+		traces:     make(map[trace.TraceID]*traceBuffer),
+	}
+}
+
+// add buffers the spans and prints the trees of the traces whose root span is
+// received. The spans whose parent is not received are printed once the
+// timeout of their trace expires.
+func (p *treePrinter) add(spans tracetest.SpanStubs) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var order []trace.TraceID
+	for _, s := range spans {
+		id := s.SpanContext.TraceID()
+		buf, ok := p.traces[id]
+		if !ok {
+			buf = &traceBuffer{printed: make(map[trace.SpanID]string)}
+			buf.timer = time.AfterFunc(p.timeout, func() { p.expire(id) })
+			p.traces[id] = buf
+		}
+		if !slices.Contains(order, id) {
+			order = append(order, id)
+		}
+		buf.spans = append(buf.spans, s)
+	}
+
+	var sb strings.Builder
+	for _, id := range order {
+		buf := p.traces[id]
+		complete, pending := splitComplete(buf.spans, buf.printed)
+		if len(complete) > 0 {
+			p.writeTrace(&sb, id, complete, buf.printed, false)
+			for _, s := range complete {
+				buf.printed[s.SpanContext.SpanID()] = s.Name
+			}
+		}
+		buf.spans = pending
+	}
+	return p.write(sb.String())
+}
+
+// expire prints the spans of the trace still waiting for their parent.
+func (p *treePrinter) expire(id trace.TraceID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	buf, ok := p.traces[id]
+	if !ok {
+		return
+	}
+	var sb strings.Builder
+	if len(buf.spans) > 0 {
+		p.writeTrace(&sb, id, buf.spans, buf.printed, true)
+	}
+	p.remove(id)
+	if err := p.write(sb.String()); err != nil {
+		otel.Handle(err)
+	}
+}
+
+// flush prints all the buffered spans, ordered by the start time of their
+// trace.
+func (p *treePrinter) flush() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	type pendingTrace struct {
+		id    trace.TraceID
+		buf   *traceBuffer
+		start time.Time
+	}
+	var traces []pendingTrace
+	for id, buf := range p.traces {
+		if len(buf.spans) > 0 {
+			start := slices.MinFunc(buf.spans, func(a, b tracetest.SpanStub) int {
+				return a.StartTime.Compare(b.StartTime)
+			}).StartTime
+			traces = append(traces, pendingTrace{id: id, buf: buf, start: start})
+		}
+	}
+	slices.SortFunc(traces, func(a, b pendingTrace) int {
+		if c := a.start.Compare(b.start); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.id[:], b.id[:])
+	})
+
+	var sb strings.Builder
+	for _, t := range traces {
+		p.writeTrace(&sb, t.id, t.buf.spans, t.buf.printed, true)
+	}
+	for id := range p.traces {
+		p.remove(id)
+	}
+	return p.write(sb.String())
+}
+
+// remove stops buffering the spans of the trace. The lock must be held.
+func (p *treePrinter) remove(id trace.TraceID) {
+	if buf, ok := p.traces[id]; ok && buf.timer != nil {
+		buf.timer.Stop()
+	}
+	delete(p.traces, id)
+}
+
+func (p *treePrinter) write(s string) error {
+	if s == "" {
+		return nil
+	}
+	_, err := io.WriteString(p.w, s)
+	return err
+}
+
+// isRoot returns whether s is the local root of its trace.
+func isRoot(s tracetest.SpanStub) bool {
+	return !s.Parent.IsValid() || s.Parent.IsRemote()
+}
+
+// splitComplete splits spans into the spans descending from a root span or
+// from a printed span, and the other ones.
+func splitComplete(spans []tracetest.SpanStub, printed map[trace.SpanID]string) (complete, pending []tracetest.SpanStub) {
+	parents := make(map[trace.SpanID]trace.SpanID, len(spans))
+	for _, s := range spans {
+		if !isRoot(s) {
+			parents[s.SpanContext.SpanID()] = s.Parent.SpanID()
+		}
+	}
+	rooted := func(id trace.SpanID) bool {
+		// The number of spans bounds the depth, it guards against cycles.
+		for i := 0; i <= len(spans); i++ {
+			parent, ok := parents[id]
+			if !ok {
+				if _, ok := printed[id]; ok {
+					return true
+				}
+				return slices.ContainsFunc(spans, func(s tracetest.SpanStub) bool {
+					return s.SpanContext.SpanID() == id && isRoot(s)
+				})
+			}
+			id = parent
+		}
+		return false
+	}
+	for _, s := range spans {
+		if rooted(s.SpanContext.SpanID()) {
+			complete = append(complete, s)
+		} else {
+			pending = append(pending, s)
+		}
+	}
+	return complete, pending
+}
+
+// writeTrace writes the tree of the spans of the trace. The spans whose
+// parent was already printed are written under a line naming that parent.
+// The spans whose parent is missing are written at the top level, flagged as
+// orphans if orphans is true.
+func (p *treePrinter) writeTrace(sb *strings.Builder, id trace.TraceID, spans []tracetest.SpanStub, printed map[trace.SpanID]string, orphans bool) {
+	present := make(map[trace.SpanID]bool, len(spans))
+	for _, s := range spans {
+		present[s.SpanContext.SpanID()] = true
+	}
+	children := make(map[trace.SpanID][]tracetest.SpanStub)
+	var tops, late []tracetest.SpanStub
+	for _, s := range spans {
+		parent := s.Parent.SpanID()
+		switch {
+		case isRoot(s):
+			tops = append(tops, s)
+		case present[parent]:
+			children[parent] = append(children[parent], s)
+		default:
+			if _, ok := printed[parent]; ok {
+				late = append(late, s)
+			} else {
+				tops = append(tops, s)
+			}
+		}
+	}
+	sortByStart(tops)
+	sortByStart(late)
+	for _, c := range children {
+		sortByStart(c)
+	}
+
+	// The printed parents of the late spans, in the order of their first
+	// child.
+	var lateParents []trace.SpanID
+	for _, s := range late {
+		if parent := s.Parent.SpanID(); !slices.Contains(lateParents, parent) {
+			lateParents = append(lateParents, parent)
+		}
+		children[s.Parent.SpanID()] = append(children[s.Parent.SpanID()], s)
+	}
+
+	sb.WriteString("Trace ")
+	sb.WriteString(id.String())
+	if p.timestamps {
+		var first []tracetest.SpanStub
+		if len(tops) > 0 {
+			first = append(first, tops[0])
+		}
+		if len(late) > 0 {
+			first = append(first, late[0])
+		}
+		if len(first) > 0 {
+			sortByStart(first)
+			sb.WriteString(" started at ")
+			sb.WriteString(first[0].StartTime.Format(time.RFC3339Nano))
+		}
+	}
+	sb.WriteByte('\n')
+	for i, parent := range lateParents {
+		p.writePrinted(sb, parent, printed[parent], children, i == len(lateParents)-1 && len(tops) == 0)
+	}
+	for i, s := range tops {
+		missing := orphans && !isRoot(s)
+		p.writeSpan(sb, s, children, "", i == len(tops)-1, missing)
+	}
+}
+
+// writePrinted writes the line of the already printed span id, followed by
+// its children.
+func (p *treePrinter) writePrinted(sb *strings.Builder, id trace.SpanID, name string, children map[trace.SpanID][]tracetest.SpanStub, last bool) {
+	connector, childPrefix := "├── ", "│   "
+	if last {
+		connector, childPrefix = "└── ", "    "
+	}
+	sb.WriteString(connector)
+	sb.WriteString(name)
+	sb.WriteString(" (")
+	sb.WriteString(id.String())
+	sb.WriteString(" printed before)\n")
+
+	kids := children[id]
+	for i, c := range kids {
+		p.writeSpan(sb, c, children, childPrefix, i == len(kids)-1, false)
+	}
+}
+
+func sortByStart(spans []tracetest.SpanStub) {
+	slices.SortStableFunc(spans, func(a, b tracetest.SpanStub) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+}
+
+// writeSpan writes s, its attributes and events, and its children.
+func (p *treePrinter) writeSpan(sb *strings.Builder, s tracetest.SpanStub, children map[trace.SpanID][]tracetest.SpanStub, prefix string, last, missingParent bool) {
+	connector, childPrefix := "├── ", prefix+"│   "
+	if last {
+		connector, childPrefix = "└── ", prefix+"    "
+	}
+	kids := children[s.SpanContext.SpanID()]
+
+	sb.WriteString(prefix)
+	sb.WriteString(connector)
+	sb.WriteString(s.Name)
+	sb.WriteString(" [")
+	sb.WriteString(s.SpanKind.String())
+	sb.WriteString("] ")
+	sb.WriteString(s.EndTime.Sub(s.StartTime).String())
+	if s.Status.Code != codes.Unset {
+		sb.WriteByte(' ')
+		sb.WriteString(s.Status.Code.String())
+		if s.Status.Description != "" {
+			sb.WriteString(": ")
+			sb.WriteString(s.Status.Description)
+		}
+	}
+	if missingParent {
+		sb.WriteString(" (parent ")
+		sb.WriteString(s.Parent.SpanID().String())
+		sb.WriteString(" not received)")
+	}
+	sb.WriteByte('\n')
+
+	detailPrefix := childPrefix + "    "
+	if len(kids) > 0 {
+		detailPrefix = childPrefix + "│   "
+	}
+	if len(s.Attributes) > 0 {
+		sb.WriteString(detailPrefix)
+		writeAttributes(sb, s.Attributes)
+		sb.WriteByte('\n')
+	}
+	for _, e := range s.Events {
+		sb.WriteString(detailPrefix)
+		sb.WriteString("@ +")
+		sb.WriteString(e.Time.Sub(s.StartTime).String())
+		sb.WriteByte(' ')
+		sb.WriteString(e.Name)
+		if len(e.Attributes) > 0 {
+			sb.WriteByte(' ')
+			writeAttributes(sb, e.Attributes)
+		}
+		sb.WriteByte('\n')
+	}
+
+	for i, c := range kids {
+		p.writeSpan(sb, c, children, childPrefix, i == len(kids)-1, false)
+	}
+}
+
+func writeAttributes(sb *strings.Builder, attrs []attribute.KeyValue) {
+	for i, kv := range attrs {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(string(kv.Key))
+		sb.WriteByte('=')
+		sb.WriteString(kv.Value.Emit())
+	}
+}
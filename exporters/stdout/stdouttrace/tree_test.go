@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stdouttrace_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+var (
+	treeTraceID = trace.TraceID{0x01}
+	treeStart   = time.Date(2020, time.March, 11, 19, 24, 0, 0, time.UTC)
+)
+
+func treeSpan(name string, id, parent byte, start, end time.Duration) tracetest.SpanStub {
+	s := tracetest.SpanStub{
+		Name:        name,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: treeTraceID, SpanID: trace.SpanID{id}}),
+		SpanKind:    trace.SpanKindInternal,
+		StartTime:   treeStart.Add(start),
+		EndTime:     treeStart.Add(end),
+	}
+	if parent != 0 {
+		s.Parent = trace.NewSpanContext(trace.SpanContextConfig{TraceID: treeTraceID, SpanID: trace.SpanID{parent}})
+	}
+	return s
+}
+
+func TestTreeOutput(t *testing.T) {
+	root := treeSpan("root", 1, 0, 0, 100*time.Millisecond)
+	root.SpanKind = trace.SpanKindServer
+	root.Status = sdktrace.Status{Code: codes.Error, Description: "failed"}
+	root.Attributes = []attribute.KeyValue{attribute.String("http.method", "GET"), attribute.Int("http.status_code", 500)}
+
+	second := treeSpan("second", 3, 1, 50*time.Millisecond, 90*time.Millisecond)
+	second.SpanKind = trace.SpanKindClient
+	second.Events = []sdktrace.Event{{
+		Name:       "retry",
+		Time:       treeStart.Add(60 * time.Millisecond),
+		Attributes: []attribute.KeyValue{attribute.Int("attempt", 2)},
+	}}
+
+	first := treeSpan("first", 2, 1, 10*time.Millisecond, 40*time.Millisecond)
+	grandchild := treeSpan("grandchild", 4, 2, 20*time.Millisecond, 30*time.Millisecond)
+	grandchild.Status = sdktrace.Status{Code: codes.Ok}
+
+	var buf bytes.Buffer
+	exp, err := stdouttrace.New(stdouttrace.WithWriter(&buf), stdouttrace.WithTreeOutput())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Children are buffered until their root span ends.
+	require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{grandchild, second}.Snapshots()))
+	require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{first}.Snapshots()))
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{root}.Snapshots()))
+	want := `Trace 01000000000000000000000000000000 started at 2020-03-11T19:24:00Z
+└── root [server] 100ms Error: failed
+    │   http.method=GET http.status_code=500
+    ├── first [internal] 30ms
+    │   └── grandchild [internal] 10ms Ok
+    └── second [client] 40ms
+            @ +10ms retry attempt=2
+`
+	assert.Equal(t, want, buf.String())
+
+	buf.Reset()
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Empty(t, buf.String(), "nothing left to flush")
+}
+
+func TestTreeOutputOrphans(t *testing.T) {
+	orphan := treeSpan("orphan", 2, 1, 10*time.Millisecond, 20*time.Millisecond)
+	child := treeSpan("child", 3, 2, 12*time.Millisecond, 15*time.Millisecond)
+
+	t.Run("Timeout", func(t *testing.T) {
+		var buf syncBuffer
+		exp, err := stdouttrace.New(
+			stdouttrace.WithWriter(&buf),
+			stdouttrace.WithTreeOutput(),
+			stdouttrace.WithTreeFlushTimeout(10*time.Millisecond),
+			stdouttrace.WithoutTimestamps(),
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, exp.ExportSpans(context.Background(), tracetest.SpanStubs{child, orphan}.Snapshots()))
+		want := `Trace 01000000000000000000000000000000
+└── orphan [internal] 10ms (parent 0100000000000000 not received)
+    └── child [internal] 3ms
+`
+		assert.Eventually(t, func() bool { return buf.String() == want }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		var buf syncBuffer
+		exp, err := stdouttrace.New(
+			stdouttrace.WithWriter(&buf),
+			stdouttrace.WithTreeOutput(),
+			stdouttrace.WithTreeFlushTimeout(time.Hour),
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, exp.ExportSpans(context.Background(), tracetest.SpanStubs{orphan}.Snapshots()))
+		assert.Empty(t, buf.String())
+		require.NoError(t, exp.Shutdown(context.Background()))
+		assert.Contains(t, buf.String(), "orphan [internal] 10ms (parent 0100000000000000 not received)")
+	})
+}
+
+func TestTreeOutputLateChild(t *testing.T) {
+	root := treeSpan("root", 1, 0, 0, 10*time.Millisecond)
+	child := treeSpan("child", 2, 1, 5*time.Millisecond, 20*time.Millisecond)
+	grandchild := treeSpan("grandchild", 3, 2, 6*time.Millisecond, 8*time.Millisecond)
+
+	var buf syncBuffer
+	exp, err := stdouttrace.New(
+		stdouttrace.WithWriter(&buf),
+		stdouttrace.WithTreeOutput(),
+		stdouttrace.WithTreeFlushTimeout(time.Hour),
+		stdouttrace.WithoutTimestamps(),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{root}.Snapshots()))
+	require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{grandchild}.Snapshots()))
+	require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{child}.Snapshots()))
+	want := `Trace 01000000000000000000000000000000
+└── root [internal] 10ms
+Trace 01000000000000000000000000000000
+└── root (0100000000000000 printed before)
+    └── child [internal] 15ms
+        └── grandchild [internal] 2ms
+`
+	assert.Equal(t, want, buf.String())
+
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Equal(t, want, buf.String(), "nothing left to flush")
+}
+
+func TestTreeOutputShutdownOrder(t *testing.T) {
+	orphan := func(traceID byte, start time.Duration) tracetest.SpanStub {
+		s := treeSpan("orphan", 2, 1, start, start+time.Millisecond)
+		s.SpanContext = s.SpanContext.WithTraceID(trace.TraceID{traceID})
+		s.Parent = s.Parent.WithTraceID(trace.TraceID{traceID})
+		return s
+	}
+
+	var buf syncBuffer
+	exp, err := stdouttrace.New(
+		stdouttrace.WithWriter(&buf),
+		stdouttrace.WithTreeOutput(),
+		stdouttrace.WithTreeFlushTimeout(time.Hour),
+		stdouttrace.WithoutTimestamps(),
+	)
+	require.NoError(t, err)
+
+	spans := tracetest.SpanStubs{orphan(3, 0), orphan(1, 2*time.Millisecond), orphan(2, time.Millisecond)}
+	require.NoError(t, exp.ExportSpans(context.Background(), spans.Snapshots()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	want := `Trace 03000000000000000000000000000000
+└── orphan [internal] 1ms (parent 0100000000000000 not received)
+Trace 02000000000000000000000000000000
+└── orphan [internal] 1ms (parent 0100000000000000 not received)
+Trace 01000000000000000000000000000000
+└── orphan [internal] 1ms (parent 0100000000000000 not received)
+`
+	assert.Equal(t, want, buf.String())
+}
@@ -3,12 +3,19 @@
 
 package zipkin // import "go.opentelemetry.io/otel/exporters/zipkin"
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 // Environment variable names.
 const (
 	// Endpoint for Zipkin collector.
 	envEndpoint = "OTEL_EXPORTER_ZIPKIN_ENDPOINT"
+	// Maximum time, in milliseconds, the exporter waits for each batch
+	// export.
+	envTimeout = "OTEL_EXPORTER_ZIPKIN_TIMEOUT"
 )
 
 // envOr returns an env variable's value if it is exists or the default if not.
@@ -18,3 +25,18 @@ func envOr(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// envDurationOr returns the duration of an env variable's value, interpreted
+// as a number of milliseconds, if it exists and is a valid non-negative
+// integer, or the default if not.
+func envDurationOr(key string, defaultValue time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	ms, err := strconv.Atoi(v)
+	if err != nil || ms < 0 {
+		return defaultValue
+	}
+	return time.Duration(ms) * time.Millisecond
+}
@@ -6,6 +6,7 @@ package zipkin
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,3 +50,24 @@ func TestEnvOrWithCollectorEndpointOptionsFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvDurationOr(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "unset", want: defaultTimeout},
+		{name: "milliseconds", value: "1500", want: 1500 * time.Millisecond},
+		{name: "zero", value: "0", want: 0},
+		{name: "negative", value: "-1", want: defaultTimeout},
+		{name: "invalid", value: "10s", want: defaultTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(envTimeout, tc.value)
+			assert.Equal(t, tc.want, envDurationOr(envTimeout, defaultTimeout))
+		})
+	}
+}
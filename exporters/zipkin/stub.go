@@ -0,0 +1,399 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zipkin // import "go.opentelemetry.io/otel/exporters/zipkin"
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv120 "go.opentelemetry.io/otel/semconv/v1.20.0"
+	semconv121 "go.opentelemetry.io/otel/semconv/v1.21.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// keyOTelLibraryName is the deprecated tag holding the instrumentation scope
+// name, still found in older Zipkin data.
+const keyOTelLibraryName = "otel.library.name"
+
+// SpanStubsFromJSON converts Zipkin v2 JSON encoded spans into OpenTelemetry
+// span stubs. See SpanStubs for the details of the conversion.
+func SpanStubsFromJSON(data []byte) (tracetest.SpanStubs, error) {
+	var models []zkmodel.SpanModel
+	if err := json.Unmarshal(data, &models); err != nil {
+		return nil, fmt.Errorf("failed to parse zipkin JSON spans: %w", err)
+	}
+	return SpanStubs(models), nil
+}
+
+// SpanStubsFromProto converts Zipkin v2 protobuf encoded spans into
+// OpenTelemetry span stubs. See SpanStubs for the details of the conversion.
+func SpanStubsFromProto(data []byte) (tracetest.SpanStubs, error) {
+	models, err := zipkin_proto3.ParseSpans(data, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse zipkin protobuf spans: %w", err)
+	}
+	stubs := make(tracetest.SpanStubs, 0, len(models))
+	for _, m := range models {
+		stubs = append(stubs, toSpanStub(*m))
+	}
+	return stubs, nil
+}
+
+// SpanStubs converts Zipkin model spans into OpenTelemetry span stubs. It is
+// the reverse of SpanModels, the ReadOnlySpans of the stubs can be exported
+// with any OpenTelemetry span exporter.
+//
+// The tags are converted into attributes typed according to their value:
+// booleans, integers, floats and JSON lists of them. The otel.status_code and
+// error tags set the status, the otel.scope.name and otel.scope.version tags
+// set the instrumentation scope. The annotations are converted into events,
+// the attributes of the annotations encoded by SpanModels are restored. The
+// service name of the local endpoint is the service.name attribute of the
+// resource. The remote endpoint is converted into the peer.service,
+// net.sock.peer.addr and net.sock.peer.port attributes, unless the tags hold
+// one of the attributes SpanModels derives the remote endpoint from. The
+// local endpoint of the server spans is converted into the server.address and
+// server.port attributes, unless the tags already hold them.
+func SpanStubs(models []zkmodel.SpanModel) tracetest.SpanStubs {
+	stubs := make(tracetest.SpanStubs, 0, len(models))
+	for _, m := range models {
+		stubs = append(stubs, toSpanStub(m))
+	}
+	return stubs
+}
+
+func toSpanStub(m zkmodel.SpanModel) tracetest.SpanStub {
+	stub := tracetest.SpanStub{
+		Name:      m.Name,
+		SpanKind:  fromZipkinKind(m.Kind),
+		StartTime: m.Timestamp,
+		EndTime:   m.Timestamp.Add(m.Duration),
+		Events:    fromZipkinAnnotations(m.Annotations),
+		Resource:  resource.Empty(),
+	}
+
+	traceID := fromZipkinTraceID(m.TraceID)
+	flags := trace.FlagsSampled
+	if m.Sampled != nil && !*m.Sampled && !m.Debug {
+		flags = 0
+	}
+	stub.SpanContext = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     fromZipkinID(m.ID),
+		TraceFlags: flags,
+	})
+	if m.ParentID != nil {
+		stub.Parent = trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     fromZipkinID(*m.ParentID),
+			TraceFlags: flags,
+		})
+	}
+
+	tags := make(map[string]string, len(m.Tags))
+	for k, v := range m.Tags {
+		tags[k] = v
+	}
+
+	if m.LocalEndpoint != nil && m.LocalEndpoint.ServiceName != "" {
+		stub.Resource = resource.NewSchemaless(semconv.ServiceName(m.LocalEndpoint.ServiceName))
+		delete(tags, string(semconv.ServiceNameKey))
+	}
+
+	stub.Status = fromZipkinStatus(tags)
+	stub.InstrumentationLibrary = fromZipkinScope(tags)
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		stub.Attributes = append(stub.Attributes, tagToAttribute(k, tags[k]))
+	}
+
+	if m.RemoteEndpoint != nil && !hasRemoteEndpointTag(tags) {
+		stub.Attributes = appendEndpointAttributes(stub.Attributes, m.RemoteEndpoint,
+			semconv.PeerServiceKey, semconv120.NetSockPeerAddrKey, semconv121.NetSockPeerPortKey)
+	}
+	if m.LocalEndpoint != nil && stub.SpanKind == trace.SpanKindServer {
+		stub.Attributes = appendEndpointAttributes(stub.Attributes, m.LocalEndpoint,
+			"", semconv.ServerAddressKey, semconv.ServerPortKey)
+	}
+	return stub
+}
+
+func fromZipkinTraceID(id zkmodel.TraceID) trace.TraceID {
+	var traceID trace.TraceID
+	binary.BigEndian.PutUint64(traceID[:8], id.High)
+	binary.BigEndian.PutUint64(traceID[8:], id.Low)
+	return traceID
+}
+
+func fromZipkinID(id zkmodel.ID) trace.SpanID {
+	var spanID trace.SpanID
+	binary.BigEndian.PutUint64(spanID[:], uint64(id))
+	return spanID
+}
+
+func fromZipkinKind(kind zkmodel.Kind) trace.SpanKind {
+	switch kind {
+	case zkmodel.Server:
+		return trace.SpanKindServer
+	case zkmodel.Client:
+		return trace.SpanKindClient
+	case zkmodel.Producer:
+		return trace.SpanKindProducer
+	case zkmodel.Consumer:
+		return trace.SpanKindConsumer
+	}
+	// SpanModels converts internal spans into undetermined ones.
+	return trace.SpanKindInternal
+}
+
+// fromZipkinStatus returns the status held by the tags, and removes the tags
+// holding it.
+func fromZipkinStatus(tags map[string]string) tracesdk.Status {
+	var status tracesdk.Status
+	switch tags[string(semconv.OTelStatusCodeKey)] {
+	case "OK":
+		status.Code = codes.Ok
+	case "ERROR":
+		status.Code = codes.Error
+	}
+	if desc, ok := tags["error"]; ok {
+		status.Code = codes.Error
+		status.Description = desc
+	}
+	delete(tags, string(semconv.OTelStatusCodeKey))
+	delete(tags, "error")
+	return status
+}
+
+// fromZipkinScope returns the instrumentation scope held by the tags, and
+// removes the tags holding it.
+func fromZipkinScope(tags map[string]string) instrumentation.Scope {
+	scope := instrumentation.Scope{
+		Name:    tags[string(semconv.OTelScopeNameKey)],
+		Version: tags[string(semconv.OTelScopeVersionKey)],
+	}
+	if scope.Name == "" {
+		scope.Name = tags[keyOTelLibraryName]
+	}
+	delete(tags, string(semconv.OTelScopeNameKey))
+	delete(tags, string(semconv.OTelScopeVersionKey))
+	delete(tags, keyOTelLibraryName)
+	return scope
+}
+
+func fromZipkinAnnotations(annotations []zkmodel.Annotation) []tracesdk.Event {
+	if len(annotations) == 0 {
+		return nil
+	}
+	events := make([]tracesdk.Event, 0, len(annotations))
+	for _, a := range annotations {
+		event := tracesdk.Event{Name: a.Value, Time: a.Timestamp}
+		// SpanModels encodes the event attributes as "name: {JSON map}".
+		if i := strings.Index(a.Value, ": {"); i >= 0 {
+			if attrs, ok := jsonMapToAttributes(a.Value[i+2:]); ok {
+				event.Name, event.Attributes = a.Value[:i], attrs
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// jsonMapToAttributes returns the attributes of the JSON object s, sorted by
+// key, and whether s is a valid JSON object.
+func jsonMapToAttributes(s string) ([]attribute.KeyValue, bool) {
+	var m map[string]interface{}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil || dec.More() {
+		return nil, false
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	attrs := make([]attribute.KeyValue, 0, len(m))
+	for _, k := range keys {
+		v, ok := jsonToValue(m[k])
+		if !ok {
+			v = attribute.StringValue(fmt.Sprint(m[k]))
+		}
+		attrs = append(attrs, attribute.KeyValue{Key: attribute.Key(k), Value: v})
+	}
+	return attrs, true
+}
+
+// tagToAttribute returns the attribute of the tag, typed according to its
+// value. It is the reverse of attributeToStringPair. A tag is only typed as a
+// number if formatting the number gives back the tag value, so values such as
+// "01234" or "1.10" are kept as strings.
+func tagToAttribute(k, v string) attribute.KeyValue {
+	key := attribute.Key(k)
+	switch {
+	case v == "true" || v == "false":
+		return key.Bool(v == "true")
+	case strings.HasPrefix(v, "["):
+		dec := json.NewDecoder(strings.NewReader(v))
+		dec.UseNumber()
+		var list []interface{}
+		if err := dec.Decode(&list); err == nil && !dec.More() {
+			if value, ok := jsonToValue(list); ok {
+				return attribute.KeyValue{Key: key, Value: value}
+			}
+		}
+	case isNumeric(v):
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil && strconv.FormatInt(i, 10) == v {
+			return key.Int64(i)
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil && strconv.FormatFloat(f, 'g', -1, 64) == v {
+			return key.Float64(f)
+		}
+	}
+	return key.String(v)
+}
+
+// isNumeric returns whether s looks like a number. It excludes the values
+// strconv.ParseFloat accepts without being numbers, such as "Inf" or "NaN".
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	c := s[0]
+	if c == '-' || c == '+' {
+		if len(s) == 1 {
+			return false
+		}
+		c = s[1]
+	}
+	return (c >= '0' && c <= '9') || c == '.'
+}
+
+// jsonToValue returns the attribute value of the decoded JSON value v, and
+// whether it can be represented by an attribute value. Lists must hold values
+// of a single type.
+func jsonToValue(v interface{}) (attribute.Value, bool) {
+	switch v := v.(type) {
+	case bool:
+		return attribute.BoolValue(v), true
+	case string:
+		return attribute.StringValue(v), true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return attribute.Int64Value(i), true
+		}
+		if f, err := v.Float64(); err == nil {
+			return attribute.Float64Value(f), true
+		}
+	case []interface{}:
+		return jsonListToValue(v)
+	}
+	return attribute.Value{}, false
+}
+
+func jsonListToValue(list []interface{}) (attribute.Value, bool) {
+	if len(list) == 0 {
+		return attribute.StringSliceValue([]string{}), true
+	}
+	switch list[0].(type) {
+	case bool:
+		out := make([]bool, 0, len(list))
+		for _, e := range list {
+			b, ok := e.(bool)
+			if !ok {
+				return attribute.Value{}, false
+			}
+			out = append(out, b)
+		}
+		return attribute.BoolSliceValue(out), true
+	case string:
+		out := make([]string, 0, len(list))
+		for _, e := range list {
+			s, ok := e.(string)
+			if !ok {
+				return attribute.Value{}, false
+			}
+			out = append(out, s)
+		}
+		return attribute.StringSliceValue(out), true
+	case json.Number:
+		ints := make([]int64, 0, len(list))
+		floats := make([]float64, 0, len(list))
+		isInt := true
+		for _, e := range list {
+			n, ok := e.(json.Number)
+			if !ok {
+				return attribute.Value{}, false
+			}
+			f, err := n.Float64()
+			if err != nil {
+				return attribute.Value{}, false
+			}
+			floats = append(floats, f)
+			if i, err := n.Int64(); err == nil && isInt {
+				ints = append(ints, i)
+			} else {
+				isInt = false
+			}
+		}
+		if isInt {
+			return attribute.Int64SliceValue(ints), true
+		}
+		return attribute.Float64SliceValue(floats), true
+	}
+	return attribute.Value{}, false
+}
+
+// hasRemoteEndpointTag returns whether the tags hold one of the attributes
+// the remote endpoint is derived from.
+func hasRemoteEndpointTag(tags map[string]string) bool {
+	for k := range remoteEndpointKeyRank {
+		if _, ok := tags[string(k)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// appendEndpointAttributes appends to attrs the attributes of the endpoint
+// that attrs does not already hold. The service name is ignored if nameKey is
+// empty.
+func appendEndpointAttributes(attrs []attribute.KeyValue, ep *zkmodel.Endpoint, nameKey, addrKey, portKey attribute.Key) []attribute.KeyValue {
+	has := func(k attribute.Key) bool {
+		return slices.ContainsFunc(attrs, func(kv attribute.KeyValue) bool { return kv.Key == k })
+	}
+	if nameKey != "" && ep.ServiceName != "" && !has(nameKey) {
+		attrs = append(attrs, nameKey.String(ep.ServiceName))
+	}
+	ip := ep.IPv4
+	if ip == nil {
+		ip = ep.IPv6
+	}
+	if len(ip) > 0 && !ip.IsUnspecified() && !has(addrKey) {
+		attrs = append(attrs, addrKey.String(ip.String()))
+		if ep.Port != 0 && !has(portKey) {
+			attrs = append(attrs, portKey.Int(int(ep.Port)))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zipkin
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	stubTraceID = trace.TraceID{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F}
+	stubStart   = time.Date(2020, time.March, 11, 19, 24, 0, 0, time.UTC)
+)
+
+// roundTripStub is a span stub SpanModels and SpanStubs convert back and
+// forth without loss.
+func roundTripStub() tracetest.SpanStub {
+	return tracetest.SpanStub{
+		Name: "foo",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    stubTraceID,
+			SpanID:     trace.SpanID{0xDF, 0xDE, 0xDD, 0xDC, 0xDB, 0xDA, 0xD9, 0xD8},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Parent: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    stubTraceID,
+			SpanID:     trace.SpanID{0xFF, 0xFE, 0xFD, 0xFC, 0xFB, 0xFA, 0xF9, 0xF8},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		SpanKind:  trace.SpanKindClient,
+		StartTime: stubStart,
+		EndTime:   stubStart.Add(time.Second),
+		Attributes: []attribute.KeyValue{
+			attribute.Bool("bool", true),
+			attribute.Float64("float", 1.5),
+			attribute.Int64("int", 42),
+			attribute.Int64Slice("ints", []int64{1, 2}),
+			semconv.NetworkPeerAddress("10.0.0.1"),
+			semconv.NetworkPeerPort(8080),
+			attribute.String("string", "value"),
+			attribute.StringSlice("strings", []string{"a", "b"}),
+		},
+		Events: []tracesdk.Event{
+			{Name: "no attributes", Time: stubStart},
+			{
+				Name: "with attributes",
+				Time: stubStart.Add(time.Millisecond),
+				Attributes: []attribute.KeyValue{
+					attribute.Bool("b", false),
+					attribute.Int64("i", 1),
+					attribute.String("s", "x"),
+				},
+			},
+		},
+		Status:                 tracesdk.Status{Code: codes.Error, Description: "failure"},
+		Resource:               resource.NewSchemaless(semconv.ServiceName("svc")),
+		InstrumentationLibrary: instrumentation.Scope{Name: "scope", Version: "v1"},
+	}
+}
+
+func TestSpanStubsRoundTrip(t *testing.T) {
+	want := roundTripStub()
+	models := SpanModels(tracetest.SpanStubs{want}.Snapshots())
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(models)
+		require.NoError(t, err)
+		got, err := SpanStubsFromJSON(data)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertSpanStub(t, want, got[0])
+	})
+
+	t.Run("Proto", func(t *testing.T) {
+		ptrs := []*zkmodel.SpanModel{&models[0]}
+		data, err := zipkin_proto3.SpanSerializer{}.Serialize(ptrs)
+		require.NoError(t, err)
+		got, err := SpanStubsFromProto(data)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertSpanStub(t, want, got[0])
+	})
+}
+
+func assertSpanStub(t *testing.T, want, got tracetest.SpanStub) {
+	t.Helper()
+	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.SpanContext, got.SpanContext)
+	assert.Equal(t, want.Parent, got.Parent)
+	assert.Equal(t, want.SpanKind, got.SpanKind)
+	assert.True(t, want.StartTime.Equal(got.StartTime), "start time")
+	assert.True(t, want.EndTime.Equal(got.EndTime), "end time")
+	assert.Equal(t, want.Attributes, got.Attributes)
+	require.Len(t, got.Events, len(want.Events))
+	for i := range want.Events {
+		assert.Equal(t, want.Events[i].Name, got.Events[i].Name)
+		assert.True(t, want.Events[i].Time.Equal(got.Events[i].Time), "event time")
+		assert.Equal(t, want.Events[i].Attributes, got.Events[i].Attributes)
+	}
+	assert.Equal(t, want.Status, got.Status)
+	assert.Equal(t, want.Resource, got.Resource)
+	assert.Equal(t, want.InstrumentationLibrary, got.InstrumentationLibrary)
+}
+
+func TestSpanStubsFromJSON(t *testing.T) {
+	data := []byte(`[{
+		"traceId": "000102030405060708090a0b0c0d0e0f",
+		"id": "fffefdfcfbfaf9f8",
+		"name": "get",
+		"kind": "SERVER",
+		"timestamp": 1583954640000000,
+		"duration": 1000,
+		"localEndpoint": {"serviceName": "frontend", "ipv4": "192.168.0.1", "port": 80},
+		"remoteEndpoint": {"serviceName": "client", "ipv6": "::2", "port": 5000},
+		"annotations": [{"timestamp": 1583954640000500, "value": "ws: not json"}],
+		"tags": {"otel.status_code": "OK", "otel.library.name": "legacy", "http.status_code": "200"}
+	}]`)
+
+	stubs, err := SpanStubsFromJSON(data)
+	require.NoError(t, err)
+	require.Len(t, stubs, 1)
+	got := stubs[0]
+
+	assert.Equal(t, stubTraceID, got.SpanContext.TraceID())
+	assert.True(t, got.SpanContext.IsSampled())
+	assert.False(t, got.Parent.IsValid())
+	assert.Equal(t, trace.SpanKindServer, got.SpanKind)
+	assert.Equal(t, time.Millisecond, got.EndTime.Sub(got.StartTime))
+	assert.Equal(t, tracesdk.Status{Code: codes.Ok}, got.Status)
+	assert.Equal(t, instrumentation.Scope{Name: "legacy"}, got.InstrumentationLibrary)
+	assert.Equal(t, resource.NewSchemaless(semconv.ServiceName("frontend")), got.Resource)
+	assert.Equal(t, []tracesdk.Event{{Name: "ws: not json", Time: got.StartTime.Add(500 * time.Microsecond)}}, got.Events)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64("http.status_code", 200),
+		semconv.PeerService("client"),
+		attribute.String("net.sock.peer.addr", "::2"),
+		attribute.Int("net.sock.peer.port", 5000),
+		semconv.ServerAddress("192.168.0.1"),
+		semconv.ServerPort(80),
+	}, got.Attributes)
+
+	_, err = SpanStubsFromJSON([]byte("{"))
+	assert.Error(t, err)
+}
+
+func TestSpanStubsNotSampled(t *testing.T) {
+	sampled := false
+	stubs := SpanStubs([]zkmodel.SpanModel{{
+		SpanContext: zkmodel.SpanContext{TraceID: zkmodel.TraceID{Low: 1}, ID: 1, Sampled: &sampled},
+	}})
+	require.Len(t, stubs, 1)
+	assert.False(t, stubs[0].SpanContext.IsSampled())
+	assert.Equal(t, trace.SpanKindInternal, stubs[0].SpanKind)
+}
+
+func TestTagToAttribute(t *testing.T) {
+	testCases := []struct {
+		value string
+		want  attribute.Value
+	}{
+		{"true", attribute.BoolValue(true)},
+		{"false", attribute.BoolValue(false)},
+		{"True", attribute.StringValue("True")},
+		{"-12", attribute.Int64Value(-12)},
+		{"1.25", attribute.Float64Value(1.25)},
+		{"1e+21", attribute.Float64Value(1e21)},
+		{"01234", attribute.StringValue("01234")},
+		{"-007", attribute.StringValue("-007")},
+		{"+5", attribute.StringValue("+5")},
+		{"1.10", attribute.StringValue("1.10")},
+		{"1.0", attribute.StringValue("1.0")},
+		{"0.50", attribute.StringValue("0.50")},
+		{".5", attribute.StringValue(".5")},
+		{"1e3", attribute.StringValue("1e3")},
+		{"99999999999999999999", attribute.StringValue("99999999999999999999")},
+		{"NaN", attribute.StringValue("NaN")},
+		{"Inf", attribute.StringValue("Inf")},
+		{"-", attribute.StringValue("-")},
+		{"1.2.3", attribute.StringValue("1.2.3")},
+		{"[true,false]", attribute.BoolSliceValue([]bool{true, false})},
+		{"[1,2]", attribute.Int64SliceValue([]int64{1, 2})},
+		{"[1,2.5]", attribute.Float64SliceValue([]float64{1, 2.5})},
+		{`["a","b"]`, attribute.StringSliceValue([]string{"a", "b"})},
+		{`[1,"a"]`, attribute.StringValue(`[1,"a"]`)},
+		{"[not json", attribute.StringValue("[not json")},
+		{"", attribute.StringValue("")},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			assert.Equal(t, attribute.KeyValue{Key: "k", Value: tc.want}, tagToAttribute("k", tc.value))
+		})
+	}
+}
+
+func TestAppendEndpointAttributesKeepsTags(t *testing.T) {
+	attrs := []attribute.KeyValue{semconv.PeerService("tag")}
+	got := appendEndpointAttributes(attrs, &zkmodel.Endpoint{ServiceName: "endpoint", IPv4: net.IPv4(10, 0, 0, 1)},
+		semconv.PeerServiceKey, semconv.NetworkPeerAddressKey, semconv.NetworkPeerPortKey)
+	assert.Equal(t, []attribute.KeyValue{semconv.PeerService("tag"), semconv.NetworkPeerAddress("10.0.0.1")}, got)
+}
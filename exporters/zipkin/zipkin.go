@@ -5,32 +5,80 @@ package zipkin // import "go.opentelemetry.io/otel/exporters/zipkin"
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/stdr"
+	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 
+	"go.opentelemetry.io/otel/exporters/zipkin/internal/retry"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 const (
 	defaultCollectorURL = "http://localhost:9411/api/v2/spans"
+	defaultTimeout      = 10 * time.Second
 )
 
+// Encoding is the encoding of the spans sent to the Zipkin collector.
+type Encoding int
+
+const (
+	// EncodingJSON encodes the spans with the Zipkin v2 JSON encoding.
+	EncodingJSON Encoding = iota
+	// EncodingProto encodes the spans with the Zipkin v2 protobuf encoding.
+	EncodingProto
+)
+
+// Compression is the compression of the requests sent to the Zipkin
+// collector.
+type Compression int
+
+const (
+	// NoCompression sends the requests without compression.
+	NoCompression Compression = iota
+	// GzipCompression compresses the requests with gzip.
+	GzipCompression
+)
+
+// RetryConfig defines configuration for retrying batches in case of export
+// failure using an exponential backoff.
+type RetryConfig retry.Config
+
+// retryableStatusCodes are the status codes of the responses retried.
+var retryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
 // Exporter exports spans to the zipkin collector.
 type Exporter struct {
-	url     string
-	client  *http.Client
-	logger  logr.Logger
-	headers map[string]string
+	url            string
+	client         *http.Client
+	logger         logr.Logger
+	headers        map[string]string
+	encoding       Encoding
+	compression    Compression
+	maxBatchSize   int
+	maxPayloadSize int
+	timeout        time.Duration
+	requestFunc    retry.RequestFunc
 
 	stoppedMu sync.RWMutex
 	stopped   bool
@@ -42,9 +90,15 @@ var emptyLogger = logr.Logger{}
 
 // Options contains configuration for the exporter.
 type config struct {
-	client  *http.Client
-	logger  logr.Logger
-	headers map[string]string
+	client         *http.Client
+	logger         logr.Logger
+	headers        map[string]string
+	encoding       Encoding
+	compression    Compression
+	maxBatchSize   int
+	maxPayloadSize int
+	timeout        *time.Duration
+	retry          *retry.Config
 }
 
 // Option defines a function that configures the exporter.
@@ -89,6 +143,74 @@ func WithClient(client *http.Client) Option {
 	})
 }
 
+// WithEncoding configures the encoding of the spans sent to the collector.
+// If this option is not used, the spans are encoded in JSON.
+func WithEncoding(encoding Encoding) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.encoding = encoding
+		return cfg
+	})
+}
+
+// WithCompression configures the compression of the requests sent to the
+// collector. If this option is not used, the requests are not compressed.
+func WithCompression(compression Compression) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.compression = compression
+		return cfg
+	})
+}
+
+// WithMaxBatchSize configures the maximum number of spans sent in a single
+// request. Larger batches are split into several requests. If this option
+// is not used, or if size is not positive, batches are not split by number
+// of spans.
+func WithMaxBatchSize(size int) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.maxBatchSize = size
+		return cfg
+	})
+}
+
+// WithMaxPayloadSize configures the maximum size, in bytes, of the encoded
+// spans sent in a single request, before compression. Batches exceeding it
+// are split into several requests, and single spans exceeding it are
+// dropped. If this option is not used, or if size is not positive, the
+// payload size is not limited.
+func WithMaxPayloadSize(size int) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.maxPayloadSize = size
+		return cfg
+	})
+}
+
+// WithTimeout configures the maximum amount of time an export of spans,
+// including its retries, can take.
+//
+// If this option is not used, the timeout is read from the
+// OTEL_EXPORTER_ZIPKIN_TIMEOUT environment variable, in milliseconds. If
+// neither is set, the timeout is 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.timeout = &timeout
+		return cfg
+	})
+}
+
+// WithRetry configures the retry policy for transient errors that may occur
+// when exporting spans: network errors and responses with the 429, 502, 503
+// and 504 status codes. An exponential back-off algorithm is used to ensure
+// endpoints are not overwhelmed with retries. If unset, the default retry
+// policy will retry after 5 seconds and increase exponentially after each
+// error for a total of 1 minute.
+func WithRetry(rc RetryConfig) Option {
+	return optionFunc(func(cfg config) config {
+		c := retry.Config(rc)
+		cfg.retry = &c
+		return cfg
+	})
+}
+
 // New creates a new Zipkin exporter.
 func New(collectorURL string, opts ...Option) (*Exporter, error) {
 	if collectorURL == "" {
@@ -111,11 +233,25 @@ func New(collectorURL string, opts ...Option) (*Exporter, error) {
 	if cfg.client == nil {
 		cfg.client = http.DefaultClient
 	}
+	timeout := envDurationOr(envTimeout, defaultTimeout)
+	if cfg.timeout != nil {
+		timeout = *cfg.timeout
+	}
+	rc := retry.DefaultConfig
+	if cfg.retry != nil {
+		rc = *cfg.retry
+	}
 	return &Exporter{
-		url:     collectorURL,
-		client:  cfg.client,
-		logger:  cfg.logger,
-		headers: cfg.headers,
+		url:            collectorURL,
+		client:         cfg.client,
+		logger:         cfg.logger,
+		headers:        cfg.headers,
+		encoding:       cfg.encoding,
+		compression:    cfg.compression,
+		maxBatchSize:   cfg.maxBatchSize,
+		maxPayloadSize: cfg.maxPayloadSize,
+		timeout:        timeout,
+		requestFunc:    rc.RequestFunc(evaluate),
 	}, nil
 }
 
@@ -133,46 +269,152 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpa
 		e.logf("no spans to export")
 		return nil
 	}
+
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
 	models := SpanModels(spans)
-	body, err := json.Marshal(models)
-	if err != nil {
-		return e.errf("failed to serialize zipkin models to JSON: %v", err)
+	batchSize := e.maxBatchSize
+	if batchSize <= 0 {
+		batchSize = len(models)
+	}
+	var errs []error
+	for start := 0; start < len(models); start += batchSize {
+		end := min(start+batchSize, len(models))
+		payloads, err := e.marshal(models[start:end], nil)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, body := range payloads {
+			if err := e.send(ctx, body); err != nil {
+				// The following requests would most likely fail too.
+				return errors.Join(append(errs, err)...)
+			}
+		}
 	}
-	e.logf("about to send a POST request to %s with body %s", e.url, body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewBuffer(body))
+	return errors.Join(errs...)
+}
+
+// marshal appends the encoded models to payloads. The models are split in
+// halves until their encoding fits in the maximum payload size, models that
+// do not fit on their own are dropped.
+func (e *Exporter) marshal(models []zkmodel.SpanModel, payloads [][]byte) ([][]byte, error) {
+	body, err := e.encode(models)
 	if err != nil {
-		return e.errf("failed to create request to %s: %v", e.url, err)
+		return payloads, e.errf("failed to serialize zipkin models: %v", err)
+	}
+	if e.maxPayloadSize <= 0 || len(body) <= e.maxPayloadSize {
+		return append(payloads, body), nil
+	}
+	if len(models) == 1 {
+		return payloads, e.errf("span %s exceeds the maximum payload size: %d > %d bytes", models[0].ID, len(body), e.maxPayloadSize)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	for k, v := range e.headers {
-		if strings.ToLower(k) == "host" {
-			req.Host = v
-		} else {
-			req.Header.Set(k, v)
+	half := len(models) / 2
+	payloads, err = e.marshal(models[:half], payloads)
+	var err2 error
+	payloads, err2 = e.marshal(models[half:], payloads)
+	return payloads, errors.Join(err, err2)
+}
+
+// encode returns the models encoded with the encoding of the exporter.
+func (e *Exporter) encode(models []zkmodel.SpanModel) ([]byte, error) {
+	if e.encoding == EncodingProto {
+		ptrs := make([]*zkmodel.SpanModel, len(models))
+		for i := range models {
+			ptrs[i] = &models[i]
 		}
+		return zipkin_proto3.SpanSerializer{}.Serialize(ptrs)
 	}
+	return json.Marshal(models)
+}
 
-	resp, err := e.client.Do(req)
-	if err != nil {
-		return e.errf("request to %s failed: %v", e.url, err)
+// contentType returns the content type of the encoding of the exporter.
+func (e *Exporter) contentType() string {
+	if e.encoding == EncodingProto {
+		return zipkin_proto3.SpanSerializer{}.ContentType()
 	}
-	defer resp.Body.Close()
+	return "application/json"
+}
 
-	// Zipkin API returns a 202 on success and the content of the body isn't interesting
-	// but it is still being read because according to https://golang.org/pkg/net/http/#Response
-	// > The default HTTP client's Transport may not reuse HTTP/1.x "keep-alive" TCP connections
-	// > if the Body is not read to completion and closed.
-	_, err = io.Copy(io.Discard, resp.Body)
-	if err != nil {
-		return e.errf("failed to read response body: %v", err)
+// send sends the encoded spans in body to the collector, retrying on
+// transient errors.
+func (e *Exporter) send(ctx context.Context, body []byte) error {
+	if e.encoding == EncodingJSON {
+		e.logf("about to send a POST request to %s with body %s", e.url, body)
+	} else {
+		e.logf("about to send a POST request to %s with a body of %d bytes", e.url, len(body))
 	}
+	contentEncoding := ""
+	if e.compression == GzipCompression {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(body); err != nil {
+			return e.errf("failed to compress request body: %v", err)
+		}
+		if err := gz.Close(); err != nil {
+			return e.errf("failed to compress request body: %v", err)
+		}
+		body, contentEncoding = buf.Bytes(), "gzip"
+	}
+
+	do := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request to %s: %v", e.url, err)
+		}
+		req.Header.Set("Content-Type", e.contentType())
+		if contentEncoding != "" {
+			req.Header.Set("Content-Encoding", contentEncoding)
+		}
+
+		for k, v := range e.headers {
+			if strings.ToLower(k) == "host" {
+				req.Host = v
+			} else {
+				req.Header.Set(k, v)
+			}
+		}
+
+		resp, err := e.client.Do(req)
+		if err != nil {
+			return newRetryableError(nil, fmt.Errorf("request to %s failed: %v", e.url, err))
+		}
+		defer resp.Body.Close()
+
+		// Zipkin API returns a 202 on success and the content of the body isn't interesting
+		// but it is still being read because according to https://golang.org/pkg/net/http/#Response
+		// > The default HTTP client's Transport may not reuse HTTP/1.x "keep-alive" TCP connections
+		// > if the Body is not read to completion and closed.
+		_, err = io.Copy(io.Discard, resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %v", err)
+		}
 
-	if resp.StatusCode != http.StatusAccepted {
-		return e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode)
+		if resp.StatusCode != http.StatusAccepted {
+			err := fmt.Errorf("failed to send spans to zipkin server with status %d", resp.StatusCode)
+			if slices.Contains(retryableStatusCodes, resp.StatusCode) {
+				return newRetryableError(resp.Header, err)
+			}
+			return err
+		}
+		return nil
 	}
 
-	return nil
+	var err error
+	if e.requestFunc == nil {
+		err = do(ctx)
+	} else {
+		err = e.requestFunc(ctx, do)
+	}
+	// Log the error of the last attempt only, not the retried ones.
+	if err != nil {
+		e.logf("%v", err)
+	}
+	return err
 }
 
 // Shutdown stops the exporter flushing any pending exports.
@@ -189,6 +431,40 @@ func (e *Exporter) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// retryableError is a request failure that can be retried.
+type retryableError struct {
+	throttle time.Duration
+	err      error
+}
+
+// newRetryableError returns a retryableError wrapping err, with the throttle
+// delay of the Retry-After header, if any.
+func newRetryableError(header http.Header, err error) error {
+	rErr := retryableError{err: err}
+	if s, err := strconv.Atoi(header.Get("Retry-After")); err == nil && s > 0 {
+		rErr.throttle = time.Duration(s) * time.Second
+	}
+	return rErr
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e retryableError) Unwrap() error {
+	return e.err
+}
+
+// evaluate returns if err is retry-able. If it is and it includes an explicit
+// throttling delay, that delay is also returned.
+func evaluate(err error) (bool, time.Duration) {
+	var rErr retryableError
+	if !errors.As(err, &rErr) {
+		return false, 0
+	}
+	return true, rErr.throttle
+}
+
 func (e *Exporter) logf(format string, args ...interface{}) {
 	if e.logger != emptyLogger {
 		e.logger.Info(fmt.Sprintf(format, args...))
@@ -5,6 +5,7 @@ package zipkin
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/go-logr/logr/funcr"
 	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -421,3 +423,209 @@ func TestWithHeaders(t *testing.T) {
 	assert.Equal(t, headers["name1"], req.Header.Get("name1"))
 	assert.Equal(t, headers["name2"], req.Header.Get("name2"))
 }
+
+// request is a request received by a recordingCollector.
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+// recordingCollector is a Zipkin collector recording the requests it
+// receives. It responds with the statuses in order, then with 202.
+type recordingCollector struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []request
+	statuses []int
+}
+
+func newRecordingCollector(t *testing.T, statuses ...int) *recordingCollector {
+	c := &recordingCollector{statuses: statuses}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.requests = append(c.requests, request{header: r.Header, body: body})
+		status := http.StatusAccepted
+		if len(c.statuses) > 0 {
+			status, c.statuses = c.statuses[0], c.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *recordingCollector) Requests() []request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requests
+}
+
+func testSpans(n int) []sdktrace.ReadOnlySpan {
+	stubs := make(tracetest.SpanStubs, n)
+	for i := range stubs {
+		stubs[i] = tracetest.SpanStub{
+			Name: fmt.Sprintf("span-%d", i),
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: trace.TraceID{0x01},
+				SpanID:  trace.SpanID{byte(i + 1)},
+			}),
+			StartTime: time.Unix(1700000000, 0),
+			EndTime:   time.Unix(1700000001, 0),
+		}
+	}
+	return stubs.Snapshots()
+}
+
+func TestExportSpansProtoEncoding(t *testing.T) {
+	collector := newRecordingCollector(t)
+	ls := &logStore{T: t}
+	exp, err := New(collector.URL, WithEncoding(EncodingProto), WithLogger(logStoreLogger(ls)))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.ExportSpans(context.Background(), testSpans(2)))
+	require.Len(t, ls.Messages, 1)
+	assert.NotContains(t, ls.Messages[0], "span-0", "binary body not logged")
+
+	reqs := collector.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "application/x-protobuf", reqs[0].header.Get("Content-Type"))
+	models, err := zipkin_proto3.ParseSpans(reqs[0].body, false)
+	require.NoError(t, err)
+	require.Len(t, models, 2)
+	assert.Equal(t, "span-0", models[0].Name)
+	assert.Equal(t, "span-1", models[1].Name)
+}
+
+func TestExportSpansGzipCompression(t *testing.T) {
+	collector := newRecordingCollector(t)
+	exp, err := New(collector.URL, WithCompression(GzipCompression))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.ExportSpans(context.Background(), testSpans(1)))
+
+	reqs := collector.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "gzip", reqs[0].header.Get("Content-Encoding"))
+	gz, err := gzip.NewReader(bytes.NewReader(reqs[0].body))
+	require.NoError(t, err)
+	var models []zkmodel.SpanModel
+	require.NoError(t, json.NewDecoder(gz).Decode(&models))
+	require.Len(t, models, 1)
+	assert.Equal(t, "span-0", models[0].Name)
+}
+
+func TestExportSpansMaxBatchSize(t *testing.T) {
+	collector := newRecordingCollector(t)
+	exp, err := New(collector.URL, WithMaxBatchSize(2))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.ExportSpans(context.Background(), testSpans(5)))
+
+	var sizes []int
+	for _, r := range collector.Requests() {
+		var models []zkmodel.SpanModel
+		require.NoError(t, json.Unmarshal(r.body, &models))
+		sizes = append(sizes, len(models))
+	}
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+}
+
+func TestExportSpansMaxPayloadSize(t *testing.T) {
+	spans := testSpans(4)
+	one, err := json.Marshal(SpanModels(spans[:1]))
+	require.NoError(t, err)
+
+	collector := newRecordingCollector(t)
+	// Two spans fit in a payload, not three.
+	exp, err := New(collector.URL, WithMaxPayloadSize(2*len(one)))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+	reqs := collector.Requests()
+	require.Len(t, reqs, 2)
+	for _, r := range reqs {
+		assert.LessOrEqual(t, len(r.body), 2*len(one))
+	}
+
+	exp, err = New(collector.URL, WithMaxPayloadSize(len(one)/2))
+	require.NoError(t, err)
+	assert.ErrorContains(t, exp.ExportSpans(context.Background(), spans[:1]), "exceeds the maximum payload size")
+	assert.Len(t, collector.Requests(), 2, "span too large dropped")
+}
+
+func TestExportSpansRetry(t *testing.T) {
+	rc := RetryConfig{
+		Enabled:         true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Minute,
+	}
+
+	t.Run("Retryable", func(t *testing.T) {
+		collector := newRecordingCollector(t, http.StatusTooManyRequests, http.StatusServiceUnavailable)
+		exp, err := New(collector.URL, WithRetry(rc))
+		require.NoError(t, err)
+
+		require.NoError(t, exp.ExportSpans(context.Background(), testSpans(1)))
+		assert.Len(t, collector.Requests(), 3)
+	})
+
+	t.Run("NonRetryable", func(t *testing.T) {
+		collector := newRecordingCollector(t, http.StatusBadRequest)
+		exp, err := New(collector.URL, WithRetry(rc))
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, exp.ExportSpans(context.Background(), testSpans(1)), "400")
+		assert.Len(t, collector.Requests(), 1)
+	})
+
+	t.Run("LogLastError", func(t *testing.T) {
+		collector := newRecordingCollector(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusBadRequest)
+		ls := &logStore{T: t}
+		exp, err := New(collector.URL, WithRetry(rc), WithLogger(logStoreLogger(ls)))
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, exp.ExportSpans(context.Background(), testSpans(1)), "400")
+		assert.Len(t, collector.Requests(), 3)
+		require.Len(t, ls.Messages, 2)
+		assert.Contains(t, ls.Messages[0], "send a POST request")
+		assert.Contains(t, ls.Messages[1], "400")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		collector := newRecordingCollector(t, http.StatusServiceUnavailable)
+		exp, err := New(collector.URL, WithRetry(RetryConfig{Enabled: false}))
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, exp.ExportSpans(context.Background(), testSpans(1)), "503")
+		assert.Len(t, collector.Requests(), 1)
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	exp, err := New("")
+	require.NoError(t, err)
+	assert.Equal(t, defaultTimeout, exp.timeout)
+
+	t.Setenv(envTimeout, "2000")
+	exp, err = New("")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, exp.timeout)
+
+	exp, err = New("", WithTimeout(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, exp.timeout, "option overrides environment")
+
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-block }))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(block) })
+	exp, err = New(srv.URL, WithTimeout(10*time.Millisecond), WithRetry(RetryConfig{}))
+	require.NoError(t, err)
+	assert.ErrorContains(t, exp.ExportSpans(context.Background(), testSpans(1)), context.DeadlineExceeded.Error())
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest // import "go.opentelemetry.io/otel/sdk/goldentest"
+
+import "go.opentelemetry.io/otel/attribute"
+
+// Format is the serialization format of a snapshot.
+type Format int
+
+const (
+	// FormatText is a YAML-like human-readable format.
+	FormatText Format = iota
+	// FormatJSON is an indented JSON format.
+	FormatJSON
+)
+
+// maskedValue replaces the masked values of a snapshot.
+const maskedValue = "<masked>"
+
+type config struct {
+	format          Format
+	masked          map[attribute.Key]struct{}
+	maskValues      bool
+	withoutResource bool
+}
+
+func newConfig(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option allows for fine grain control over how snapshots are serialized.
+type Option interface {
+	apply(cfg config) config
+}
+
+type fnOption func(cfg config) config
+
+func (fn fnOption) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithFormat sets the serialization format of the snapshots. By default,
+// FormatText is used.
+func WithFormat(format Format) Option {
+	return fnOption(func(cfg config) config {
+		cfg.format = format
+		return cfg
+	})
+}
+
+// WithMaskedAttributes masks the values of the attributes with the keys.
+// This can be useful for non-deterministic attributes, like host names or
+// port numbers. It applies to the attributes of resources, spans, events,
+// links, data points, exemplars and log records.
+func WithMaskedAttributes(keys ...attribute.Key) Option {
+	return fnOption(func(cfg config) config {
+		if cfg.masked == nil {
+			cfg.masked = make(map[attribute.Key]struct{}, len(keys))
+		}
+		for _, k := range keys {
+			cfg.masked[k] = struct{}{}
+		}
+		return cfg
+	})
+}
+
+// WithMaskedValues masks the values of metric data points. This can be
+// useful for non-deterministic values, like measured durations.
+//
+// This will mask the value of data points and exemplars; the sum, count,
+// min, max and bucket counts of histogram data points; the sum, count, zero
+// count, scale and buckets of exponential histogram data points; the sum,
+// count and quantile values of summary data points.
+func WithMaskedValues() Option {
+	return fnOption(func(cfg config) config {
+		cfg.maskValues = true
+		return cfg
+	})
+}
+
+// WithoutResource omits the resources from the snapshots. This can be
+// useful as the default resource depends on the environment and on the
+// version of the SDK.
+func WithoutResource() Option {
+	return fnOption(func(cfg config) config {
+		cfg.withoutResource = true
+		return cfg
+	})
+}
+
+func (c config) isMasked(k attribute.Key) bool {
+	_, ok := c.masked[k]
+	return ok
+}
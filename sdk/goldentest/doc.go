@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package goldentest provides golden file snapshot assertions for the
+telemetry exported by the OpenTelemetry SDKs.
+
+The spans, metrics and log records captured in a test (e.g. with the
+tracetest.InMemoryExporter, metrictest.InMemoryExporter or
+logtest.InMemoryExporter) are serialized into a normalized snapshot and
+compared with the content of a golden file:
+
+  - Timestamps are dropped.
+  - Trace and span IDs are replaced with placeholders (trace-1, span-1, ...)
+    assigned in the order of the snapshot, so the relationships between
+    spans, links and log records are preserved.
+  - Telemetry is grouped by resource and instrumentation scope, and sorted
+    by content instead of by the order in which it was recorded.
+
+Snapshots are written in a YAML-like text format by default, or as JSON
+with [WithFormat].
+
+Golden files are created or updated with the content of the snapshots,
+instead of being compared, when the tests are run with the
+-goldentest.update flag:
+
+	go test ./... -goldentest.update
+*/
+package goldentest // import "go.opentelemetry.io/otel/sdk/goldentest"
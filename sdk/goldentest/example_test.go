@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest_test
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/sdk/goldentest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func ExampleSpans() {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	tracer := tp.Tracer("example")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	// In a test, use goldentest.AssertSpans to compare the snapshot with
+	// a golden file instead.
+	fmt.Print(string(goldentest.Spans(exp.GetSpans(), goldentest.WithoutResource())))
+	// Output:
+	// - scopes:
+	//     - scope:
+	//         name: "example"
+	//       spans:
+	//         - trace_id: "trace-1"
+	//           span_id: "span-1"
+	//           name: "parent"
+	//           kind: "internal"
+	//           child_span_count: 1
+	//         - trace_id: "trace-1"
+	//           span_id: "span-2"
+	//           parent_span_id: "span-1"
+	//           name: "child"
+	//           kind: "internal"
+}
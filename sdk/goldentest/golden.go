@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest // import "go.opentelemetry.io/otel/sdk/goldentest"
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// updateFlag is the name of the flag updating the golden files.
+const updateFlag = "goldentest.update"
+
+var update = flag.Bool(updateFlag, false, "update the golden files with the snapshots of the tests")
+
+// TestingT is an interface that implements [testing.T], but without the
+// private method of [testing.TB], so other testing packages can rely on it as
+// well.
+// The methods in this interface must match the [testing.TB] interface.
+type TestingT interface {
+	Helper()
+	// DO NOT CHANGE: any modification will not be backwards compatible and
+	// must never be done outside of a new major release.
+
+	Error(...any)
+	// DO NOT CHANGE: any modification will not be backwards compatible and
+	// must never be done outside of a new major release.
+}
+
+// AssertSpans asserts that the snapshot of spans matches the golden file.
+func AssertSpans(t TestingT, golden string, spans tracetest.SpanStubs, opts ...Option) bool {
+	t.Helper()
+	return Assert(t, golden, Spans(spans, opts...))
+}
+
+// AssertMetrics asserts that the snapshot of rm matches the golden file.
+func AssertMetrics(t TestingT, golden string, rm metricdata.ResourceMetrics, opts ...Option) bool {
+	t.Helper()
+	return Assert(t, golden, Metrics(rm, opts...))
+}
+
+// AssertLogs asserts that the snapshot of records matches the golden file.
+func AssertLogs(t TestingT, golden string, records []sdklog.Record, opts ...Option) bool {
+	t.Helper()
+	return Assert(t, golden, Logs(records, opts...))
+}
+
+// Assert asserts that snapshot matches the content of the golden file.
+//
+// If the tests are run with the -goldentest.update flag, the golden file is
+// written with snapshot instead, creating it and its directory if needed.
+func Assert(t TestingT, golden string, snapshot []byte) bool {
+	t.Helper()
+
+	if *update {
+		if err := write(golden, snapshot); err != nil {
+			t.Error(err)
+			return false
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Error(fmt.Sprintf("golden file %s does not exist, run the tests with -%s to create it", golden, updateFlag))
+			return false
+		}
+		t.Error(fmt.Errorf("cannot read the golden file: %w", err))
+		return false
+	}
+	// Golden files may have been checked out with Windows line endings.
+	want = bytes.ReplaceAll(want, []byte("\r\n"), []byte("\n"))
+	if bytes.Equal(want, snapshot) {
+		return true
+	}
+
+	t.Error(fmt.Sprintf(
+		"snapshot does not match the golden file %s, run the tests with -%s to update it\n%s",
+		golden, updateFlag, diff(string(want), string(snapshot)),
+	))
+	return false
+}
+
+func write(golden string, snapshot []byte) error {
+	if err := os.MkdirAll(filepath.Dir(golden), 0o750); err != nil {
+		return fmt.Errorf("cannot create the golden file directory: %w", err)
+	}
+	if err := os.WriteFile(golden, snapshot, 0o600); err != nil {
+		return fmt.Errorf("cannot write the golden file: %w", err)
+	}
+	return nil
+}
+
+// diff returns the lines removed from want, prefixed with "-", and added
+// to got, prefixed with "+", based on their longest common subsequence.
+// Unchanged lines are prefixed with a space.
+func diff(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var buf strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			buf.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			buf.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			buf.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+	return buf.String()
+}
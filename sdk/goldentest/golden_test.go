@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testingT records the errors of assertions.
+type testingT struct {
+	errors []string
+}
+
+func (*testingT) Helper() {}
+
+func (t *testingT) Error(args ...any) {
+	t.errors = append(t.errors, fmt.Sprint(args...))
+}
+
+func setUpdate(t *testing.T, v bool) {
+	prev := *update
+	*update = v
+	t.Cleanup(func() { *update = prev })
+}
+
+func TestAssert(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "golden.txt")
+	require.NoError(t, os.WriteFile(golden, []byte("a\r\nb\r\nc\r\n"), 0o600))
+
+	mockT := new(testingT)
+	assert.True(t, Assert(mockT, golden, []byte("a\nb\nc\n")), "Windows line endings")
+	assert.Empty(t, mockT.errors)
+
+	assert.False(t, Assert(mockT, golden, []byte("a\nc\nd\n")))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "snapshot does not match the golden file")
+	assert.Contains(t, mockT.errors[0], "  a\n- b\n  c\n+ d\n")
+}
+
+func TestAssertMissing(t *testing.T) {
+	mockT := new(testingT)
+	assert.False(t, Assert(mockT, filepath.Join(t.TempDir(), "missing.txt"), []byte("a\n")))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "-goldentest.update")
+}
+
+func TestAssertUpdate(t *testing.T) {
+	setUpdate(t, true)
+	golden := filepath.Join(t.TempDir(), "dir", "golden.txt")
+
+	mockT := new(testingT)
+	assert.True(t, Assert(mockT, golden, []byte("a\n")))
+	assert.Empty(t, mockT.errors)
+
+	got, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, "a\n", string(got))
+}
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name      string
+		want, got string
+		diff      string
+	}{
+		{
+			name: "Equal",
+			want: "a\nb\n",
+			got:  "a\nb\n",
+			diff: "  a\n  b\n",
+		},
+		{
+			name: "Added",
+			want: "a\n",
+			got:  "a\nb\n",
+			diff: "  a\n+ b\n",
+		},
+		{
+			name: "Removed",
+			want: "a\nb\n",
+			got:  "b\n",
+			diff: "- a\n  b\n",
+		},
+		{
+			name: "Changed",
+			want: "a\nb\nc\n",
+			got:  "a\nB\nc\n",
+			diff: "  a\n- b\n+ B\n  c\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.diff, diff(tc.want, tc.got))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest // import "go.opentelemetry.io/otel/sdk/goldentest"
+
+import (
+	"cmp"
+	"encoding/base64"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// Logs returns the snapshot of records.
+//
+// Log records are sorted by content, their attributes by key.
+func Logs(records []sdklog.Record, opts ...Option) []byte {
+	cfg := newConfig(opts)
+
+	type keyed struct {
+		key     string
+		content object
+		record  *sdklog.Record
+	}
+	items := make([]keyed, len(records))
+	for i := range records {
+		r := &records[i]
+		content := cfg.logContent(r)
+		items[i] = keyed{key: key(content), content: content, record: r}
+	}
+	groups := groupByScope(items,
+		func(k keyed) object {
+			res := k.record.Resource()
+			return cfg.resource(&res)
+		},
+		func(k keyed) object { return scope(k.record.InstrumentationScope()) },
+	)
+
+	ids := newIDs()
+	return encode(nest(groups, "log_records", func(items []keyed) []any {
+		slices.SortStableFunc(items, func(a, b keyed) int {
+			return cmp.Compare(a.key, b.key)
+		})
+		out := make([]any, len(items))
+		for i, k := range items {
+			var o object
+			o.add("trace_id", ids.traceID(k.record.TraceID()))
+			o.add("span_id", ids.spanID(k.record.SpanID()))
+			if k.record.TraceFlags().IsSampled() {
+				o.add("sampled", true)
+			}
+			out[i] = append(o, k.content...)
+		}
+		return out
+	}), cfg.format)
+}
+
+// logContent returns the snapshot of r without its timestamps and trace
+// context.
+func (c config) logContent(r *sdklog.Record) object {
+	var o object
+	if s := r.Severity(); s != log.SeverityUndefined {
+		o.add("severity", s.String())
+	}
+	o.add("severity_text", r.SeverityText())
+	o.add("body", logValue(r.Body()))
+
+	var attrs object
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		var v any = maskedValue
+		if !c.isMasked(attribute.Key(kv.Key)) {
+			v = logValue(kv.Value)
+		}
+		attrs = append(attrs, member{key: kv.Key, value: v})
+		return true
+	})
+	slices.SortStableFunc(attrs, func(a, b member) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	o.add("attributes", attrs)
+	o.addCount("dropped_attributes", r.DroppedAttributes())
+	o.addCount("dropped_body_values", r.DroppedBodyValues())
+	return o
+}
+
+// logValue returns the snapshot value of v.
+func logValue(v log.Value) any {
+	switch v.Kind() {
+	case log.KindBool:
+		return v.AsBool()
+	case log.KindInt64:
+		return v.AsInt64()
+	case log.KindFloat64:
+		return v.AsFloat64()
+	case log.KindString:
+		return v.AsString()
+	case log.KindBytes:
+		return base64.StdEncoding.EncodeToString(v.AsBytes())
+	case log.KindSlice:
+		s := v.AsSlice()
+		out := make([]any, len(s))
+		for i, e := range s {
+			out[i] = logValue(e)
+		}
+		return out
+	case log.KindMap:
+		m := v.AsMap()
+		out := make(object, len(m))
+		for i, kv := range m {
+			out[i] = member{key: kv.Key, value: logValue(kv.Value)}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/log/logtest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func logRecords() []sdklog.Record {
+	res := resource.NewSchemaless(attribute.String("service.name", "svc"))
+	scope := &instrumentation.Scope{Name: "logger"}
+	return []sdklog.Record{
+		logtest.RecordFactory{
+			Timestamp:            time.Now(),
+			Severity:             log.SeverityError,
+			SeverityText:         "ERROR",
+			Body:                 log.StringValue("request failed"),
+			Attributes:           []log.KeyValue{log.Int("status", 500), log.String("path", "/users")},
+			TraceID:              trace.TraceID{1},
+			SpanID:               trace.SpanID{1},
+			TraceFlags:           trace.FlagsSampled,
+			Resource:             res,
+			InstrumentationScope: scope,
+		}.NewRecord(),
+		logtest.RecordFactory{
+			Timestamp: time.Now().Add(time.Second),
+			Severity:  log.SeverityInfo,
+			Body: log.MapValue(
+				log.String("message", "started"),
+				log.Slice("ports", log.IntValue(80), log.IntValue(443)),
+				log.Bytes("token", []byte("abc")),
+			),
+			Attributes:           []log.KeyValue{log.String("request.id", "1234")},
+			Resource:             res,
+			InstrumentationScope: scope,
+		}.NewRecord(),
+	}
+}
+
+func TestLogs(t *testing.T) {
+	records := logRecords()
+	opts := []Option{WithMaskedAttributes("request.id")}
+	AssertLogs(t, "testdata/logs.txt", records, opts...)
+
+	reversed := slices.Clone(records)
+	slices.Reverse(reversed)
+	assert.Equal(t, string(Logs(records, opts...)), string(Logs(reversed, opts...)), "order")
+}
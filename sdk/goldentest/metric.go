@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest // import "go.opentelemetry.io/otel/sdk/goldentest"
+
+import (
+	"cmp"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Metrics returns the snapshot of rm.
+//
+// Scopes are sorted by name, metrics by name and data points by
+// attributes.
+func Metrics(rm metricdata.ResourceMetrics, opts ...Option) []byte {
+	cfg := newConfig(opts)
+	ids := newIDs()
+
+	scopes := slices.Clone(rm.ScopeMetrics)
+	slices.SortStableFunc(scopes, func(a, b metricdata.ScopeMetrics) int {
+		return cmp.Compare(key(scope(a.Scope)), key(scope(b.Scope)))
+	})
+	scopesOut := make([]any, 0, len(scopes))
+	for _, sm := range scopes {
+		metrics := slices.Clone(sm.Metrics)
+		slices.SortStableFunc(metrics, func(a, b metricdata.Metrics) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+		metricsOut := make([]any, 0, len(metrics))
+		for _, m := range metrics {
+			metricsOut = append(metricsOut, metric(cfg, m, ids))
+		}
+
+		var o object
+		o.add("scope", scope(sm.Scope))
+		o.add("metrics", metricsOut)
+		scopesOut = append(scopesOut, o)
+	}
+
+	var o object
+	o.add("resource", cfg.resource(rm.Resource))
+	o.add("scopes", scopesOut)
+	return encode([]any{o}, cfg.format)
+}
+
+func metric(c config, m metricdata.Metrics, ids *ids) object {
+	var o object
+	o.add("name", m.Name)
+	o.add("description", m.Description)
+	o.add("unit", m.Unit)
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		o.add("gauge", gauge(c, data, ids))
+	case metricdata.Gauge[float64]:
+		o.add("gauge", gauge(c, data, ids))
+	case metricdata.Sum[int64]:
+		o.add("sum", sum(c, data, ids))
+	case metricdata.Sum[float64]:
+		o.add("sum", sum(c, data, ids))
+	case metricdata.Histogram[int64]:
+		o.add("histogram", histogram(c, data, ids))
+	case metricdata.Histogram[float64]:
+		o.add("histogram", histogram(c, data, ids))
+	case metricdata.ExponentialHistogram[int64]:
+		o.add("exponential_histogram", exponentialHistogram(c, data, ids))
+	case metricdata.ExponentialHistogram[float64]:
+		o.add("exponential_histogram", exponentialHistogram(c, data, ids))
+	case metricdata.Summary:
+		o.add("summary", object{{"data_points", dataPoints(c, data.DataPoints,
+			func(dp metricdata.SummaryDataPoint) attribute.Set { return dp.Attributes },
+			func(dp metricdata.SummaryDataPoint) object { return summaryDataPoint(c, dp) },
+		)}})
+	}
+	return o
+}
+
+func gauge[N int64 | float64](c config, g metricdata.Gauge[N], ids *ids) object {
+	return object{{"data_points", numberDataPoints(c, g.DataPoints, ids)}}
+}
+
+func sum[N int64 | float64](c config, s metricdata.Sum[N], ids *ids) object {
+	return object{
+		{"temporality", s.Temporality.String()},
+		{"monotonic", s.IsMonotonic},
+		{"data_points", numberDataPoints(c, s.DataPoints, ids)},
+	}
+}
+
+func histogram[N int64 | float64](c config, h metricdata.Histogram[N], ids *ids) object {
+	return object{
+		{"temporality", h.Temporality.String()},
+		{"data_points", dataPoints(c, h.DataPoints,
+			func(dp metricdata.HistogramDataPoint[N]) attribute.Set { return dp.Attributes },
+			func(dp metricdata.HistogramDataPoint[N]) object { return histogramDataPoint(c, dp, ids) },
+		)},
+	}
+}
+
+func exponentialHistogram[N int64 | float64](c config, h metricdata.ExponentialHistogram[N], ids *ids) object {
+	return object{
+		{"temporality", h.Temporality.String()},
+		{"data_points", dataPoints(c, h.DataPoints,
+			func(dp metricdata.ExponentialHistogramDataPoint[N]) attribute.Set { return dp.Attributes },
+			func(dp metricdata.ExponentialHistogramDataPoint[N]) object {
+				return exponentialHistogramDataPoint(c, dp, ids)
+			},
+		)},
+	}
+}
+
+// dataPoints returns the snapshots of points sorted by attributes.
+func dataPoints[T any](c config, points []T, attrs func(T) attribute.Set, snapshot func(T) object) []any {
+	type keyed struct {
+		key   string
+		point T
+	}
+	sorted := make([]keyed, len(points))
+	for i, dp := range points {
+		set := attrs(dp)
+		sorted[i] = keyed{key: key(c.attributes(set.ToSlice()...)), point: dp}
+	}
+	slices.SortStableFunc(sorted, func(a, b keyed) int {
+		return cmp.Compare(a.key, b.key)
+	})
+
+	out := make([]any, len(sorted))
+	for i, k := range sorted {
+		out[i] = snapshot(k.point)
+	}
+	return out
+}
+
+func numberDataPoints[N int64 | float64](c config, points []metricdata.DataPoint[N], ids *ids) []any {
+	return dataPoints(c, points,
+		func(dp metricdata.DataPoint[N]) attribute.Set { return dp.Attributes },
+		func(dp metricdata.DataPoint[N]) object {
+			var o object
+			o.add("attributes", c.attributes(dp.Attributes.ToSlice()...))
+			o.add("value", c.value(dp.Value))
+			o.add("exemplars", exemplars(c, dp.Exemplars, ids))
+			return o
+		},
+	)
+}
+
+func histogramDataPoint[N int64 | float64](c config, dp metricdata.HistogramDataPoint[N], ids *ids) object {
+	var o object
+	o.add("attributes", c.attributes(dp.Attributes.ToSlice()...))
+	o.add("count", c.value(dp.Count))
+	o.add("sum", c.value(dp.Sum))
+	if v, ok := dp.Min.Value(); ok {
+		o.add("min", c.value(v))
+	}
+	if v, ok := dp.Max.Value(); ok {
+		o.add("max", c.value(v))
+	}
+	o.add("bounds", sliceValue(dp.Bounds))
+	if c.maskValues {
+		o.add("bucket_counts", maskedValue)
+	} else {
+		o.add("bucket_counts", sliceValue(dp.BucketCounts))
+	}
+	o.add("exemplars", exemplars(c, dp.Exemplars, ids))
+	return o
+}
+
+func exponentialHistogramDataPoint[N int64 | float64](c config, dp metricdata.ExponentialHistogramDataPoint[N], ids *ids) object {
+	var o object
+	o.add("attributes", c.attributes(dp.Attributes.ToSlice()...))
+	o.add("count", c.value(dp.Count))
+	o.add("sum", c.value(dp.Sum))
+	if v, ok := dp.Min.Value(); ok {
+		o.add("min", c.value(v))
+	}
+	if v, ok := dp.Max.Value(); ok {
+		o.add("max", c.value(v))
+	}
+	o.add("scale", c.value(int64(dp.Scale)))
+	o.add("zero_count", c.value(dp.ZeroCount))
+	o.add("zero_threshold", dp.ZeroThreshold)
+	o.add("positive_bucket", c.exponentialBucket(dp.PositiveBucket))
+	o.add("negative_bucket", c.exponentialBucket(dp.NegativeBucket))
+	o.add("exemplars", exemplars(c, dp.Exemplars, ids))
+	return o
+}
+
+func (c config) exponentialBucket(b metricdata.ExponentialBucket) any {
+	if len(b.Counts) == 0 {
+		return nil
+	}
+	if c.maskValues {
+		return maskedValue
+	}
+	return object{
+		{"offset", int64(b.Offset)},
+		{"counts", sliceValue(b.Counts)},
+	}
+}
+
+func summaryDataPoint(c config, dp metricdata.SummaryDataPoint) object {
+	var o object
+	o.add("attributes", c.attributes(dp.Attributes.ToSlice()...))
+	o.add("count", c.value(dp.Count))
+	o.add("sum", c.value(dp.Sum))
+	quantiles := make([]any, len(dp.QuantileValues))
+	for i, q := range dp.QuantileValues {
+		quantiles[i] = object{
+			{"quantile", q.Quantile},
+			{"value", c.value(q.Value)},
+		}
+	}
+	o.add("quantile_values", quantiles)
+	return o
+}
+
+// exemplars returns the snapshots of exemplars sorted by content.
+func exemplars[N int64 | float64](c config, list []metricdata.Exemplar[N], ids *ids) []any {
+	type keyed struct {
+		key      string
+		content  object
+		exemplar metricdata.Exemplar[N]
+	}
+	sorted := make([]keyed, len(list))
+	for i, e := range list {
+		var o object
+		o.add("filtered_attributes", c.attributes(e.FilteredAttributes...))
+		o.add("value", c.value(e.Value))
+		sorted[i] = keyed{key: key(o), content: o, exemplar: e}
+	}
+	slices.SortStableFunc(sorted, func(a, b keyed) int {
+		return cmp.Compare(a.key, b.key)
+	})
+
+	out := make([]any, len(sorted))
+	for i, k := range sorted {
+		var o object
+		var (
+			traceID trace.TraceID
+			spanID  trace.SpanID
+		)
+		copy(traceID[:], k.exemplar.TraceID)
+		copy(spanID[:], k.exemplar.SpanID)
+		o.add("trace_id", ids.traceID(traceID))
+		o.add("span_id", ids.spanID(spanID))
+		out[i] = append(o, k.content...)
+	}
+	return out
+}
+
+// value returns v, or the masked value if the values are masked.
+func (c config) value(v any) any {
+	if c.maskValues {
+		return maskedValue
+	}
+	return v
+}
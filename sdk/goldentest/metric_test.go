@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var (
+	traceID = []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID  = []byte{1, 2, 3, 4, 5, 6, 7, 8}
+)
+
+func resourceMetrics(reverse bool) metricdata.ResourceMetrics {
+	points := []metricdata.DataPoint[int64]{
+		{Attributes: attribute.NewSet(attribute.String("method", "GET")), Value: 1},
+		{Attributes: attribute.NewSet(attribute.String("method", "POST")), Value: 2},
+	}
+	if reverse {
+		slices.Reverse(points)
+	}
+	requests := metricdata.Metrics{
+		Name:        "requests",
+		Description: "Number of requests",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  points,
+		},
+	}
+
+	metrics := []metricdata.Metrics{
+		requests,
+		{
+			Name: "latency",
+			Unit: "s",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.DeltaTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{{
+					Count:        3,
+					Sum:          6.5,
+					Min:          metricdata.NewExtrema(0.5),
+					Max:          metricdata.NewExtrema(4.0),
+					Bounds:       []float64{1, 5},
+					BucketCounts: []uint64{1, 2, 0},
+					Exemplars: []metricdata.Exemplar[float64]{{
+						FilteredAttributes: []attribute.KeyValue{attribute.String("user", "alice")},
+						Value:              4,
+						TraceID:            traceID,
+						SpanID:             spanID,
+					}},
+				}},
+			},
+		},
+		{
+			Name: "temperature",
+			Data: metricdata.Gauge[float64]{
+				DataPoints: []metricdata.DataPoint[float64]{{Value: math.Inf(-1)}},
+			},
+		},
+		{
+			Name: "sizes",
+			Data: metricdata.ExponentialHistogram[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{
+					Count:          4,
+					Sum:            10,
+					Scale:          2,
+					ZeroCount:      1,
+					PositiveBucket: metricdata.ExponentialBucket{Offset: 3, Counts: []uint64{1, 2}},
+				}},
+			},
+		},
+		{
+			Name: "summary",
+			Data: metricdata.Summary{
+				DataPoints: []metricdata.SummaryDataPoint{{
+					Count:          2,
+					Sum:            3,
+					QuantileValues: []metricdata.QuantileValue{{Quantile: 0.5, Value: 1}},
+				}},
+			},
+		},
+	}
+	scopes := []metricdata.ScopeMetrics{
+		{Scope: instrumentation.Scope{Name: "b"}, Metrics: metrics},
+		{Scope: instrumentation.Scope{Name: "a", Version: "v1"}, Metrics: []metricdata.Metrics{requests}},
+	}
+	if reverse {
+		slices.Reverse(scopes)
+		slices.Reverse(metrics)
+	}
+	return metricdata.ResourceMetrics{
+		Resource:     resource.NewSchemaless(attribute.String("service.name", "svc")),
+		ScopeMetrics: scopes,
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	AssertMetrics(t, "testdata/metrics.txt", resourceMetrics(false))
+	AssertMetrics(t, "testdata/metrics_masked.json", resourceMetrics(false),
+		WithFormat(FormatJSON),
+		WithMaskedValues(),
+		WithMaskedAttributes("user"),
+		WithoutResource(),
+	)
+	assert.Equal(t, string(Metrics(resourceMetrics(false))), string(Metrics(resourceMetrics(true))), "order")
+}
@@ -0,0 +1,388 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest // import "go.opentelemetry.io/otel/sdk/goldentest"
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// object is a snapshot object. Its members keep the order they are added
+// in.
+//
+// The values of a snapshot are nil, string, bool, int64, uint64, float64,
+// []any or object.
+type object []member
+
+type member struct {
+	key   string
+	value any
+}
+
+// add adds the member key with the value v to o, unless v is nil or empty.
+func (o *object) add(key string, v any) {
+	switch v := v.(type) {
+	case nil:
+		return
+	case string:
+		if v == "" {
+			return
+		}
+	case object:
+		if len(v) == 0 {
+			return
+		}
+	case []any:
+		if len(v) == 0 {
+			return
+		}
+	}
+	*o = append(*o, member{key: key, value: v})
+}
+
+// addCount adds the member key with the value n to o, unless n is 0.
+func (o *object) addCount(key string, n int) {
+	if n != 0 {
+		o.add(key, int64(n))
+	}
+}
+
+// encode returns the serialization of v in the format.
+func encode(v any, format Format) []byte {
+	var buf bytes.Buffer
+	switch format {
+	case FormatJSON:
+		var compact bytes.Buffer
+		writeJSON(&compact, v)
+		_ = json.Indent(&buf, compact.Bytes(), "", "  ")
+		buf.WriteByte('\n')
+	default:
+		writeText(&buf, v, 0)
+	}
+	return buf.Bytes()
+}
+
+func writeJSON(buf *bytes.Buffer, v any) {
+	switch v := v.(type) {
+	case object:
+		buf.WriteByte('{')
+		for i, m := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSON(buf, m.key)
+			buf.WriteByte(':')
+			writeJSON(buf, m.value)
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSON(buf, e)
+		}
+		buf.WriteByte(']')
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			// JSON does not support non-finite numbers.
+			writeJSON(buf, formatFloat(v))
+			return
+		}
+		buf.WriteString(formatFloat(v))
+	default:
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		_ = enc.Encode(v)
+		buf.Write(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+	}
+}
+
+// writeText writes v in the text format, the nested lines indented with
+// indent spaces.
+func writeText(buf *bytes.Buffer, v any, indent int) {
+	switch v := v.(type) {
+	case object:
+		for _, m := range v {
+			writeTextMember(buf, strings.Repeat(" ", indent), indent, m)
+		}
+	case []any:
+		writeTextList(buf, v, indent)
+	default:
+		buf.WriteString(strings.Repeat(" ", indent))
+		buf.WriteString(textScalar(v))
+		buf.WriteByte('\n')
+	}
+}
+
+func writeTextMember(buf *bytes.Buffer, prefix string, indent int, m member) {
+	buf.WriteString(prefix)
+	buf.WriteString(m.key)
+	buf.WriteByte(':')
+	switch v := m.value.(type) {
+	case object:
+		if len(v) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+		buf.WriteByte('\n')
+		writeText(buf, v, indent+2)
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+		buf.WriteByte('\n')
+		writeTextList(buf, v, indent+2)
+	default:
+		buf.WriteByte(' ')
+		buf.WriteString(textScalar(v))
+		buf.WriteByte('\n')
+	}
+}
+
+func writeTextList(buf *bytes.Buffer, list []any, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, e := range list {
+		switch e := e.(type) {
+		case object:
+			if len(e) == 0 {
+				buf.WriteString(pad + "- {}\n")
+				continue
+			}
+			// The first member is written on the line of the list item.
+			writeTextMember(buf, pad+"- ", indent+2, e[0])
+			for _, m := range e[1:] {
+				writeTextMember(buf, pad+"  ", indent+2, m)
+			}
+		case []any:
+			if len(e) == 0 {
+				buf.WriteString(pad + "- []\n")
+				continue
+			}
+			buf.WriteString(pad + "-\n")
+			writeTextList(buf, e, indent+2)
+		default:
+			buf.WriteString(pad + "- " + textScalar(e) + "\n")
+		}
+	}
+}
+
+func textScalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return formatFloat(v)
+	default:
+		return strconv.Quote("<unknown>")
+	}
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+// key returns a text serialization of v used to sort snapshot values.
+func key(v any) string {
+	var buf bytes.Buffer
+	writeText(&buf, v, 0)
+	return buf.String()
+}
+
+// attributeValue returns the snapshot value of v.
+func attributeValue(v attribute.Value) any {
+	switch v.Type() {
+	case attribute.BOOL:
+		return v.AsBool()
+	case attribute.INT64:
+		return v.AsInt64()
+	case attribute.FLOAT64:
+		return v.AsFloat64()
+	case attribute.STRING:
+		return v.AsString()
+	case attribute.BOOLSLICE:
+		return sliceValue(v.AsBoolSlice())
+	case attribute.INT64SLICE:
+		return sliceValue(v.AsInt64Slice())
+	case attribute.FLOAT64SLICE:
+		return sliceValue(v.AsFloat64Slice())
+	case attribute.STRINGSLICE:
+		return sliceValue(v.AsStringSlice())
+	default:
+		return nil
+	}
+}
+
+func sliceValue[T any](s []T) []any {
+	out := make([]any, len(s))
+	for i, v := range s {
+		out[i] = v
+	}
+	return out
+}
+
+// attributes returns the snapshot object of attrs sorted by key.
+func (c config) attributes(attrs ...attribute.KeyValue) object {
+	if len(attrs) == 0 {
+		return nil
+	}
+	s := attribute.NewSet(attrs...)
+	o := make(object, 0, s.Len())
+	for iter := s.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		var v any = maskedValue
+		if !c.isMasked(kv.Key) {
+			v = attributeValue(kv.Value)
+		}
+		o = append(o, member{key: string(kv.Key), value: v})
+	}
+	return o
+}
+
+func (c config) resource(res *resource.Resource) object {
+	if c.withoutResource || res == nil {
+		return nil
+	}
+	var o object
+	o.add("attributes", c.attributes(res.Attributes()...))
+	o.add("schema_url", res.SchemaURL())
+	return o
+}
+
+func scope(s instrumentation.Scope) object {
+	var o object
+	o.add("name", s.Name)
+	o.add("version", s.Version)
+	o.add("schema_url", s.SchemaURL)
+	return o
+}
+
+// ids assigns placeholders to trace and span IDs in the order they are
+// first seen.
+type ids struct {
+	traces map[trace.TraceID]string
+	spans  map[trace.SpanID]string
+}
+
+func newIDs() *ids {
+	return &ids{
+		traces: make(map[trace.TraceID]string),
+		spans:  make(map[trace.SpanID]string),
+	}
+}
+
+func (i *ids) traceID(id trace.TraceID) any {
+	if !id.IsValid() {
+		return nil
+	}
+	p, ok := i.traces[id]
+	if !ok {
+		p = "trace-" + strconv.Itoa(len(i.traces)+1)
+		i.traces[id] = p
+	}
+	return p
+}
+
+func (i *ids) spanID(id trace.SpanID) any {
+	if !id.IsValid() {
+		return nil
+	}
+	p, ok := i.spans[id]
+	if !ok {
+		p = "span-" + strconv.Itoa(len(i.spans)+1)
+		i.spans[id] = p
+	}
+	return p
+}
+
+// scopeGroup is the telemetry of an instrumentation scope of a resource.
+type scopeGroup[T any] struct {
+	resource, scope object
+	items           []T
+}
+
+// groupByScope groups items by resource and instrumentation scope. The
+// groups are sorted by resource and scope.
+func groupByScope[T any](items []T, resourceOf, scopeOf func(T) object) []*scopeGroup[T] {
+	var groups []*scopeGroup[T]
+	index := make(map[[2]string]*scopeGroup[T])
+	for _, item := range items {
+		res, scp := resourceOf(item), scopeOf(item)
+		k := [2]string{key(res), key(scp)}
+		g, ok := index[k]
+		if !ok {
+			g = &scopeGroup[T]{resource: res, scope: scp}
+			index[k] = g
+			groups = append(groups, g)
+		}
+		g.items = append(g.items, item)
+	}
+	slices.SortFunc(groups, func(a, b *scopeGroup[T]) int {
+		if c := cmp.Compare(key(a.resource), key(b.resource)); c != 0 {
+			return c
+		}
+		return cmp.Compare(key(a.scope), key(b.scope))
+	})
+	return groups
+}
+
+// nest returns the snapshot of groups: the list of the resources and of
+// their scopes. The telemetry of a scope is added as the itemsKey member
+// with the value returned by items.
+func nest[T any](groups []*scopeGroup[T], itemsKey string, items func([]T) []any) []any {
+	var (
+		out      []any
+		scopes   []any
+		resource object
+	)
+	flush := func() {
+		if scopes == nil {
+			return
+		}
+		var o object
+		o.add("resource", resource)
+		o.add("scopes", scopes)
+		out = append(out, o)
+	}
+	for i, g := range groups {
+		if i == 0 || key(g.resource) != key(resource) {
+			flush()
+			resource, scopes = g.resource, []any{}
+		}
+		var o object
+		o.add("scope", g.scope)
+		o.add(itemsKey, items(g.items))
+		scopes = append(scopes, o)
+	}
+	flush()
+	return out
+}
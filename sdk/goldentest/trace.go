@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest // import "go.opentelemetry.io/otel/sdk/goldentest"
+
+import (
+	"cmp"
+	"slices"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Spans returns the snapshot of spans.
+//
+// Spans are sorted by the content of their ancestors first, and then by
+// their own content, so the spans of a trace are kept together and the
+// order does not depend on the order they ended in.
+func Spans(spans tracetest.SpanStubs, opts ...Option) []byte {
+	cfg := newConfig(opts)
+
+	type spanKey struct {
+		trace trace.TraceID
+		span  trace.SpanID
+	}
+	index := make(map[spanKey]int, len(spans))
+	for i, s := range spans {
+		index[spanKey{s.SpanContext.TraceID(), s.SpanContext.SpanID()}] = i
+	}
+
+	// The sort key of a span is the content of its ancestors followed by
+	// its own content.
+	sortKeys := make([]string, len(spans))
+	done := make([]bool, len(spans))
+	var sortKey func(i int) string
+	sortKey = func(i int) string {
+		if done[i] {
+			return sortKeys[i]
+		}
+		// Guard against cycles.
+		done[i] = true
+		s := spans[i]
+		var k string
+		if p, ok := index[spanKey{s.Parent.TraceID(), s.Parent.SpanID()}]; ok && s.Parent.IsValid() {
+			k = sortKey(p)
+		}
+		sortKeys[i] = k + "\n/" + key(cfg.spanContent(s))
+		return sortKeys[i]
+	}
+
+	idx := make([]int, len(spans))
+	for i := range spans {
+		idx[i] = i
+		sortKey(i)
+	}
+	groups := groupByScope(idx,
+		func(i int) object { return cfg.resource(spans[i].Resource) },
+		func(i int) object { return scope(spans[i].InstrumentationLibrary) },
+	)
+
+	ids := newIDs()
+	return encode(nest(groups, "spans", func(items []int) []any {
+		slices.SortStableFunc(items, func(a, b int) int {
+			return cmp.Compare(sortKeys[a], sortKeys[b])
+		})
+		out := make([]any, len(items))
+		for i, item := range items {
+			out[i] = cfg.span(spans[item], ids)
+		}
+		return out
+	}), cfg.format)
+}
+
+// span returns the snapshot of s, its trace and span IDs replaced with
+// placeholders.
+func (c config) span(s tracetest.SpanStub, ids *ids) object {
+	var o object
+	o.add("trace_id", ids.traceID(s.SpanContext.TraceID()))
+	o.add("span_id", ids.spanID(s.SpanContext.SpanID()))
+	o.add("parent_span_id", ids.spanID(s.Parent.SpanID()))
+	if s.Parent.IsRemote() {
+		o.add("parent_remote", true)
+	}
+	o = append(o, c.spanContent(s)...)
+
+	if len(s.Links) > 0 {
+		links := make([]any, len(s.Links))
+		for i, l := range s.Links {
+			var lo object
+			lo.add("trace_id", ids.traceID(l.SpanContext.TraceID()))
+			lo.add("span_id", ids.spanID(l.SpanContext.SpanID()))
+			lo.add("attributes", c.attributes(l.Attributes...))
+			lo.addCount("dropped_attributes", l.DroppedAttributeCount)
+			links[i] = lo
+		}
+		o.add("links", links)
+	}
+	o.addCount("dropped_links", s.DroppedLinks)
+	return o
+}
+
+// spanContent returns the snapshot of s without its trace and span IDs and
+// links.
+func (c config) spanContent(s tracetest.SpanStub) object {
+	var o object
+	o.add("name", s.Name)
+	o.add("kind", s.SpanKind.String())
+	if s.Status.Code != codes.Unset {
+		var so object
+		so.add("code", s.Status.Code.String())
+		so.add("description", s.Status.Description)
+		o.add("status", so)
+	}
+	o.add("attributes", c.attributes(s.Attributes...))
+	o.addCount("dropped_attributes", s.DroppedAttributes)
+
+	if len(s.Events) > 0 {
+		events := make([]any, len(s.Events))
+		for i, e := range s.Events {
+			var eo object
+			eo.add("name", e.Name)
+			eo.add("attributes", c.attributes(e.Attributes...))
+			eo.addCount("dropped_attributes", e.DroppedAttributeCount)
+			events[i] = eo
+		}
+		o.add("events", events)
+	}
+	o.addCount("dropped_events", s.DroppedEvents)
+	o.addCount("child_span_count", s.ChildSpanCount)
+	return o
+}
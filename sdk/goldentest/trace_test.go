@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goldentest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func recordSpans(t *testing.T) tracetest.SpanStubs {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exp),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "svc"),
+			attribute.String("host.name", "host-1234"),
+		)),
+	)
+	tracer := tp.Tracer("scope", trace.WithInstrumentationVersion("v1"))
+
+	ctx, server := tracer.Start(context.Background(), "GET /users", trace.WithSpanKind(trace.SpanKindServer))
+	server.SetAttributes(attribute.String("http.method", "GET"), attribute.Int("http.status_code", 500))
+	for _, table := range []string{"users", "groups"} {
+		_, query := tracer.Start(ctx, "query", trace.WithAttributes(attribute.String("db.table", table)))
+		if table == "groups" {
+			query.RecordError(errors.New("timeout"))
+			query.SetStatus(codes.Error, "query failed")
+		}
+		query.End()
+	}
+	server.End()
+
+	_, consumer := tracer.Start(context.Background(), "process", trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithLinks(trace.Link{SpanContext: server.SpanContext(), Attributes: []attribute.KeyValue{attribute.Bool("retry", true)}}),
+	)
+	consumer.End()
+
+	return exp.GetSpans()
+}
+
+func TestSpans(t *testing.T) {
+	spans := recordSpans(t)
+	opts := []Option{WithMaskedAttributes("host.name")}
+
+	AssertSpans(t, "testdata/spans.txt", spans, opts...)
+	AssertSpans(t, "testdata/spans.json", spans, append(opts, WithFormat(FormatJSON))...)
+
+	reversed := slices.Clone(spans)
+	slices.Reverse(reversed)
+	assert.Equal(t, string(Spans(spans, opts...)), string(Spans(reversed, opts...)), "order")
+	assert.Equal(t, string(Spans(spans, opts...)), string(Spans(recordSpans(t), opts...)), "IDs")
+}
+
+func TestSpansEmpty(t *testing.T) {
+	assert.Equal(t, "", string(Spans(nil)))
+	assert.Equal(t, "[]\n", string(Spans(nil, WithFormat(FormatJSON))))
+}
@@ -11,7 +11,10 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 const (
@@ -24,6 +27,8 @@ const (
 	envarExpInterval     = "OTEL_BLRP_SCHEDULE_DELAY"
 	envarExpTimeout      = "OTEL_BLRP_EXPORT_TIMEOUT"
 	envarExpMaxBatchSize = "OTEL_BLRP_MAX_EXPORT_BATCH_SIZE"
+
+	meterName = "go.opentelemetry.io/otel/sdk/log"
 )
 
 // Compile-time check BatchProcessor implements Processor.
@@ -69,6 +74,10 @@ type BatchProcessor struct {
 	// to OnEmit are made faster than the queue can be flushed. If batches
 	// cannot be flushed to the export buffer, the records will remain in the
 	// queue.
+	//
+	// When blocking is enabled, OnEmit instead waits for space in the queue
+	// before overwriting any record. The wait is bounded by maxWait, the
+	// context passed to OnEmit, and the shutdown of the BatchProcessor.
 
 	// exporter is the bufferedExporter all batches are exported with.
 	exporter *bufferExporter
@@ -79,6 +88,15 @@ type BatchProcessor struct {
 	// triggered (unless the interval expires).
 	batchSize int
 
+	// blocking is true if OnEmit waits for space in a full queue.
+	blocking bool
+	// maxWait is the maximum duration OnEmit waits for space in a full
+	// queue. A non-positive value means there is no maximum.
+	maxWait time.Duration
+
+	// dropped counts the records dropped due to a full queue.
+	dropped metric.Int64Counter
+
 	// pollTrigger triggers the poll goroutine to flush a batch from the queue.
 	// This is sent to when it is known that the queue contains at least one
 	// complete batch.
@@ -122,6 +140,9 @@ func NewBatchProcessor(exporter Exporter, opts ...BatchProcessorOption) *BatchPr
 
 		q:           newQueue(cfg.maxQSize.Value),
 		batchSize:   cfg.expMaxBatchSize.Value,
+		blocking:    cfg.blocking,
+		maxWait:     cfg.maxWait,
+		dropped:     newDroppedCounter(cfg.meterProvider),
 		pollTrigger: make(chan struct{}, 1),
 		pollKill:    make(chan struct{}),
 	}
@@ -129,6 +150,34 @@ func NewBatchProcessor(exporter Exporter, opts ...BatchProcessorOption) *BatchPr
 	return b
 }
 
+// newDroppedCounter returns the counter of dropped log records created with
+// a Meter from mp. If mp is nil or the counter cannot be created, a no-op
+// counter is returned.
+func newDroppedCounter(mp metric.MeterProvider) metric.Int64Counter {
+	if mp == nil {
+		return noop.Int64Counter{}
+	}
+	c, err := mp.Meter(meterName).Int64Counter(
+		"otel.sdk.log.processor.dropped",
+		metric.WithUnit("{log_record}"),
+		metric.WithDescription("The number of log records dropped by the batch processor because its queue was full."),
+	)
+	if err != nil {
+		otel.Handle(err)
+		return noop.Int64Counter{}
+	}
+	return c
+}
+
+// reportDropped reports the number of records dropped by the queue since the
+// last report.
+func (b *BatchProcessor) reportDropped() {
+	if d := b.q.Dropped(); d > 0 {
+		global.Warn("dropped log records", "dropped", d)
+		b.dropped.Add(context.Background(), int64(d))
+	}
+}
+
 // poll spawns a goroutine to handle interval polling and batch exporting. The
 // returned done chan is closed when the spawned goroutine completes.
 func (b *BatchProcessor) poll(interval time.Duration) (done chan struct{}) {
@@ -150,9 +199,7 @@ func (b *BatchProcessor) poll(interval time.Duration) (done chan struct{}) {
 				return
 			}
 
-			if d := b.q.Dropped(); d > 0 {
-				global.Warn("dropped log records", "dropped", d)
-			}
+			b.reportDropped()
 
 			qLen := b.q.TryDequeue(buf, func(r []Record) bool {
 				ok := b.exporter.EnqueueExport(r)
@@ -176,22 +223,68 @@ func (b *BatchProcessor) poll(interval time.Duration) (done chan struct{}) {
 }
 
 // OnEmit batches provided log record.
-func (b *BatchProcessor) OnEmit(_ context.Context, r Record) error {
+func (b *BatchProcessor) OnEmit(ctx context.Context, r Record) error {
 	if b.stopped.Load() || b.q == nil {
 		return nil
 	}
-	if n := b.q.Enqueue(r); n >= b.batchSize {
-		select {
-		case b.pollTrigger <- struct{}{}:
-		default:
-			// Flush chan full. The poll goroutine will handle this by
-			// re-sending any trigger until the queue has less than batchSize
-			// records.
-		}
+
+	var n int
+	if b.blocking {
+		n = b.enqueueWait(ctx, r)
+	} else {
+		n = b.q.Enqueue(r)
+	}
+	if n >= b.batchSize {
+		b.trigger()
 	}
 	return nil
 }
 
+// trigger signals the poll goroutine to flush a batch from the queue.
+func (b *BatchProcessor) trigger() {
+	select {
+	case b.pollTrigger <- struct{}{}:
+	default:
+		// Flush chan full. The poll goroutine will handle this by re-sending
+		// any trigger until the queue has less than batchSize records.
+	}
+}
+
+// enqueueWait adds r to the queue. If the queue is full, it waits for space
+// to become available until maxWait elapses, ctx is done, or b is shut down.
+// If the wait is abandoned, r is enqueued and the oldest queued record is
+// dropped.
+func (b *BatchProcessor) enqueueWait(ctx context.Context, r Record) int {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	return b.q.EnqueueWait(r, func(space <-chan struct{}) bool {
+		// Make sure space is being made.
+		b.trigger()
+
+		var timeout <-chan time.Time
+		if b.maxWait > 0 {
+			if timer == nil {
+				timer = time.NewTimer(b.maxWait)
+			}
+			timeout = timer.C
+		}
+
+		select {
+		case <-space:
+			return true
+		case <-timeout:
+		case <-ctx.Done():
+		case <-b.pollKill:
+		}
+		return false
+	})
+}
+
 // Enabled returns if b is enabled.
 func (b *BatchProcessor) Enabled(context.Context, Record) bool {
 	return !b.stopped.Load() && b.q != nil
@@ -214,6 +307,7 @@ func (b *BatchProcessor) Shutdown(ctx context.Context) error {
 
 	// Flush remaining queued before exporter shutdown.
 	err := b.exporter.Export(ctx, b.q.Flush())
+	b.reportDropped()
 	return errors.Join(err, b.exporter.Shutdown(ctx))
 }
 
@@ -262,6 +356,10 @@ type queue struct {
 	dropped     atomic.Uint64
 	cap, len    int
 	read, write *ring
+
+	// space is closed, and then cleared, when records are removed from the
+	// queue. It is only allocated when there is a waiter for space.
+	space chan struct{}
 }
 
 func newQueue(size int) *queue {
@@ -288,6 +386,42 @@ func (q *queue) Enqueue(r Record) int {
 	q.Lock()
 	defer q.Unlock()
 
+	return q.enqueue(r)
+}
+
+// EnqueueWait adds r to the queue once the queue has capacity for it. The
+// queue size, including the addition of r, is returned.
+//
+// While q is full, wait is called with a channel that is closed when records
+// are removed from q. The wait function is expected to block until that
+// channel is closed, returning true, or until it gives up waiting, returning
+// false. If wait gives up, r is enqueued as if Enqueue was called.
+//
+// When wait is called the lock of q is not held.
+func (q *queue) EnqueueWait(r Record, wait func(space <-chan struct{}) bool) int {
+	q.Lock()
+	defer q.Unlock()
+
+	for q.len >= q.cap {
+		if q.space == nil {
+			q.space = make(chan struct{})
+		}
+		space := q.space
+
+		q.Unlock()
+		ok := wait(space)
+		q.Lock()
+
+		if !ok {
+			break
+		}
+	}
+	return q.enqueue(r)
+}
+
+// enqueue adds r to the queue. The lock of q needs to be held when calling
+// this method.
+func (q *queue) enqueue(r Record) int {
 	q.write.Value = r
 	q.write = q.write.Next()
 
@@ -323,6 +457,7 @@ func (q *queue) TryDequeue(buf []Record, write func([]Record) bool) int {
 
 	if write(buf[:n]) {
 		q.len -= n
+		q.signalSpace()
 	} else {
 		q.read = origRead
 	}
@@ -341,15 +476,28 @@ func (q *queue) Flush() []Record {
 		q.read = q.read.Next()
 	}
 	q.len = 0
+	q.signalSpace()
 
 	return out
 }
 
+// signalSpace wakes all the waiters for space in the queue. The lock of q
+// needs to be held when calling this method.
+func (q *queue) signalSpace() {
+	if q.space != nil {
+		close(q.space)
+		q.space = nil
+	}
+}
+
 type batchConfig struct {
 	maxQSize        setting[int]
 	expInterval     setting[time.Duration]
 	expTimeout      setting[time.Duration]
 	expMaxBatchSize setting[int]
+	blocking        bool
+	maxWait         time.Duration
+	meterProvider   metric.MeterProvider
 }
 
 func newBatchConfig(options []BatchProcessorOption) batchConfig {
@@ -459,3 +607,39 @@ func WithExportMaxBatchSize(size int) BatchProcessorOption {
 		return cfg
 	})
 }
+
+// WithBlocking configures the [BatchProcessor] to block the caller of OnEmit
+// when its queue is full, instead of dropping the oldest queued log record.
+//
+// The caller is blocked until space becomes available in the queue, the
+// context passed to OnEmit is done, the BatchProcessor is shut down, or
+// maxWait elapses, whichever happens first. If the wait ends without space
+// becoming available, the oldest queued log record is dropped. A maxWait
+// less than or equal to zero means the wait is not bounded by a duration.
+//
+// This option should be used carefully as it can severely affect the
+// latency of the code emitting log records. It is intended for log records
+// whose delivery is more important than the latency of emitting them (e.g.
+// audit logs).
+//
+// By default, if this option is not passed, OnEmit never blocks.
+func WithBlocking(maxWait time.Duration) BatchProcessorOption {
+	return batchOptionFunc(func(cfg batchConfig) batchConfig {
+		cfg.blocking = true
+		cfg.maxWait = maxWait
+		return cfg
+	})
+}
+
+// WithMeterProvider sets the [metric.MeterProvider] used to create the
+// instruments that report on the [BatchProcessor]. The
+// "otel.sdk.log.processor.dropped" counter reports the number of log records
+// dropped because the queue was full.
+//
+// By default, if this option is not passed, no metrics are reported.
+func WithMeterProvider(mp metric.MeterProvider) BatchProcessorOption {
+	return batchOptionFunc(func(cfg batchConfig) batchConfig {
+		cfg.meterProvider = mp
+		return cfg
+	})
+}
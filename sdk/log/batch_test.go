@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 	"unsafe"
@@ -22,6 +23,8 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 type concurrentBuffer struct {
@@ -41,6 +44,43 @@ func (b *concurrentBuffer) String() string {
 	return b.b.String()
 }
 
+// testMeterProvider is a metric.MeterProvider that records the name of, and
+// the sum of the values added to, the Int64Counter created by its Meter.
+type testMeterProvider struct {
+	noop.MeterProvider
+
+	mu   sync.Mutex
+	name string
+	sum  atomic.Int64
+}
+
+func (p *testMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return testMeter{p: p}
+}
+
+type testMeter struct {
+	noop.Meter
+
+	p *testMeterProvider
+}
+
+func (m testMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	m.p.mu.Lock()
+	m.p.name = name
+	m.p.mu.Unlock()
+	return testCounter{p: m.p}, nil
+}
+
+type testCounter struct {
+	noop.Int64Counter
+
+	p *testMeterProvider
+}
+
+func (c testCounter) Add(_ context.Context, incr int64, _ ...metric.AddOption) {
+	c.p.sum.Add(incr)
+}
+
 func TestEmptyBatchConfig(t *testing.T) {
 	assert.NotPanics(t, func() {
 		var bp BatchProcessor
@@ -155,6 +195,20 @@ func TestNewBatchConfig(t *testing.T) {
 				expMaxBatchSize: newSetting(2),
 			},
 		},
+		{
+			name: "Blocking",
+			options: []BatchProcessorOption{
+				WithBlocking(time.Second),
+			},
+			want: batchConfig{
+				maxQSize:        newSetting(dfltMaxQSize),
+				expInterval:     newSetting(dfltExpInterval),
+				expTimeout:      newSetting(dfltExpTimeout),
+				expMaxBatchSize: newSetting(dfltExpMaxBatchSize),
+				blocking:        true,
+				maxWait:         time.Second,
+			},
+		},
 		{
 			name: "BatchLessThanOrEqualToQSize",
 			options: []BatchProcessorOption{
@@ -480,6 +534,180 @@ func TestBatchProcessor(t *testing.T) {
 		_ = b.Shutdown(ctx)
 	})
 
+	// fillQueue emits records to b until its queue is full while the export
+	// of e is blocked. The blocked export is released by sending to, or
+	// closing, e.ExportTrigger.
+	fillQueue := func(t *testing.T, e *testExporter, b *BatchProcessor) {
+		t.Helper()
+
+		var r Record
+		// First record will be blocked by testExporter.Export
+		assert.NoError(t, b.OnEmit(ctx, r), "exported record")
+		require.Eventually(t, func() bool {
+			return e.ExportN() > 0
+		}, 2*time.Second, time.Microsecond, "blocked export not attempted")
+
+		// Second record will be written to export queue
+		assert.NoError(t, b.OnEmit(ctx, r), "export queue record")
+		require.Eventually(t, func() bool {
+			return len(b.exporter.input) == cap(b.exporter.input)
+		}, 2*time.Second, time.Microsecond, "blocked queue read not attempted")
+
+		// Third record will be written to BatchProcessor.q
+		assert.NoError(t, b.OnEmit(ctx, r), "queued")
+	}
+
+	t.Run("DroppedMetric", func(t *testing.T) {
+		mp := new(testMeterProvider)
+		e := newTestExporter(nil)
+		e.ExportTrigger = make(chan struct{})
+
+		b := NewBatchProcessor(
+			e,
+			WithMaxQueueSize(1),
+			WithExportMaxBatchSize(1),
+			WithExportInterval(time.Hour),
+			WithExportTimeout(time.Hour),
+			WithMeterProvider(mp),
+		)
+		fillQueue(t, e, b)
+		assert.NoError(t, b.OnEmit(ctx, Record{}), "dropped")
+		assert.NoError(t, b.OnEmit(ctx, Record{}), "dropped")
+
+		close(e.ExportTrigger)
+		assert.NoError(t, b.Shutdown(ctx))
+
+		mp.mu.Lock()
+		assert.Equal(t, "otel.sdk.log.processor.dropped", mp.name)
+		mp.mu.Unlock()
+		assert.Equal(t, int64(2), mp.sum.Load())
+	})
+
+	t.Run("Blocking", func(t *testing.T) {
+		t.Run("Space", func(t *testing.T) {
+			mp := new(testMeterProvider)
+			e := newTestExporter(nil)
+			e.ExportTrigger = make(chan struct{})
+
+			b := NewBatchProcessor(
+				e,
+				WithMaxQueueSize(1),
+				WithExportMaxBatchSize(1),
+				WithExportInterval(time.Hour),
+				WithExportTimeout(time.Hour),
+				WithBlocking(0),
+				WithMeterProvider(mp),
+			)
+			fillQueue(t, e, b)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				assert.NoError(t, b.OnEmit(ctx, Record{}))
+			}()
+
+			select {
+			case <-done:
+				t.Fatal("OnEmit did not block on a full queue")
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			close(e.ExportTrigger)
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("OnEmit still blocked after space was made")
+			}
+
+			assert.NoError(t, b.Shutdown(ctx))
+			assert.Equal(t, int64(0), mp.sum.Load(), "dropped")
+
+			var got int
+			for _, r := range e.Records() {
+				got += len(r)
+			}
+			assert.Equal(t, 4, got, "exported records")
+		})
+
+		t.Run("MaxWait", func(t *testing.T) {
+			mp := new(testMeterProvider)
+			e := newTestExporter(nil)
+			e.ExportTrigger = make(chan struct{})
+
+			b := NewBatchProcessor(
+				e,
+				WithMaxQueueSize(1),
+				WithExportMaxBatchSize(1),
+				WithExportInterval(time.Hour),
+				WithExportTimeout(time.Hour),
+				WithBlocking(time.Millisecond),
+				WithMeterProvider(mp),
+			)
+			fillQueue(t, e, b)
+
+			assert.NoError(t, b.OnEmit(ctx, Record{}))
+
+			close(e.ExportTrigger)
+			assert.NoError(t, b.Shutdown(ctx))
+			assert.Equal(t, int64(1), mp.sum.Load(), "dropped")
+		})
+
+		t.Run("CanceledContext", func(t *testing.T) {
+			e := newTestExporter(nil)
+			e.ExportTrigger = make(chan struct{})
+
+			b := NewBatchProcessor(
+				e,
+				WithMaxQueueSize(1),
+				WithExportMaxBatchSize(1),
+				WithExportInterval(time.Hour),
+				WithExportTimeout(time.Hour),
+				WithBlocking(0),
+			)
+			fillQueue(t, e, b)
+
+			c, cancel := context.WithCancel(ctx)
+			cancel()
+			assert.NoError(t, b.OnEmit(c, Record{}))
+
+			close(e.ExportTrigger)
+			assert.NoError(t, b.Shutdown(ctx))
+		})
+
+		t.Run("Shutdown", func(t *testing.T) {
+			e := newTestExporter(nil)
+			e.ExportTrigger = make(chan struct{})
+
+			b := NewBatchProcessor(
+				e,
+				WithMaxQueueSize(1),
+				WithExportMaxBatchSize(1),
+				WithExportInterval(time.Hour),
+				WithExportTimeout(time.Hour),
+				WithBlocking(0),
+			)
+			fillQueue(t, e, b)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				assert.NoError(t, b.OnEmit(ctx, Record{}))
+			}()
+
+			c, cancel := context.WithCancel(ctx)
+			cancel()
+			// The export is still blocked, only stop the poll goroutine.
+			_ = b.Shutdown(c)
+
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("OnEmit still blocked after shutdown")
+			}
+			close(e.ExportTrigger)
+		})
+	})
+
 	t.Run("ConcurrentSafe", func(t *testing.T) {
 		const goRoutines = 10
 
@@ -567,6 +795,36 @@ func TestQueue(t *testing.T) {
 		assert.Equal(t, uint64(2), q.Dropped(), "second")
 	})
 
+	t.Run("EnqueueWait", func(t *testing.T) {
+		q := newQueue(1)
+		_ = q.Enqueue(r)
+
+		var calls int
+		giveUp := func(<-chan struct{}) bool {
+			calls++
+			return false
+		}
+		assert.Equal(t, 1, q.EnqueueWait(r, giveUp), "abandoned wait")
+		assert.Equal(t, 1, calls, "wait calls")
+		assert.Equal(t, uint64(1), q.Dropped(), "abandoned wait dropped")
+
+		calls = 0
+		flush := func(space <-chan struct{}) bool {
+			calls++
+			_ = q.Flush()
+			<-space
+			return true
+		}
+		assert.Equal(t, 1, q.EnqueueWait(r, flush), "waited")
+		assert.Equal(t, 1, calls, "wait calls")
+		assert.Equal(t, uint64(0), q.Dropped(), "waited dropped")
+
+		calls = 0
+		q = newQueue(1)
+		assert.Equal(t, 1, q.EnqueueWait(r, flush), "not full")
+		assert.Equal(t, 0, calls, "wait calls")
+	})
+
 	t.Run("Flush", func(t *testing.T) {
 		const size = 2
 		q := newQueue(size)
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package log // import "go.opentelemetry.io/otel/sdk/log"
+
+import "go.opentelemetry.io/otel/sdk/instrumentation"
+
+// LoggerConfig is the configuration of a Logger.
+//
+// The zero value is the default configuration: an enabled Logger.
+//
+// This type is experimental and is based on the Logger configurator
+// defined in the OpenTelemetry specification. It may change or be removed
+// in a future release.
+type LoggerConfig struct {
+	// Disabled defines if the Logger is disabled.
+	//
+	// A disabled Logger behaves like a no-op Logger: it does not emit any
+	// log records and its Enabled method always returns false.
+	Disabled bool
+}
+
+// LoggerConfigurator computes the LoggerConfig of the Logger identified by
+// an instrumentation scope.
+//
+// A LoggerConfigurator needs to be safe to call concurrently.
+//
+// This type is experimental and is based on the Logger configurator
+// defined in the OpenTelemetry specification. It may change or be removed
+// in a future release.
+type LoggerConfigurator func(instrumentation.Scope) LoggerConfig
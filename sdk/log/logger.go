@@ -5,6 +5,7 @@ package log // import "go.opentelemetry.io/otel/sdk/log"
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -24,6 +25,8 @@ type logger struct {
 
 	provider             *LoggerProvider
 	instrumentationScope instrumentation.Scope
+
+	disabled atomic.Bool
 }
 
 func newLogger(p *LoggerProvider, scope instrumentation.Scope) *logger {
@@ -33,7 +36,16 @@ func newLogger(p *LoggerProvider, scope instrumentation.Scope) *logger {
 	}
 }
 
+// setConfig applies the LoggerConfig c to l.
+func (l *logger) setConfig(c LoggerConfig) {
+	l.disabled.Store(c.Disabled)
+}
+
 func (l *logger) Emit(ctx context.Context, r log.Record) {
+	if l.disabled.Load() {
+		return
+	}
+
 	newRecord := l.newRecord(ctx, r)
 	for _, p := range l.provider.processors {
 		if err := p.OnEmit(ctx, newRecord); err != nil {
@@ -43,6 +55,10 @@ func (l *logger) Emit(ctx context.Context, r log.Record) {
 }
 
 func (l *logger) Enabled(ctx context.Context, r log.Record) bool {
+	if l.disabled.Load() {
+		return false
+	}
+
 	newRecord := l.newRecord(ctx, r)
 	for _, p := range l.provider.processors {
 		if enabled := p.Enabled(ctx, newRecord); enabled {
@@ -60,7 +76,6 @@ func (l *logger) newRecord(ctx context.Context, r log.Record) Record {
 		observedTimestamp: r.ObservedTimestamp(),
 		severity:          r.Severity(),
 		severityText:      r.SeverityText(),
-		body:              r.Body(),
 
 		traceID:    sc.TraceID(),
 		spanID:     sc.SpanID(),
@@ -70,7 +85,12 @@ func (l *logger) newRecord(ctx context.Context, r log.Record) Record {
 		scope:                     &l.instrumentationScope,
 		attributeValueLengthLimit: l.provider.attributeValueLengthLimit,
 		attributeCountLimit:       l.provider.attributeCountLimit,
+		valueLimitsSet:            true,
+		bodyLengthLimit:           l.provider.bodyLengthLimit,
+		valueDepthLimit:           l.provider.valueDepthLimit,
+		valueElementCountLimit:    l.provider.valueElementCountLimit,
 	}
+	newRecord.SetBody(r.Body())
 
 	// This field SHOULD be set once the event is observed by OpenTelemetry.
 	if newRecord.observedTimestamp.IsZero() {
@@ -86,6 +86,10 @@ func TestLoggerEmit(t *testing.T) {
 					resource:                  resource.NewSchemaless(attribute.String("key", "value")),
 					attributeValueLengthLimit: 3,
 					attributeCountLimit:       2,
+					valueLimitsSet:            true,
+					bodyLengthLimit:           -1,
+					valueDepthLimit:           -1,
+					valueElementCountLimit:    -1,
 					scope:                     &instrumentation.Scope{Name: "scope"},
 					front: [attributesInlineCount]log.KeyValue{
 						log.String("k1", "str"),
@@ -126,6 +130,10 @@ func TestLoggerEmit(t *testing.T) {
 					resource:                  resource.NewSchemaless(attribute.String("key", "value")),
 					attributeValueLengthLimit: 3,
 					attributeCountLimit:       2,
+					valueLimitsSet:            true,
+					bodyLengthLimit:           -1,
+					valueDepthLimit:           -1,
+					valueElementCountLimit:    -1,
 					scope:                     &instrumentation.Scope{Name: "scope"},
 					front: [attributesInlineCount]log.KeyValue{
 						log.String("k1", "str"),
@@ -159,6 +167,10 @@ func TestLoggerEmit(t *testing.T) {
 					resource:                  resource.NewSchemaless(attribute.String("key", "value")),
 					attributeValueLengthLimit: 3,
 					attributeCountLimit:       2,
+					valueLimitsSet:            true,
+					bodyLengthLimit:           -1,
+					valueDepthLimit:           -1,
+					valueElementCountLimit:    -1,
 					scope:                     &instrumentation.Scope{Name: "scope"},
 					front: [attributesInlineCount]log.KeyValue{
 						log.String("k1", "str"),
@@ -189,6 +201,10 @@ func TestLoggerEmit(t *testing.T) {
 					resource:                  resource.NewSchemaless(attribute.String("key", "value")),
 					attributeValueLengthLimit: 3,
 					attributeCountLimit:       2,
+					valueLimitsSet:            true,
+					bodyLengthLimit:           -1,
+					valueDepthLimit:           -1,
+					valueElementCountLimit:    -1,
 					scope:                     &instrumentation.Scope{Name: "scope"},
 					front: [attributesInlineCount]log.KeyValue{
 						log.String("k1", "str"),
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logtest // import "go.opentelemetry.io/otel/sdk/log/logtest"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+var _ sdklog.Exporter = (*InMemoryExporter)(nil)
+
+// errShutdown is returned by Export once the InMemoryExporter is shut down.
+var errShutdown = errors.New("exporter is shutdown")
+
+// NewInMemoryExporter returns a new InMemoryExporter.
+func NewInMemoryExporter() *InMemoryExporter {
+	return new(InMemoryExporter)
+}
+
+// InMemoryExporter is an exporter that stores all received log records
+// in-memory.
+type InMemoryExporter struct {
+	mu       sync.Mutex
+	records  []sdklog.Record
+	shutdown bool
+}
+
+// Export handles export of log records by storing a copy of them in memory.
+func (e *InMemoryExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.shutdown {
+		return errShutdown
+	}
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+// ForceFlush does nothing, the log records are stored when they are
+// exported.
+func (e *InMemoryExporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown stops the exporter. The log records held in memory are kept so
+// they can be verified after the LoggerProvider is shut down.
+func (e *InMemoryExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return ctx.Err()
+}
+
+// Reset the current in-memory storage.
+func (e *InMemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.records = nil
+}
+
+// GetRecords returns the current in-memory stored log records, in the order
+// they were exported.
+func (e *InMemoryExporter) GetRecords() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ret := make([]sdklog.Record, len(e.records))
+	copy(ret, e.records)
+	return ret
+}
+
+// FindRecordsBySeverity returns the records with a severity greater than or
+// equal to minimum, in their original order.
+func FindRecordsBySeverity(records []sdklog.Record, minimum log.Severity) []sdklog.Record {
+	var out []sdklog.Record
+	for _, r := range records {
+		if r.Severity() >= minimum {
+			out = append(out, r)
+		}
+	}
+	return out
+}
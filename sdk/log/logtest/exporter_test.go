@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+func TestInMemoryExporter(t *testing.T) {
+	exp := NewInMemoryExporter()
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exp)))
+	logger := provider.Logger("logger")
+
+	ctx := context.Background()
+	for _, s := range []log.Severity{log.SeverityInfo, log.SeverityError, log.SeverityWarn, log.SeverityError2} {
+		var r log.Record
+		r.SetSeverity(s)
+		r.SetBody(log.StringValue(s.String()))
+		logger.Emit(ctx, r)
+	}
+	require.NoError(t, provider.Shutdown(ctx))
+
+	// The records exported when the provider is shut down are kept.
+	got := exp.GetRecords()
+	require.Len(t, got, 4)
+	assert.Equal(t, "INFO", got[0].Body().AsString())
+
+	errs := FindRecordsBySeverity(got, log.SeverityError)
+	require.Len(t, errs, 2)
+	assert.Equal(t, log.SeverityError, errs[0].Severity())
+	assert.Equal(t, log.SeverityError2, errs[1].Severity())
+	assert.Len(t, FindRecordsBySeverity(got, log.SeverityWarn), 3)
+	assert.Empty(t, FindRecordsBySeverity(got, log.SeverityFatal))
+
+	// Export fails after Shutdown.
+	err := exp.Export(ctx, []sdklog.Record{RecordFactory{}.NewRecord()})
+	assert.ErrorIs(t, err, errShutdown)
+	assert.Len(t, exp.GetRecords(), 4)
+
+	exp.Reset()
+	assert.Empty(t, exp.GetRecords())
+}
+
+func TestInMemoryExporterShutdownContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	exp := NewInMemoryExporter()
+	assert.ErrorIs(t, exp.Shutdown(ctx), context.Canceled)
+	assert.ErrorIs(t, exp.Export(context.Background(), nil), errShutdown, "shut down")
+}
+
+func TestInMemoryExporterCopies(t *testing.T) {
+	records := []sdklog.Record{RecordFactory{
+		Attributes: []log.KeyValue{log.String("a", "b")},
+	}.NewRecord()}
+
+	exp := NewInMemoryExporter()
+	require.NoError(t, exp.Export(context.Background(), records))
+
+	records[0].SetAttributes(log.String("c", "d"))
+	got := exp.GetRecords()
+	require.Len(t, got, 1)
+	assertAttributes(t, []log.KeyValue{log.String("a", "b")}, got[0])
+}
+
+func TestInMemoryExporterContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	exp := NewInMemoryExporter()
+	assert.ErrorIs(t, exp.Export(ctx, []sdklog.Record{{}}), context.Canceled)
+	assert.ErrorIs(t, exp.ForceFlush(ctx), context.Canceled)
+	assert.Empty(t, exp.GetRecords())
+}
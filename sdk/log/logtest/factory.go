@@ -38,6 +38,11 @@ type RecordFactory struct {
 	DroppedAttributes         int
 	AttributeValueLengthLimit int
 	AttributeCountLimit       int
+
+	DroppedBodyValues      int
+	BodyLengthLimit        int
+	ValueDepthLimit        int
+	ValueElementCountLimit int
 }
 
 // NewRecord returns a [sdklog.Record] configured from the values of f.
@@ -64,6 +69,10 @@ func (f RecordFactory) NewRecord() sdklog.Record {
 	set(r, "dropped", f.DroppedAttributes)
 	set(r, "attributeCountLimit", f.AttributeCountLimit)
 	set(r, "attributeValueLengthLimit", f.AttributeValueLengthLimit)
+	set(r, "droppedBody", f.DroppedBodyValues)
+	set(r, "bodyLengthLimit", f.BodyLengthLimit)
+	set(r, "valueDepthLimit", f.ValueDepthLimit)
+	set(r, "valueElementCountLimit", f.ValueElementCountLimit)
 
 	return *r
 }
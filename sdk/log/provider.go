@@ -20,16 +20,26 @@ import (
 const (
 	defaultAttrCntLim    = 128
 	defaultAttrValLenLim = -1
+	defaultBodyLenLim    = -1
+	defaultValDepthLim   = -1
+	defaultValElemCntLim = -1
 
 	envarAttrCntLim    = "OTEL_LOGRECORD_ATTRIBUTE_COUNT_LIMIT"
 	envarAttrValLenLim = "OTEL_LOGRECORD_ATTRIBUTE_VALUE_LENGTH_LIMIT"
+	envarBodyLenLim    = "OTEL_LOGRECORD_BODY_LENGTH_LIMIT"
+	envarValDepthLim   = "OTEL_LOGRECORD_VALUE_DEPTH_LIMIT"
+	envarValElemCntLim = "OTEL_LOGRECORD_VALUE_ELEMENT_COUNT_LIMIT"
 )
 
 type providerConfig struct {
-	resource      *resource.Resource
-	processors    []Processor
-	attrCntLim    setting[int]
-	attrValLenLim setting[int]
+	resource           *resource.Resource
+	processors         []Processor
+	attrCntLim         setting[int]
+	attrValLenLim      setting[int]
+	bodyLenLim         setting[int]
+	valDepthLim        setting[int]
+	valElemCntLim      setting[int]
+	loggerConfigurator LoggerConfigurator
 }
 
 func newProviderConfig(opts []LoggerProviderOption) providerConfig {
@@ -52,6 +62,21 @@ func newProviderConfig(opts []LoggerProviderOption) providerConfig {
 		fallback[int](defaultAttrValLenLim),
 	)
 
+	c.bodyLenLim = c.bodyLenLim.Resolve(
+		getenv[int](envarBodyLenLim),
+		fallback[int](defaultBodyLenLim),
+	)
+
+	c.valDepthLim = c.valDepthLim.Resolve(
+		getenv[int](envarValDepthLim),
+		fallback[int](defaultValDepthLim),
+	)
+
+	c.valElemCntLim = c.valElemCntLim.Resolve(
+		getenv[int](envarValElemCntLim),
+		fallback[int](defaultValElemCntLim),
+	)
+
 	return c
 }
 
@@ -64,9 +89,13 @@ type LoggerProvider struct {
 	processors                []Processor
 	attributeCountLimit       int
 	attributeValueLengthLimit int
+	bodyLengthLimit           int
+	valueDepthLimit           int
+	valueElementCountLimit    int
 
-	loggersMu sync.Mutex
-	loggers   map[instrumentation.Scope]*logger
+	loggersMu          sync.Mutex
+	loggers            map[instrumentation.Scope]*logger
+	loggerConfigurator LoggerConfigurator
 
 	stopped atomic.Bool
 }
@@ -87,6 +116,10 @@ func NewLoggerProvider(opts ...LoggerProviderOption) *LoggerProvider {
 		processors:                cfg.processors,
 		attributeCountLimit:       cfg.attrCntLim.Value,
 		attributeValueLengthLimit: cfg.attrValLenLim.Value,
+		bodyLengthLimit:           cfg.bodyLenLim.Value,
+		valueDepthLimit:           cfg.valDepthLim.Value,
+		valueElementCountLimit:    cfg.valElemCntLim.Value,
+		loggerConfigurator:        cfg.loggerConfigurator,
 	}
 }
 
@@ -116,6 +149,7 @@ func (p *LoggerProvider) Logger(name string, opts ...log.LoggerOption) log.Logge
 
 	if p.loggers == nil {
 		l := newLogger(p, scope)
+		l.setConfig(p.loggerConfig(scope))
 		p.loggers = map[instrumentation.Scope]*logger{scope: l}
 		return l
 	}
@@ -123,12 +157,39 @@ func (p *LoggerProvider) Logger(name string, opts ...log.LoggerOption) log.Logge
 	l, ok := p.loggers[scope]
 	if !ok {
 		l = newLogger(p, scope)
+		l.setConfig(p.loggerConfig(scope))
 		p.loggers[scope] = l
 	}
 
 	return l
 }
 
+// SetLoggerConfigurator replaces the LoggerConfigurator used by p to compute
+// the LoggerConfig of its Loggers. The new configurator is applied to all
+// Loggers already created by p, as well as the ones created afterwards.
+//
+// Passing nil restores the default behavior of all Loggers being enabled.
+//
+// This method can be called concurrently.
+func (p *LoggerProvider) SetLoggerConfigurator(configurator LoggerConfigurator) {
+	p.loggersMu.Lock()
+	defer p.loggersMu.Unlock()
+
+	p.loggerConfigurator = configurator
+	for scope, l := range p.loggers {
+		l.setConfig(p.loggerConfig(scope))
+	}
+}
+
+// loggerConfig returns the LoggerConfig for scope. The loggersMu lock needs to
+// be held when calling this method.
+func (p *LoggerProvider) loggerConfig(scope instrumentation.Scope) LoggerConfig {
+	if p.loggerConfigurator == nil {
+		return LoggerConfig{}
+	}
+	return p.loggerConfigurator(scope)
+}
+
 // Shutdown shuts down the provider and all processors.
 //
 // This method can be called concurrently.
@@ -238,3 +299,85 @@ func WithAttributeValueLengthLimit(limit int) LoggerProviderOption {
 		return cfg
 	})
 }
+
+// WithBodyLengthLimit sets the maximum allowed length of the string and
+// byte slice values of a log record body. This includes the values nested
+// in map and slice body values. Any value longer than this limit will be
+// truncated to this length.
+//
+// Setting this to zero means the string and byte slice values will be empty.
+//
+// Setting this to a negative value means no limit is applied.
+//
+// If the OTEL_LOGRECORD_BODY_LENGTH_LIMIT environment variable is set, and
+// this option is not passed, that variable value will be used.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, no limit (-1) will be used.
+func WithBodyLengthLimit(limit int) LoggerProviderOption {
+	return loggerProviderOptionFunc(func(cfg providerConfig) providerConfig {
+		cfg.bodyLenLim = newSetting(limit)
+		return cfg
+	})
+}
+
+// WithValueDepthLimit sets the maximum allowed nesting depth of map and
+// slice values in a log record body and attributes. A map or slice value
+// that is a direct body or attribute value has a depth of 1, its map or
+// slice elements a depth of 2, and so on. Any map or slice value deeper than
+// this limit will be replaced with an empty value. The values replaced in
+// the body are counted by Record.DroppedBodyValues, the ones replaced in
+// attributes by Record.DroppedAttributes.
+//
+// Setting this to zero means all map and slice values will be replaced with
+// an empty value.
+//
+// Setting this to a negative value means no limit is applied.
+//
+// If the OTEL_LOGRECORD_VALUE_DEPTH_LIMIT environment variable is set, and
+// this option is not passed, that variable value will be used.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, no limit (-1) will be used.
+func WithValueDepthLimit(limit int) LoggerProviderOption {
+	return loggerProviderOptionFunc(func(cfg providerConfig) providerConfig {
+		cfg.valDepthLim = newSetting(limit)
+		return cfg
+	})
+}
+
+// WithValueElementCountLimit sets the maximum allowed number of elements in
+// the map and slice values of a log record body and attributes. Any element
+// added to a map or slice value once this limit is reached will be dropped.
+// The elements dropped from the body are counted by Record.DroppedBodyValues,
+// the ones dropped from attributes by Record.DroppedAttributes.
+//
+// Setting this to zero means all the elements of map and slice values will be
+// dropped.
+//
+// Setting this to a negative value means no limit is applied.
+//
+// If the OTEL_LOGRECORD_VALUE_ELEMENT_COUNT_LIMIT environment variable is set,
+// and this option is not passed, that variable value will be used.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, no limit (-1) will be used.
+func WithValueElementCountLimit(limit int) LoggerProviderOption {
+	return loggerProviderOptionFunc(func(cfg providerConfig) providerConfig {
+		cfg.valElemCntLim = newSetting(limit)
+		return cfg
+	})
+}
+
+// WithLoggerConfigurator sets the LoggerConfigurator used to compute the
+// LoggerConfig of each Logger the LoggerProvider creates. The configurator
+// can be replaced after the LoggerProvider is created using its
+// SetLoggerConfigurator method.
+//
+// By default, if this option is not used, all Loggers are enabled.
+func WithLoggerConfigurator(configurator LoggerConfigurator) LoggerProviderOption {
+	return loggerProviderOptionFunc(func(cfg providerConfig) providerConfig {
+		cfg.loggerConfigurator = configurator
+		return cfg
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/noop"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -73,6 +74,9 @@ func TestNewLoggerProviderConfiguration(t *testing.T) {
 	p0, p1 := newProcessor("0"), newProcessor("1")
 	attrCntLim := 12
 	attrValLenLim := 21
+	bodyLenLim := 1024
+	valDepthLim := 3
+	valElemCntLim := 7
 
 	testcases := []struct {
 		name    string
@@ -86,6 +90,9 @@ func TestNewLoggerProviderConfiguration(t *testing.T) {
 				resource:                  resource.Default(),
 				attributeCountLimit:       defaultAttrCntLim,
 				attributeValueLengthLimit: defaultAttrValLenLim,
+				bodyLengthLimit:           defaultBodyLenLim,
+				valueDepthLimit:           defaultValDepthLim,
+				valueElementCountLimit:    defaultValElemCntLim,
 			},
 		},
 		{
@@ -96,12 +103,18 @@ func TestNewLoggerProviderConfiguration(t *testing.T) {
 				WithProcessor(p1),
 				WithAttributeCountLimit(attrCntLim),
 				WithAttributeValueLengthLimit(attrValLenLim),
+				WithBodyLengthLimit(bodyLenLim),
+				WithValueDepthLimit(valDepthLim),
+				WithValueElementCountLimit(valElemCntLim),
 			},
 			want: &LoggerProvider{
 				resource:                  res,
 				processors:                []Processor{p0, p1},
 				attributeCountLimit:       attrCntLim,
 				attributeValueLengthLimit: attrValLenLim,
+				bodyLengthLimit:           bodyLenLim,
+				valueDepthLimit:           valDepthLim,
+				valueElementCountLimit:    valElemCntLim,
 			},
 		},
 		{
@@ -109,11 +122,17 @@ func TestNewLoggerProviderConfiguration(t *testing.T) {
 			envars: map[string]string{
 				envarAttrCntLim:    strconv.Itoa(attrCntLim),
 				envarAttrValLenLim: strconv.Itoa(attrValLenLim),
+				envarBodyLenLim:    strconv.Itoa(bodyLenLim),
+				envarValDepthLim:   strconv.Itoa(valDepthLim),
+				envarValElemCntLim: strconv.Itoa(valElemCntLim),
 			},
 			want: &LoggerProvider{
 				resource:                  resource.Default(),
 				attributeCountLimit:       attrCntLim,
 				attributeValueLengthLimit: attrValLenLim,
+				bodyLengthLimit:           bodyLenLim,
+				valueDepthLimit:           valDepthLim,
+				valueElementCountLimit:    valElemCntLim,
 			},
 		},
 		{
@@ -121,11 +140,17 @@ func TestNewLoggerProviderConfiguration(t *testing.T) {
 			envars: map[string]string{
 				envarAttrCntLim:    "invalid attributeCountLimit",
 				envarAttrValLenLim: "invalid attributeValueLengthLimit",
+				envarBodyLenLim:    "invalid bodyLengthLimit",
+				envarValDepthLim:   "invalid valueDepthLimit",
+				envarValElemCntLim: "invalid valueElementCountLimit",
 			},
 			want: &LoggerProvider{
 				resource:                  resource.Default(),
 				attributeCountLimit:       defaultAttrCntLim,
 				attributeValueLengthLimit: defaultAttrValLenLim,
+				bodyLengthLimit:           defaultBodyLenLim,
+				valueDepthLimit:           defaultValDepthLim,
+				valueElementCountLimit:    defaultValElemCntLim,
 			},
 		},
 		{
@@ -133,16 +158,25 @@ func TestNewLoggerProviderConfiguration(t *testing.T) {
 			envars: map[string]string{
 				envarAttrCntLim:    strconv.Itoa(100),
 				envarAttrValLenLim: strconv.Itoa(101),
+				envarBodyLenLim:    strconv.Itoa(102),
+				envarValDepthLim:   strconv.Itoa(103),
+				envarValElemCntLim: strconv.Itoa(104),
 			},
 			options: []LoggerProviderOption{
 				// These override the environment variables.
 				WithAttributeCountLimit(attrCntLim),
 				WithAttributeValueLengthLimit(attrValLenLim),
+				WithBodyLengthLimit(bodyLenLim),
+				WithValueDepthLimit(valDepthLim),
+				WithValueElementCountLimit(valElemCntLim),
 			},
 			want: &LoggerProvider{
 				resource:                  resource.Default(),
 				attributeCountLimit:       attrCntLim,
 				attributeValueLengthLimit: attrValLenLim,
+				bodyLengthLimit:           bodyLenLim,
+				valueDepthLimit:           valDepthLim,
+				valueElementCountLimit:    valElemCntLim,
 			},
 		},
 	}
@@ -230,6 +264,54 @@ func TestLoggerProviderLogger(t *testing.T) {
 	})
 }
 
+func TestLoggerProviderLoggerConfigurator(t *testing.T) {
+	disableFoo := func(s instrumentation.Scope) LoggerConfig {
+		return LoggerConfig{Disabled: s.Name == "foo"}
+	}
+
+	ctx := context.Background()
+	var r log.Record
+	r.SetBody(log.StringValue("message"))
+
+	t.Run("Option", func(t *testing.T) {
+		proc := newProcessor("")
+		p := NewLoggerProvider(
+			WithProcessor(proc),
+			WithLoggerConfigurator(disableFoo),
+		)
+
+		foo, bar := p.Logger("foo"), p.Logger("bar")
+		assert.False(t, foo.Enabled(ctx, r), "disabled Logger enabled")
+		assert.True(t, bar.Enabled(ctx, r), "enabled Logger disabled")
+
+		foo.Emit(ctx, r)
+		bar.Emit(ctx, r)
+		require.Len(t, proc.records, 1, "disabled Logger emitted")
+		assert.Equal(t, "bar", proc.records[0].InstrumentationScope().Name)
+	})
+
+	t.Run("SetLoggerConfigurator", func(t *testing.T) {
+		proc := newProcessor("")
+		p := NewLoggerProvider(WithProcessor(proc))
+
+		foo := p.Logger("foo")
+		require.True(t, foo.Enabled(ctx, r), "Logger disabled by default")
+
+		p.SetLoggerConfigurator(disableFoo)
+		assert.False(t, foo.Enabled(ctx, r), "existing Logger not disabled")
+		foo.Emit(ctx, r)
+		assert.Empty(t, proc.records, "disabled Logger emitted")
+
+		assert.Same(t, foo, p.Logger("foo"))
+		assert.True(t, p.Logger("bar").Enabled(ctx, r), "new Logger disabled")
+
+		p.SetLoggerConfigurator(nil)
+		assert.True(t, foo.Enabled(ctx, r), "Logger not re-enabled")
+		foo.Emit(ctx, r)
+		assert.Len(t, proc.records, 1, "re-enabled Logger did not emit")
+	})
+}
+
 func TestLoggerProviderShutdown(t *testing.T) {
 	t.Run("Once", func(t *testing.T) {
 		proc := newProcessor("")
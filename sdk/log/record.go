@@ -27,6 +27,10 @@ var logAttrDropped = sync.OnceFunc(func() {
 	global.Warn("limit reached: dropping log Record attributes")
 })
 
+var logBodyDropped = sync.OnceFunc(func() {
+	global.Warn("limit reached: dropping log Record body values")
+})
+
 // indexPool is a pool of index maps used for de-duplication.
 var indexPool = sync.Pool{
 	New: func() any { return make(map[string]int) },
@@ -74,6 +78,10 @@ type Record struct {
 	// were reached.
 	dropped int
 
+	// droppedBody is the count of values nested in body that have been
+	// dropped when limits were reached.
+	droppedBody int
+
 	traceID    trace.TraceID
 	spanID     trace.SpanID
 	traceFlags trace.TraceFlags
@@ -86,6 +94,14 @@ type Record struct {
 
 	attributeValueLengthLimit int
 	attributeCountLimit       int
+
+	// The limits below are only applied if valueLimitsSet is true, i.e. they
+	// were configured by the LoggerProvider the Record was created with. A
+	// zero-value Record does not limit its body and nested values.
+	valueLimitsSet         bool
+	bodyLengthLimit        int
+	valueDepthLimit        int
+	valueElementCountLimit int
 }
 
 func (r *Record) addDropped(n int) {
@@ -147,7 +163,22 @@ func (r *Record) Body() log.Value {
 
 // SetBody sets the body of the log record.
 func (r *Record) SetBody(v log.Value) {
-	r.body = v
+	limits := valueLimits{
+		length:       r.bodyLengthLimit,
+		bytes:        true,
+		depth:        r.valueDepthLimit,
+		elementCount: r.valueElementCountLimit,
+	}
+	if !r.valueLimitsSet || limits.none() {
+		r.body = v
+		r.droppedBody = 0
+		return
+	}
+	// The limits are applied in place, do not modify the value of the caller.
+	r.body, r.droppedBody = limits.apply(cloneValue(v), 1)
+	if r.droppedBody > 0 {
+		logBodyDropped()
+	}
 }
 
 // WalkAttributes walks all attributes the log record holds by calling f for
@@ -331,11 +362,19 @@ func (r *Record) AttributesLen() int {
 }
 
 // DroppedAttributes returns the number of attributes dropped due to limits
-// being reached.
+// being reached. It includes the map and slice elements nested in the
+// attribute values that were dropped due to the value depth and element count
+// limits.
 func (r *Record) DroppedAttributes() int {
 	return r.dropped
 }
 
+// DroppedBodyValues returns the number of map and slice elements nested in
+// the body that were dropped due to limits being reached.
+func (r *Record) DroppedBodyValues() int {
+	return r.droppedBody
+}
+
 // TraceID returns the trace ID or empty array.
 func (r *Record) TraceID() trace.TraceID {
 	return r.traceID
@@ -396,27 +435,114 @@ func (r *Record) applyAttrLimits(attr log.KeyValue) log.KeyValue {
 }
 
 func (r *Record) applyValueLimits(val log.Value) log.Value {
+	limits := valueLimits{
+		length:       r.attributeValueLengthLimit,
+		depth:        -1,
+		elementCount: -1,
+		dedup:        true,
+	}
+	if r.valueLimitsSet {
+		limits.depth = r.valueDepthLimit
+		limits.elementCount = r.valueElementCountLimit
+	}
+	val, dropped := limits.apply(val, 1)
+	if dropped > 0 {
+		r.addDropped(dropped)
+	}
+	return val
+}
+
+// valueLimits are the limits applied to a [log.Value].
+type valueLimits struct {
+	// length is the maximum length of string values. No limit is applied if
+	// it is less than zero.
+	length int
+	// bytes defines if length is also applied to byte slice values.
+	bytes bool
+	// depth is the maximum nesting depth of map and slice values. No limit
+	// is applied if it is less than zero.
+	depth int
+	// elementCount is the maximum number of elements of map and slice
+	// values. No limit is applied if it is less than zero.
+	elementCount int
+	// dedup defines if map values are deduplicated.
+	dedup bool
+}
+
+// none returns if l does not apply any limit.
+func (l valueLimits) none() bool {
+	return l.length < 0 && l.depth < 0 && l.elementCount < 0 && !l.dedup
+}
+
+// apply returns val, which is nested at depth, with the limits l applied
+// along with the number of nested values dropped.
+func (l valueLimits) apply(val log.Value, depth int) (log.Value, int) {
 	switch val.Kind() {
 	case log.KindString:
-		s := val.AsString()
-		if len(s) > r.attributeValueLengthLimit {
-			val = log.StringValue(truncate(s, r.attributeValueLengthLimit))
+		if s := val.AsString(); l.length >= 0 && len(s) > l.length {
+			val = log.StringValue(truncate(s, l.length))
+		}
+	case log.KindBytes:
+		if b := val.AsBytes(); l.bytes && l.length >= 0 && len(b) > l.length {
+			val = log.BytesValue(b[:l.length])
 		}
 	case log.KindSlice:
+		if l.depth >= 0 && depth > l.depth {
+			return log.Value{}, 1
+		}
+		var dropped int
 		sl := val.AsSlice()
+		if l.elementCount >= 0 && len(sl) > l.elementCount {
+			dropped += len(sl) - l.elementCount
+			sl = sl[:l.elementCount]
+		}
 		for i := range sl {
-			sl[i] = r.applyValueLimits(sl[i])
+			var n int
+			sl[i], n = l.apply(sl[i], depth+1)
+			dropped += n
 		}
-		val = log.SliceValue(sl...)
+		return log.SliceValue(sl...), dropped
 	case log.KindMap:
-		// Deduplicate then truncate. Do not do at the same time to avoid
-		// wasted truncation operations.
-		kvs, dropped := dedup(val.AsMap())
-		r.addDropped(dropped)
+		if l.depth >= 0 && depth > l.depth {
+			return log.Value{}, 1
+		}
+		var dropped int
+		kvs := val.AsMap()
+		if l.dedup {
+			// Deduplicate then truncate. Do not do at the same time to avoid
+			// wasted truncation operations.
+			kvs, dropped = dedup(kvs)
+		}
+		if l.elementCount >= 0 && len(kvs) > l.elementCount {
+			dropped += len(kvs) - l.elementCount
+			kvs = kvs[:l.elementCount]
+		}
+		for i := range kvs {
+			var n int
+			kvs[i].Value, n = l.apply(kvs[i].Value, depth+1)
+			dropped += n
+		}
+		return log.MapValue(kvs...), dropped
+	}
+	return val, 0
+}
+
+// cloneValue returns a copy of val not sharing the map and slice values
+// nested in val.
+func cloneValue(val log.Value) log.Value {
+	switch val.Kind() {
+	case log.KindSlice:
+		sl := slices.Clone(val.AsSlice())
+		for i := range sl {
+			sl[i] = cloneValue(sl[i])
+		}
+		return log.SliceValue(sl...)
+	case log.KindMap:
+		kvs := slices.Clone(val.AsMap())
 		for i := range kvs {
-			kvs[i] = r.applyAttrLimits(kvs[i])
+			kvs[i].Value = cloneValue(kvs[i].Value)
 		}
-		val = log.MapValue(kvs...)
+		return log.MapValue(kvs...)
 	}
 	return val
 }
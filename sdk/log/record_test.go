@@ -556,6 +556,208 @@ func TestApplyAttrLimitsTruncation(t *testing.T) {
 	}
 }
 
+func TestApplyValueNestingLimits(t *testing.T) {
+	nested := func() log.Value {
+		return log.MapValue(
+			log.String("a", "foo"),
+			log.Slice("b", log.Int64Value(1), log.Int64Value(2), log.Int64Value(3)),
+			log.Map("c", log.Map("d", log.Bool("e", true))),
+		)
+	}
+
+	testcases := []struct {
+		name         string
+		depth, count int
+		want         log.Value
+		dropped      int
+	}{
+		{
+			name:  "NoLimits",
+			depth: -1,
+			count: -1,
+			want:  nested(),
+		},
+		{
+			name:    "Depth",
+			depth:   2,
+			count:   -1,
+			want:    log.MapValue(log.String("a", "foo"), log.Slice("b", log.Int64Value(1), log.Int64Value(2), log.Int64Value(3)), log.Map("c", log.Empty("d"))),
+			dropped: 1,
+		},
+		{
+			name:    "DepthTopLevel",
+			depth:   1,
+			count:   -1,
+			want:    log.MapValue(log.String("a", "foo"), log.Empty("b"), log.Empty("c")),
+			dropped: 2,
+		},
+		{
+			name:    "ElementCount",
+			depth:   -1,
+			count:   2,
+			want:    log.MapValue(log.String("a", "foo"), log.Slice("b", log.Int64Value(1), log.Int64Value(2))),
+			dropped: 2,
+		},
+		{
+			name:    "DepthZero",
+			depth:   0,
+			count:   -1,
+			want:    log.Value{},
+			dropped: 1,
+		},
+		{
+			name:    "ElementCountZero",
+			depth:   -1,
+			count:   0,
+			want:    log.MapValue(),
+			dropped: 3,
+		},
+		{
+			name:    "DepthAndElementCount",
+			depth:   1,
+			count:   1,
+			want:    log.MapValue(log.String("a", "foo")),
+			dropped: 2,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("Body", func(t *testing.T) {
+				r := Record{
+					valueLimitsSet:         true,
+					bodyLengthLimit:        -1,
+					valueDepthLimit:        tc.depth,
+					valueElementCountLimit: tc.count,
+				}
+				r.SetBody(nested())
+				assert.Truef(t, tc.want.Equal(r.Body()), "%s != %s", tc.want, r.Body())
+				assert.Equal(t, tc.dropped, r.DroppedBodyValues(), "dropped body values")
+				assert.Equal(t, 0, r.DroppedAttributes(), "dropped attributes")
+			})
+
+			t.Run("Attributes", func(t *testing.T) {
+				r := Record{
+					attributeValueLengthLimit: -1,
+					valueLimitsSet:            true,
+					valueDepthLimit:           tc.depth,
+					valueElementCountLimit:    tc.count,
+				}
+				r.AddAttributes(log.KeyValue{Key: "key", Value: nested()})
+				assertKV(t, r, log.KeyValue{Key: "key", Value: tc.want})
+				assert.Equal(t, tc.dropped, r.DroppedAttributes(), "dropped attributes")
+				assert.Equal(t, 0, r.DroppedBodyValues(), "dropped body values")
+			})
+		})
+	}
+}
+
+func TestRecordSetBodyDoesNotModifyValue(t *testing.T) {
+	nested := func() log.Value {
+		return log.SliceValue(
+			log.StringValue("foo"),
+			log.MapValue(
+				log.String("a", "bar"),
+				log.Slice("b", log.Int64Value(1), log.Int64Value(2)),
+				log.Map("c", log.Bool("d", true)),
+			),
+			log.Int64Value(100),
+		)
+	}
+
+	v := nested()
+	r := Record{
+		valueLimitsSet:         true,
+		bodyLengthLimit:        1,
+		valueDepthLimit:        2,
+		valueElementCountLimit: 2,
+	}
+	r.SetBody(v)
+
+	want := log.SliceValue(
+		log.StringValue("f"),
+		log.MapValue(log.String("a", "b"), log.Empty("b")),
+	)
+	assert.Truef(t, want.Equal(r.Body()), "%s != %s", want, r.Body())
+	assert.Truef(t, nested().Equal(v), "value passed to SetBody modified: %s", v)
+}
+
+func TestRecordZeroValueLimits(t *testing.T) {
+	body := log.MapValue(log.String("a", "foo"), log.Slice("b", log.Int64Value(1)))
+	attr := log.Map("c", log.Slice("d", log.StringValue("bar")))
+
+	var r Record
+	r.attributeValueLengthLimit = -1
+	r.SetBody(body)
+	r.AddAttributes(attr)
+
+	assert.Truef(t, body.Equal(r.Body()), "%s != %s", body, r.Body())
+	assert.Equal(t, 0, r.DroppedBodyValues())
+	assertKV(t, r, attr)
+	assert.Equal(t, 0, r.DroppedAttributes())
+}
+
+func TestRecordBodyLengthLimit(t *testing.T) {
+	testcases := []struct {
+		name        string
+		limit       int
+		input, want log.Value
+	}{
+		{
+			name:  "NoLimit",
+			limit: -1,
+			input: log.StringValue("foo"),
+			want:  log.StringValue("foo"),
+		},
+		{
+			name:  "Zero",
+			limit: 0,
+			input: log.StringValue("foo"),
+			want:  log.StringValue(""),
+		},
+		{
+			name:  "String",
+			limit: 2,
+			input: log.StringValue("foo"),
+			want:  log.StringValue("fo"),
+		},
+		{
+			name:  "Bytes",
+			limit: 2,
+			input: log.BytesValue([]byte("foo")),
+			want:  log.BytesValue([]byte("fo")),
+		},
+		{
+			name:  "Nested",
+			limit: 2,
+			input: log.SliceValue(
+				log.StringValue("foo"),
+				log.MapValue(log.String("bar", "baz")),
+				log.Int64Value(100),
+			),
+			want: log.SliceValue(
+				log.StringValue("fo"),
+				log.MapValue(log.String("bar", "ba")),
+				log.Int64Value(100),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Record{
+				valueLimitsSet:         true,
+				bodyLengthLimit:        tc.limit,
+				valueDepthLimit:        -1,
+				valueElementCountLimit: -1,
+			}
+			r.SetBody(tc.input)
+			assert.Truef(t, tc.want.Equal(r.Body()), "%s != %s", tc.want, r.Body())
+			assert.Equal(t, 0, r.DroppedBodyValues())
+		})
+	}
+}
+
 func assertKV(t *testing.T, r Record, kv log.KeyValue) {
 	t.Helper()
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sync"
 
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -16,6 +17,8 @@ type config struct {
 	res     *resource.Resource
 	readers []Reader
 	views   []View
+
+	meterConfigurator MeterConfigurator
 }
 
 // readerSignals returns a force-flush and shutdown function for a
@@ -135,3 +138,42 @@ func WithView(views ...View) Option {
 		return cfg
 	})
 }
+
+// WithMeterConfigurator sets the MeterConfigurator used to compute the
+// MeterConfig of each Meter the MeterProvider creates. The configurator can
+// be replaced after the MeterProvider is created using its
+// SetMeterConfigurator method.
+//
+// By default, if this option is not used, all Meters are enabled.
+func WithMeterConfigurator(configurator MeterConfigurator) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.meterConfigurator = configurator
+		return cfg
+	})
+}
+
+// MeterConfig is the configuration of a Meter.
+//
+// The zero value is the default configuration: an enabled Meter.
+//
+// This type is experimental and is based on the Meter configurator defined
+// in the OpenTelemetry specification. It may change or be removed in a
+// future release.
+type MeterConfig struct {
+	// Disabled defines if the Meter is disabled.
+	//
+	// A disabled Meter behaves like a no-op Meter: measurements made by its
+	// instruments are dropped, its callbacks are not called, and no metric
+	// data is produced for its instrumentation scope.
+	Disabled bool
+}
+
+// MeterConfigurator computes the MeterConfig of the Meter identified by an
+// instrumentation scope.
+//
+// A MeterConfigurator needs to be safe to call concurrently.
+//
+// This type is experimental and is based on the Meter configurator defined
+// in the OpenTelemetry specification. It may change or be removed in a
+// future release.
+type MeterConfigurator func(instrumentation.Scope) MeterConfig
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -172,6 +173,9 @@ func (i instID) normalize() instID {
 
 type int64Inst struct {
 	measures []aggregate.Measure[int64]
+	// disabled reports if the meter that created the instrument is disabled.
+	// A nil value means the instrument is always enabled.
+	disabled *atomic.Bool
 
 	embedded.Int64Counter
 	embedded.Int64UpDownCounter
@@ -197,6 +201,9 @@ func (i *int64Inst) Record(ctx context.Context, val int64, opts ...metric.Record
 }
 
 func (i *int64Inst) aggregate(ctx context.Context, val int64, s attribute.Set) { // nolint:revive  // okay to shadow pkg with method.
+	if i.disabled != nil && i.disabled.Load() {
+		return
+	}
 	for _, in := range i.measures {
 		in(ctx, val, s)
 	}
@@ -204,6 +211,9 @@ func (i *int64Inst) aggregate(ctx context.Context, val int64, s attribute.Set) {
 
 type float64Inst struct {
 	measures []aggregate.Measure[float64]
+	// disabled reports if the meter that created the instrument is disabled.
+	// A nil value means the instrument is always enabled.
+	disabled *atomic.Bool
 
 	embedded.Float64Counter
 	embedded.Float64UpDownCounter
@@ -229,6 +239,9 @@ func (i *float64Inst) Record(ctx context.Context, val float64, opts ...metric.Re
 }
 
 func (i *float64Inst) aggregate(ctx context.Context, val float64, s attribute.Set) {
+	if i.disabled != nil && i.disabled.Load() {
+		return
+	}
 	for _, in := range i.measures {
 		in(ctx, val, s)
 	}
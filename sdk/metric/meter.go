@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/metric"
@@ -30,6 +31,9 @@ type meter struct {
 	scope instrumentation.Scope
 	pipes pipelines
 
+	// disabled is true if the meter is disabled by a MeterConfig.
+	disabled atomic.Bool
+
 	int64Insts             *cacheWithErr[instID, *int64Inst]
 	float64Insts           *cacheWithErr[instID, *float64Inst]
 	int64ObservableInsts   *cacheWithErr[instID, int64Observable]
@@ -49,7 +53,7 @@ func newMeter(s instrumentation.Scope, p pipelines) *meter {
 	var int64ObservableInsts cacheWithErr[instID, int64Observable]
 	var float64ObservableInsts cacheWithErr[instID, float64Observable]
 
-	return &meter{
+	m := &meter{
 		scope:                  s,
 		pipes:                  p,
 		int64Insts:             &int64Insts,
@@ -59,6 +63,13 @@ func newMeter(s instrumentation.Scope, p pipelines) *meter {
 		int64Resolver:          newResolver[int64](p, &viewCache),
 		float64Resolver:        newResolver[float64](p, &viewCache),
 	}
+	p.registerScope(s, &m.disabled)
+	return m
+}
+
+// setConfig applies the MeterConfig c to m.
+func (m *meter) setConfig(c MeterConfig) {
+	m.disabled.Store(c.Disabled)
 }
 
 // Compile-time check meter implements metric.Meter.
@@ -153,7 +164,12 @@ func (m *meter) int64ObservableInstrument(id Instrument, callbacks []metric.Int6
 			for _, cback := range callbacks {
 				inst := int64Observer{measures: in}
 				fn := cback
-				insert.addCallback(func(ctx context.Context) error { return fn(ctx, inst) })
+				insert.addCallback(func(ctx context.Context) error {
+					if m.disabled.Load() {
+						return nil
+					}
+					return fn(ctx, inst)
+				})
 			}
 		}
 		return inst, validateInstrumentName(id.Name)
@@ -312,7 +328,12 @@ func (m *meter) float64ObservableInstrument(id Instrument, callbacks []metric.Fl
 			for _, cback := range callbacks {
 				inst := float64Observer{measures: in}
 				fn := cback
-				insert.addCallback(func(ctx context.Context) error { return fn(ctx, inst) })
+				insert.addCallback(func(ctx context.Context) error {
+					if m.disabled.Load() {
+						return nil
+					}
+					return fn(ctx, inst)
+				})
 			}
 		}
 		return inst, validateInstrumentName(id.Name)
@@ -481,7 +502,12 @@ func (m *meter) RegisterCallback(f metric.Callback, insts ...metric.Observable)
 	}
 
 	// Some or all instruments were valid.
-	cback := func(ctx context.Context) error { return f(ctx, reg) }
+	cback := func(ctx context.Context) error {
+		if m.disabled.Load() {
+			return nil
+		}
+		return f(ctx, reg)
+	}
 	return m.pipes.registerMultiCallback(cback), err
 }
 
@@ -633,7 +659,7 @@ func (p int64InstProvider) lookup(kind InstrumentKind, name, desc, u string) (*i
 		Kind:        kind,
 	}, func() (*int64Inst, error) {
 		aggs, err := p.aggs(kind, name, desc, u)
-		return &int64Inst{measures: aggs}, err
+		return &int64Inst{measures: aggs, disabled: &p.disabled}, err
 	})
 }
 
@@ -646,7 +672,7 @@ func (p int64InstProvider) lookupHistogram(name string, cfg metric.Int64Histogra
 		Kind:        InstrumentKindHistogram,
 	}, func() (*int64Inst, error) {
 		aggs, err := p.histogramAggs(name, cfg)
-		return &int64Inst{measures: aggs}, err
+		return &int64Inst{measures: aggs, disabled: &p.disabled}, err
 	})
 }
 
@@ -691,7 +717,7 @@ func (p float64InstProvider) lookup(kind InstrumentKind, name, desc, u string) (
 		Kind:        kind,
 	}, func() (*float64Inst, error) {
 		aggs, err := p.aggs(kind, name, desc, u)
-		return &float64Inst{measures: aggs}, err
+		return &float64Inst{measures: aggs, disabled: &p.disabled}, err
 	})
 }
 
@@ -704,7 +730,7 @@ func (p float64InstProvider) lookupHistogram(name string, cfg metric.Float64Hist
 		Kind:        InstrumentKindHistogram,
 	}, func() (*float64Inst, error) {
 		aggs, err := p.histogramAggs(name, cfg)
-		return &float64Inst{measures: aggs}, err
+		return &float64Inst{measures: aggs, disabled: &p.disabled}, err
 	})
 }
 
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// copyResourceMetrics returns a deep copy of rm. Resources and attribute
+// sets are immutable and are not copied.
+func copyResourceMetrics(rm *metricdata.ResourceMetrics) metricdata.ResourceMetrics {
+	out := metricdata.ResourceMetrics{
+		Resource:     rm.Resource,
+		ScopeMetrics: make([]metricdata.ScopeMetrics, len(rm.ScopeMetrics)),
+	}
+	for i, sm := range rm.ScopeMetrics {
+		out.ScopeMetrics[i] = metricdata.ScopeMetrics{
+			Scope:   sm.Scope,
+			Metrics: make([]metricdata.Metrics, len(sm.Metrics)),
+		}
+		for j, m := range sm.Metrics {
+			m.Data = copyAggregation(m.Data)
+			out.ScopeMetrics[i].Metrics[j] = m
+		}
+	}
+	return out
+}
+
+func copyAggregation(a metricdata.Aggregation) metricdata.Aggregation {
+	switch a := a.(type) {
+	case metricdata.Gauge[int64]:
+		a.DataPoints = copyDataPoints(a.DataPoints)
+		return a
+	case metricdata.Gauge[float64]:
+		a.DataPoints = copyDataPoints(a.DataPoints)
+		return a
+	case metricdata.Sum[int64]:
+		a.DataPoints = copyDataPoints(a.DataPoints)
+		return a
+	case metricdata.Sum[float64]:
+		a.DataPoints = copyDataPoints(a.DataPoints)
+		return a
+	case metricdata.Histogram[int64]:
+		a.DataPoints = copyHistogramDataPoints(a.DataPoints)
+		return a
+	case metricdata.Histogram[float64]:
+		a.DataPoints = copyHistogramDataPoints(a.DataPoints)
+		return a
+	case metricdata.ExponentialHistogram[int64]:
+		a.DataPoints = copyExponentialHistogramDataPoints(a.DataPoints)
+		return a
+	case metricdata.ExponentialHistogram[float64]:
+		a.DataPoints = copyExponentialHistogramDataPoints(a.DataPoints)
+		return a
+	case metricdata.Summary:
+		a.DataPoints = slices.Clone(a.DataPoints)
+		for i := range a.DataPoints {
+			a.DataPoints[i].QuantileValues = slices.Clone(a.DataPoints[i].QuantileValues)
+		}
+		return a
+	default:
+		return a
+	}
+}
+
+func copyDataPoints[N int64 | float64](points []metricdata.DataPoint[N]) []metricdata.DataPoint[N] {
+	points = slices.Clone(points)
+	for i := range points {
+		points[i].Exemplars = copyExemplars(points[i].Exemplars)
+	}
+	return points
+}
+
+func copyHistogramDataPoints[N int64 | float64](points []metricdata.HistogramDataPoint[N]) []metricdata.HistogramDataPoint[N] {
+	points = slices.Clone(points)
+	for i := range points {
+		points[i].Bounds = slices.Clone(points[i].Bounds)
+		points[i].BucketCounts = slices.Clone(points[i].BucketCounts)
+		points[i].Exemplars = copyExemplars(points[i].Exemplars)
+	}
+	return points
+}
+
+func copyExponentialHistogramDataPoints[N int64 | float64](points []metricdata.ExponentialHistogramDataPoint[N]) []metricdata.ExponentialHistogramDataPoint[N] {
+	points = slices.Clone(points)
+	for i := range points {
+		points[i].PositiveBucket.Counts = slices.Clone(points[i].PositiveBucket.Counts)
+		points[i].NegativeBucket.Counts = slices.Clone(points[i].NegativeBucket.Counts)
+		points[i].Exemplars = copyExemplars(points[i].Exemplars)
+	}
+	return points
+}
+
+func copyExemplars[N int64 | float64](exemplars []metricdata.Exemplar[N]) []metricdata.Exemplar[N] {
+	exemplars = slices.Clone(exemplars)
+	for i := range exemplars {
+		exemplars[i].FilteredAttributes = slices.Clone(exemplars[i].FilteredAttributes)
+		exemplars[i].TraceID = slices.Clone(exemplars[i].TraceID)
+		exemplars[i].SpanID = slices.Clone(exemplars[i].SpanID)
+	}
+	return exemplars
+}
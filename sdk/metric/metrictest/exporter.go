@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrictest is a testing helper package for the SDK. User can
+// configure an in-memory exporter to verify the metrics produced by
+// different SDK configurations or custom instrumentation.
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type config struct {
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		temporalitySelector: metric.DefaultTemporalitySelector,
+		aggregationSelector: metric.DefaultAggregationSelector,
+	}
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option applies a configuration option value to an InMemoryExporter.
+type Option interface {
+	apply(config) config
+}
+
+type fnOption func(config) config
+
+func (fn fnOption) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithTemporalitySelector sets the TemporalitySelector the exporter will use
+// to determine the Temporality of an instrument based on its kind. If this
+// option is not used, the exporter will use the DefaultTemporalitySelector
+// from the go.opentelemetry.io/otel/sdk/metric package.
+func WithTemporalitySelector(selector metric.TemporalitySelector) Option {
+	return fnOption(func(cfg config) config {
+		if selector != nil {
+			cfg.temporalitySelector = selector
+		}
+		return cfg
+	})
+}
+
+// WithAggregationSelector sets the AggregationSelector the exporter will use
+// to determine the aggregation to use for an instrument based on its kind.
+// If this option is not used, the exporter will use the
+// DefaultAggregationSelector from the go.opentelemetry.io/otel/sdk/metric
+// package or the aggregation explicitly passed for a view matching an
+// instrument.
+func WithAggregationSelector(selector metric.AggregationSelector) Option {
+	return fnOption(func(cfg config) config {
+		if selector != nil {
+			cfg.aggregationSelector = selector
+		}
+		return cfg
+	})
+}
+
+var _ metric.Exporter = (*InMemoryExporter)(nil)
+
+// NewInMemoryExporter returns a new InMemoryExporter.
+func NewInMemoryExporter(opts ...Option) *InMemoryExporter {
+	return &InMemoryExporter{cfg: newConfig(opts)}
+}
+
+// InMemoryExporter is an exporter that stores all received metrics
+// in-memory.
+type InMemoryExporter struct {
+	cfg config
+
+	mu       sync.Mutex
+	rms      []metricdata.ResourceMetrics
+	shutdown bool
+}
+
+// Temporality returns the Temporality to use for an instrument kind.
+func (e *InMemoryExporter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	return e.cfg.temporalitySelector(kind)
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (e *InMemoryExporter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	return e.cfg.aggregationSelector(kind)
+}
+
+// Export handles export of metrics by storing a copy of them in memory.
+func (e *InMemoryExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.shutdown {
+		return metric.ErrExporterShutdown
+	}
+	// The SDK reuses rm once Export returns.
+	e.rms = append(e.rms, copyResourceMetrics(rm))
+	return nil
+}
+
+// ForceFlush does nothing, the metrics are stored when they are exported.
+func (e *InMemoryExporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown stops the exporter. The metrics held in memory are kept so they
+// can be verified after the MeterProvider is shut down.
+func (e *InMemoryExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return ctx.Err()
+}
+
+// Reset the current in-memory storage.
+func (e *InMemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rms = nil
+}
+
+// GetMetrics returns the current in-memory stored metrics, one
+// ResourceMetrics per export, in the order they were exported.
+func (e *InMemoryExporter) GetMetrics() []metricdata.ResourceMetrics {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ret := make([]metricdata.ResourceMetrics, len(e.rms))
+	copy(ret, e.rms)
+	return ret
+}
+
+// Metric returns the metric with the name from the most recent export
+// containing it. It returns false if no exported metric has the name.
+func (e *InMemoryExporter) Metric(name string) (metricdata.Metrics, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i := len(e.rms) - 1; i >= 0; i-- {
+		if m, ok := FindMetric(e.rms[i], name); ok {
+			return m, true
+		}
+	}
+	return metricdata.Metrics{}, false
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrictest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestInMemoryExporter(t *testing.T) {
+	exp := NewInMemoryExporter(WithTemporalitySelector(func(metric.InstrumentKind) metricdata.Temporality {
+		return metricdata.DeltaTemporality
+	}))
+	provider := metric.NewMeterProvider(metric.WithReader(metric.NewPeriodicReader(exp)))
+	counter, err := provider.Meter("meter").Int64Counter("requests")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	counter.Add(ctx, 1, otelmetric.WithAttributes(attribute.String("method", "GET")))
+	require.NoError(t, provider.ForceFlush(ctx))
+	counter.Add(ctx, 2, otelmetric.WithAttributes(attribute.String("method", "GET")))
+	require.NoError(t, provider.Shutdown(ctx))
+
+	// The metrics exported when the provider is shut down are kept.
+	got := exp.GetMetrics()
+	require.Len(t, got, 2)
+	for i, want := range []int64{1, 2} {
+		m, ok := FindMetric(got[i], "requests")
+		require.True(t, ok)
+		dp, ok := FindDataPoint[int64](m, attribute.String("method", "GET"))
+		require.True(t, ok)
+		assert.Equal(t, want, dp.Value, "export %d", i)
+		assert.Equal(t, metricdata.DeltaTemporality, m.Data.(metricdata.Sum[int64]).Temporality)
+	}
+
+	m, ok := exp.Metric("requests")
+	require.True(t, ok)
+	dp, ok := FindDataPoint[int64](m, attribute.String("method", "GET"))
+	require.True(t, ok)
+	assert.Equal(t, int64(2), dp.Value, "most recent export")
+
+	_, ok = exp.Metric("unknown")
+	assert.False(t, ok)
+
+	assert.ErrorIs(t, exp.Export(ctx, &metricdata.ResourceMetrics{}), metric.ErrExporterShutdown)
+
+	exp.Reset()
+	assert.Empty(t, exp.GetMetrics())
+}
+
+func TestInMemoryExporterSelectors(t *testing.T) {
+	exp := NewInMemoryExporter()
+	assert.Equal(t, metricdata.CumulativeTemporality, exp.Temporality(metric.InstrumentKindCounter))
+	assert.Equal(t, metric.AggregationSum{}, exp.Aggregation(metric.InstrumentKindCounter))
+
+	exp = NewInMemoryExporter(WithAggregationSelector(func(metric.InstrumentKind) metric.Aggregation {
+		return metric.AggregationDrop{}
+	}))
+	assert.Equal(t, metric.AggregationDrop{}, exp.Aggregation(metric.InstrumentKindCounter))
+}
+
+func TestInMemoryExporterCopies(t *testing.T) {
+	rm := &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "histogram",
+				Data: metricdata.Histogram[float64]{
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Bounds:       []float64{1},
+						BucketCounts: []uint64{1, 2},
+						Exemplars:    []metricdata.Exemplar[float64]{{Value: 1, TraceID: []byte{1}}},
+					}},
+				},
+			}},
+		}},
+	}
+
+	exp := NewInMemoryExporter()
+	require.NoError(t, exp.Export(context.Background(), rm))
+
+	// The SDK reuses the ResourceMetrics passed to Export.
+	dp := &rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	dp.BucketCounts[0] = 10
+	dp.Exemplars[0].TraceID[0] = 10
+	rm.ScopeMetrics[0].Metrics[0].Name = "reused"
+
+	m, ok := exp.Metric("histogram")
+	require.True(t, ok)
+	got, ok := FindHistogramDataPoint[float64](m)
+	require.True(t, ok)
+	assert.Equal(t, []uint64{1, 2}, got.BucketCounts)
+	assert.Equal(t, []byte{1}, got.Exemplars[0].TraceID)
+}
+
+func TestInMemoryExporterContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	exp := NewInMemoryExporter()
+	assert.ErrorIs(t, exp.Export(ctx, &metricdata.ResourceMetrics{}), context.Canceled)
+	assert.ErrorIs(t, exp.ForceFlush(ctx), context.Canceled)
+	assert.Empty(t, exp.GetMetrics())
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// FindMetric returns the first metric of rm with the name. It returns false
+// if rm does not contain a metric with the name.
+func FindMetric(rm metricdata.ResourceMetrics, name string) (metricdata.Metrics, bool) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+	return metricdata.Metrics{}, false
+}
+
+// FindDataPoint returns the data point of the sum or gauge m with exactly
+// the attributes attrs. It returns false if m is not a sum or gauge of N
+// values, or if it has no data point with these attributes.
+func FindDataPoint[N int64 | float64](m metricdata.Metrics, attrs ...attribute.KeyValue) (metricdata.DataPoint[N], bool) {
+	var points []metricdata.DataPoint[N]
+	switch data := m.Data.(type) {
+	case metricdata.Sum[N]:
+		points = data.DataPoints
+	case metricdata.Gauge[N]:
+		points = data.DataPoints
+	}
+	return find(points, func(dp metricdata.DataPoint[N]) attribute.Set { return dp.Attributes }, attrs)
+}
+
+// FindHistogramDataPoint returns the data point of the histogram m with
+// exactly the attributes attrs. It returns false if m is not a histogram of
+// N values, or if it has no data point with these attributes.
+func FindHistogramDataPoint[N int64 | float64](m metricdata.Metrics, attrs ...attribute.KeyValue) (metricdata.HistogramDataPoint[N], bool) {
+	data, _ := m.Data.(metricdata.Histogram[N])
+	return find(data.DataPoints, func(dp metricdata.HistogramDataPoint[N]) attribute.Set { return dp.Attributes }, attrs)
+}
+
+// FindExponentialHistogramDataPoint returns the data point of the
+// exponential histogram m with exactly the attributes attrs. It returns
+// false if m is not an exponential histogram of N values, or if it has no
+// data point with these attributes.
+func FindExponentialHistogramDataPoint[N int64 | float64](m metricdata.Metrics, attrs ...attribute.KeyValue) (metricdata.ExponentialHistogramDataPoint[N], bool) {
+	data, _ := m.Data.(metricdata.ExponentialHistogram[N])
+	return find(data.DataPoints, func(dp metricdata.ExponentialHistogramDataPoint[N]) attribute.Set { return dp.Attributes }, attrs)
+}
+
+func find[T any](points []T, attributes func(T) attribute.Set, attrs []attribute.KeyValue) (T, bool) {
+	want := attribute.NewSet(attrs...)
+	for _, dp := range points {
+		if got := attributes(dp); got.Equals(&want) {
+			return dp, true
+		}
+	}
+	var zero T
+	return zero, false
+}
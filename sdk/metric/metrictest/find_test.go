@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrictest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var (
+	attrA = attribute.NewSet(attribute.String("k", "a"))
+	attrB = attribute.NewSet(attribute.String("k", "b"), attribute.Int("n", 1))
+
+	sum = metricdata.Metrics{
+		Name: "sum",
+		Data: metricdata.Sum[int64]{DataPoints: []metricdata.DataPoint[int64]{
+			{Attributes: attrA, Value: 1},
+			{Attributes: attrB, Value: 2},
+		}},
+	}
+	gauge = metricdata.Metrics{
+		Name: "gauge",
+		Data: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{
+			{Value: 3},
+		}},
+	}
+	histogram = metricdata.Metrics{
+		Name: "histogram",
+		Data: metricdata.Histogram[int64]{DataPoints: []metricdata.HistogramDataPoint[int64]{
+			{Attributes: attrA, Count: 4},
+		}},
+	}
+	exponentialHistogram = metricdata.Metrics{
+		Name: "exponential",
+		Data: metricdata.ExponentialHistogram[float64]{DataPoints: []metricdata.ExponentialHistogramDataPoint[float64]{
+			{Attributes: attrB, Count: 5},
+		}},
+	}
+)
+
+func TestFindMetric(t *testing.T) {
+	rm := metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{
+		{Scope: instrumentation.Scope{Name: "a"}, Metrics: []metricdata.Metrics{sum}},
+		{Scope: instrumentation.Scope{Name: "b"}, Metrics: []metricdata.Metrics{gauge, histogram}},
+	}}
+
+	got, ok := FindMetric(rm, "histogram")
+	assert.True(t, ok)
+	assert.Equal(t, histogram, got)
+
+	_, ok = FindMetric(rm, "unknown")
+	assert.False(t, ok)
+}
+
+func TestFindDataPoint(t *testing.T) {
+	dp, ok := FindDataPoint[int64](sum, attribute.Int("n", 1), attribute.String("k", "b"))
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), dp.Value)
+
+	_, ok = FindDataPoint[int64](sum, attribute.String("k", "b"))
+	assert.False(t, ok, "subset of the attributes")
+
+	_, ok = FindDataPoint[float64](sum, attribute.String("k", "a"))
+	assert.False(t, ok, "value type")
+
+	fdp, ok := FindDataPoint[float64](gauge)
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, fdp.Value)
+
+	_, ok = FindDataPoint[int64](histogram, attribute.String("k", "a"))
+	assert.False(t, ok, "histogram")
+}
+
+func TestFindHistogramDataPoint(t *testing.T) {
+	dp, ok := FindHistogramDataPoint[int64](histogram, attribute.String("k", "a"))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), dp.Count)
+
+	_, ok = FindHistogramDataPoint[int64](sum, attribute.String("k", "a"))
+	assert.False(t, ok)
+}
+
+func TestFindExponentialHistogramDataPoint(t *testing.T) {
+	dp, ok := FindExponentialHistogramDataPoint[float64](exponentialHistogram, attrB.ToSlice()...)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), dp.Count)
+
+	_, ok = FindExponentialHistogramDataPoint[int64](exponentialHistogram, attrB.ToSlice()...)
+	assert.False(t, ok)
+}
@@ -66,6 +66,21 @@ type pipeline struct {
 	aggregations   map[instrumentation.Scope][]instrumentSync
 	callbacks      []func(context.Context) error
 	multiCallbacks list.List
+	// disabled holds the disabled state of the meter of each scope. Scopes
+	// without an entry are considered enabled.
+	disabled map[instrumentation.Scope]*atomic.Bool
+}
+
+// registerScope registers the disabled state of the meter for scope with
+// pipeline p. Aggregations of a scope whose meter is disabled are not
+// produced.
+func (p *pipeline) registerScope(scope instrumentation.Scope, disabled *atomic.Bool) {
+	p.Lock()
+	defer p.Unlock()
+	if p.disabled == nil {
+		p.disabled = make(map[instrumentation.Scope]*atomic.Bool)
+	}
+	p.disabled[scope] = disabled
 }
 
 // addSync adds the instrumentSync to pipeline p with scope. This method is not
@@ -136,6 +151,9 @@ func (p *pipeline) produce(ctx context.Context, rm *metricdata.ResourceMetrics)
 
 	i := 0
 	for scope, instruments := range p.aggregations {
+		if d, ok := p.disabled[scope]; ok && d.Load() {
+			continue
+		}
 		rm.ScopeMetrics[i].Metrics = internal.ReuseSlice(rm.ScopeMetrics[i].Metrics, len(instruments))
 		j := 0
 		for _, inst := range instruments {
@@ -562,6 +580,12 @@ func newPipelines(res *resource.Resource, readers []Reader, views []View) pipeli
 	return pipes
 }
 
+func (p pipelines) registerScope(scope instrumentation.Scope, disabled *atomic.Bool) {
+	for _, pipe := range p {
+		pipe.registerScope(scope, disabled)
+	}
+}
+
 func (p pipelines) registerMultiCallback(c multiCallback) metric.Registration {
 	unregs := make([]func(), len(p))
 	for i, pipe := range p {
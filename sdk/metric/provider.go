@@ -24,6 +24,8 @@ type MeterProvider struct {
 	pipes  pipelines
 	meters cache[instrumentation.Scope, *meter]
 
+	meterConfigurator atomic.Pointer[MeterConfigurator]
+
 	forceFlush, shutdown func(context.Context) error
 	stopped              atomic.Bool
 }
@@ -46,6 +48,9 @@ func NewMeterProvider(options ...Option) *MeterProvider {
 		forceFlush: flush,
 		shutdown:   sdown,
 	}
+	if conf.meterConfigurator != nil {
+		mp.meterConfigurator.Store(&conf.meterConfigurator)
+	}
 	// Log after creation so all readers show correctly they are registered.
 	global.Info("MeterProvider created",
 		"Resource", conf.res,
@@ -88,10 +93,42 @@ func (mp *MeterProvider) Meter(name string, options ...metric.MeterOption) metri
 	)
 
 	return mp.meters.Lookup(s, func() *meter {
-		return newMeter(s, mp.pipes)
+		m := newMeter(s, mp.pipes)
+		m.setConfig(mp.meterConfig(s))
+		return m
 	})
 }
 
+// SetMeterConfigurator replaces the MeterConfigurator used by mp to compute
+// the MeterConfig of its Meters. The new configurator is applied to all
+// Meters already created by mp, as well as the ones created afterwards.
+//
+// Passing nil restores the default behavior of all Meters being enabled.
+//
+// This method is safe to call concurrently.
+func (mp *MeterProvider) SetMeterConfigurator(configurator MeterConfigurator) {
+	if configurator == nil {
+		mp.meterConfigurator.Store(nil)
+	} else {
+		mp.meterConfigurator.Store(&configurator)
+	}
+
+	mp.meters.Lock()
+	defer mp.meters.Unlock()
+	for s, m := range mp.meters.data {
+		m.setConfig(mp.meterConfig(s))
+	}
+}
+
+// meterConfig returns the MeterConfig for the instrumentation scope s.
+func (mp *MeterProvider) meterConfig(s instrumentation.Scope) MeterConfig {
+	c := mp.meterConfigurator.Load()
+	if c == nil || *c == nil {
+		return MeterConfig{}
+	}
+	return (*c)(s)
+}
+
 // ForceFlush flushes all pending telemetry.
 //
 // This method honors the deadline or cancellation of ctx. An appropriate
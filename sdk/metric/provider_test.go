@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/otel"
 	api "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
@@ -125,6 +126,94 @@ func TestMeterProviderReturnsNoopMeterAfterShutdown(t *testing.T) {
 	assert.Truef(t, ok, "Meter from shutdown MeterProvider is not NoOp: %T", m)
 }
 
+func TestMeterProviderMeterConfigurator(t *testing.T) {
+	disableFoo := func(s instrumentation.Scope) MeterConfig {
+		return MeterConfig{Disabled: s.Name == "foo"}
+	}
+
+	collect := func(t *testing.T, rdr Reader) []string {
+		t.Helper()
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, rdr.Collect(context.Background(), &rm))
+		var scopes []string
+		for _, sm := range rm.ScopeMetrics {
+			scopes = append(scopes, sm.Scope.Name)
+		}
+		return scopes
+	}
+
+	record := func(t *testing.T, m api.Meter) (calls *int) {
+		t.Helper()
+		ctr, err := m.Int64Counter("counter")
+		require.NoError(t, err)
+		ctr.Add(context.Background(), 1)
+
+		calls = new(int)
+		_, err = m.Int64ObservableGauge("gauge", api.WithInt64Callback(
+			func(_ context.Context, o api.Int64Observer) error {
+				*calls++
+				o.Observe(1)
+				return nil
+			},
+		))
+		require.NoError(t, err)
+		return calls
+	}
+
+	t.Run("Option", func(t *testing.T) {
+		rdr := NewManualReader()
+		mp := NewMeterProvider(WithReader(rdr), WithMeterConfigurator(disableFoo))
+
+		fooCalls := record(t, mp.Meter("foo"))
+		barCalls := record(t, mp.Meter("bar"))
+
+		assert.Equal(t, []string{"bar"}, collect(t, rdr))
+		assert.Equal(t, 0, *fooCalls, "disabled Meter callback called")
+		assert.Equal(t, 1, *barCalls, "enabled Meter callback not called")
+	})
+
+	t.Run("SetMeterConfigurator", func(t *testing.T) {
+		rdr := NewManualReader()
+		mp := NewMeterProvider(WithReader(rdr))
+
+		foo := mp.Meter("foo")
+		fooCalls := record(t, foo)
+		assert.Equal(t, []string{"foo"}, collect(t, rdr))
+		assert.Equal(t, 1, *fooCalls)
+
+		mp.SetMeterConfigurator(disableFoo)
+		assert.Same(t, foo, mp.Meter("foo"))
+		assert.Empty(t, collect(t, rdr), "existing Meter not disabled")
+		assert.Equal(t, 1, *fooCalls, "disabled Meter callback called")
+
+		mp.SetMeterConfigurator(nil)
+		assert.Equal(t, []string{"foo"}, collect(t, rdr), "Meter not re-enabled")
+		assert.Equal(t, 2, *fooCalls, "re-enabled Meter callback not called")
+	})
+
+	t.Run("DropMeasurements", func(t *testing.T) {
+		rdr := NewManualReader()
+		mp := NewMeterProvider(WithReader(rdr))
+
+		ctr, err := mp.Meter("foo").Int64Counter("counter")
+		require.NoError(t, err)
+
+		mp.SetMeterConfigurator(disableFoo)
+		ctr.Add(context.Background(), 1)
+		mp.SetMeterConfigurator(nil)
+		ctr.Add(context.Background(), 2)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, rdr.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, sum.DataPoints, 1)
+		assert.Equal(t, int64(2), sum.DataPoints[0].Value, "measurement of disabled Meter recorded")
+	})
+}
+
 func TestMeterProviderMixingOnRegisterErrors(t *testing.T) {
 	otel.SetLogger(testr.New(t))
 
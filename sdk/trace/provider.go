@@ -42,6 +42,9 @@ type tracerProviderConfig struct {
 
 	// resource contains attributes representing an entity that produces telemetry.
 	resource *resource.Resource
+
+	// tracerConfigurator computes the TracerConfig of each Tracer created.
+	tracerConfigurator TracerConfigurator
 }
 
 // MarshalLog is the marshaling function used by the logging system to represent this Provider.
@@ -66,9 +69,10 @@ func (cfg tracerProviderConfig) MarshalLog() interface{} {
 type TracerProvider struct {
 	embedded.TracerProvider
 
-	mu             sync.Mutex
-	namedTracer    map[instrumentation.Scope]*tracer
-	spanProcessors atomic.Pointer[spanProcessorStates]
+	mu                 sync.Mutex
+	namedTracer        map[instrumentation.Scope]*tracer
+	tracerConfigurator TracerConfigurator
+	spanProcessors     atomic.Pointer[spanProcessorStates]
 
 	isShutdown atomic.Bool
 
@@ -105,11 +109,12 @@ func NewTracerProvider(opts ...TracerProviderOption) *TracerProvider {
 	o = ensureValidTracerProviderConfig(o)
 
 	tp := &TracerProvider{
-		namedTracer: make(map[instrumentation.Scope]*tracer),
-		sampler:     o.sampler,
-		idGenerator: o.idGenerator,
-		spanLimits:  o.spanLimits,
-		resource:    o.resource,
+		namedTracer:        make(map[instrumentation.Scope]*tracer),
+		tracerConfigurator: o.tracerConfigurator,
+		sampler:            o.sampler,
+		idGenerator:        o.idGenerator,
+		spanLimits:         o.spanLimits,
+		resource:           o.resource,
 	}
 	global.Info("TracerProvider created", "config", o)
 
@@ -158,6 +163,7 @@ func (p *TracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.T
 				provider:             p,
 				instrumentationScope: is,
 			}
+			t.setConfig(p.tracerConfig(is))
 			p.namedTracer[is] = t
 		}
 		return t, ok
@@ -173,6 +179,32 @@ func (p *TracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.T
 	return t
 }
 
+// SetTracerConfigurator replaces the TracerConfigurator used to compute the
+// TracerConfig of the Tracers p provides. The new configurator is applied to
+// all Tracers already created by p, as well as the ones created afterwards.
+//
+// Passing nil restores the default behavior of all Tracers being enabled.
+//
+// This method is safe to be called concurrently.
+func (p *TracerProvider) SetTracerConfigurator(configurator TracerConfigurator) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tracerConfigurator = configurator
+	for is, t := range p.namedTracer {
+		t.setConfig(p.tracerConfig(is))
+	}
+}
+
+// tracerConfig returns the TracerConfig for the instrumentation scope is. The
+// mu lock needs to be held when calling this method.
+func (p *TracerProvider) tracerConfig(is instrumentation.Scope) TracerConfig {
+	if p.tracerConfigurator == nil {
+		return TracerConfig{}
+	}
+	return p.tracerConfigurator(is)
+}
+
 // RegisterSpanProcessor adds the given SpanProcessor to the list of SpanProcessors.
 func (p *TracerProvider) RegisterSpanProcessor(sp SpanProcessor) {
 	// This check prevents calls during a shutdown.
@@ -455,6 +487,19 @@ func WithRawSpanLimits(limits SpanLimits) TracerProviderOption {
 	})
 }
 
+// WithTracerConfigurator registers the TracerConfigurator used to compute
+// the TracerConfig of each Tracer the TracerProvider provides. The
+// configurator can be replaced after the TracerProvider is created using its
+// SetTracerConfigurator method.
+//
+// If this option is not used, all Tracers are enabled.
+func WithTracerConfigurator(configurator TracerConfigurator) TracerProviderOption {
+	return traceProviderOptionFunc(func(cfg tracerProviderConfig) tracerProviderConfig {
+		cfg.tracerConfigurator = configurator
+		return cfg
+	})
+}
+
 func applyTracerProviderEnvConfigs(cfg tracerProviderConfig) tracerProviderConfig {
 	for _, opt := range tracerProviderOptionsFromEnv() {
 		cfg = opt.apply(cfg)
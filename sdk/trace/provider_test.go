@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	ottest "go.opentelemetry.io/otel/sdk/internal/internaltest"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -194,6 +195,78 @@ func TestSchemaURL(t *testing.T) {
 	assert.EqualValues(t, schemaURL, tracerStruct.instrumentationScope.SchemaURL)
 }
 
+type countingSpanProcessor struct {
+	basicSpanProcessor
+
+	started, ended int
+}
+
+func (t *countingSpanProcessor) OnStart(context.Context, ReadWriteSpan) { t.started++ }
+func (t *countingSpanProcessor) OnEnd(ReadOnlySpan)                     { t.ended++ }
+
+func TestTracerConfigurator(t *testing.T) {
+	disableFoo := func(s instrumentation.Scope) TracerConfig {
+		return TracerConfig{Disabled: s.Name == "foo"}
+	}
+
+	t.Run("Option", func(t *testing.T) {
+		sp := &countingSpanProcessor{}
+		stp := NewTracerProvider(
+			WithSpanProcessor(sp),
+			WithTracerConfigurator(disableFoo),
+		)
+
+		_, span := stp.Tracer("foo").Start(context.Background(), "span")
+		assert.False(t, span.IsRecording(), "disabled Tracer span recording")
+		span.End()
+		assert.Equal(t, 0, sp.started, "disabled Tracer span started")
+		assert.Equal(t, 0, sp.ended, "disabled Tracer span ended")
+
+		_, span = stp.Tracer("bar").Start(context.Background(), "span")
+		assert.True(t, span.IsRecording(), "enabled Tracer span not recording")
+		span.End()
+		assert.Equal(t, 1, sp.started, "enabled Tracer span not started")
+		assert.Equal(t, 1, sp.ended, "enabled Tracer span not ended")
+	})
+
+	t.Run("PropagateParent", func(t *testing.T) {
+		stp := NewTracerProvider(WithTracerConfigurator(disableFoo))
+
+		ctx, parent := stp.Tracer("bar").Start(context.Background(), "parent")
+		defer parent.End()
+
+		ctx, span := stp.Tracer("foo").Start(ctx, "span")
+		assert.False(t, span.IsRecording(), "disabled Tracer span recording")
+		assert.Equal(t, parent.SpanContext(), span.SpanContext(), "parent span context not propagated")
+
+		_, child := stp.Tracer("bar").Start(ctx, "child")
+		defer child.End()
+		ro, ok := child.(ReadOnlySpan)
+		require.True(t, ok, "child span not recorded")
+		assert.Equal(t, parent.SpanContext().SpanID(), ro.Parent().SpanID(), "child span parent")
+	})
+
+	t.Run("SetTracerConfigurator", func(t *testing.T) {
+		stp := NewTracerProvider()
+		foo := stp.Tracer("foo")
+
+		_, span := foo.Start(context.Background(), "span")
+		assert.True(t, span.IsRecording(), "Tracer disabled by default")
+
+		stp.SetTracerConfigurator(disableFoo)
+		_, span = foo.Start(context.Background(), "span")
+		assert.False(t, span.IsRecording(), "existing Tracer not disabled")
+		assert.Same(t, foo, stp.Tracer("foo"))
+
+		_, span = stp.Tracer("bar").Start(context.Background(), "span")
+		assert.True(t, span.IsRecording(), "new Tracer disabled")
+
+		stp.SetTracerConfigurator(nil)
+		_, span = foo.Start(context.Background(), "span")
+		assert.True(t, span.IsRecording(), "Tracer not re-enabled")
+	})
+}
+
 func TestRegisterAfterShutdownWithoutProcessors(t *testing.T) {
 	stp := NewTracerProvider()
 	err := stp.Shutdown(context.Background())
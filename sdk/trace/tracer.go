@@ -5,6 +5,7 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/sdk/instrumentation"
@@ -17,10 +18,17 @@ type tracer struct {
 
 	provider             *TracerProvider
 	instrumentationScope instrumentation.Scope
+
+	disabled atomic.Bool
 }
 
 var _ trace.Tracer = &tracer{}
 
+// setConfig applies the TracerConfig c to tr.
+func (tr *tracer) setConfig(c TracerConfig) {
+	tr.disabled.Store(c.Disabled)
+}
+
 // Start starts a Span and returns it along with a context containing it.
 //
 // The Span is created with the provided name and as a child of any existing
@@ -34,6 +42,10 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanS
 		ctx = context.Background()
 	}
 
+	if tr.disabled.Load() {
+		return tr.startDisabled(ctx)
+	}
+
 	// For local spans created by this SDK, track child span count.
 	if p := trace.SpanFromContext(ctx); p != nil {
 		if sdkSpan, ok := p.(*recordingSpan); ok {
@@ -55,6 +67,18 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanS
 	return trace.ContextWithSpan(ctx, s), s
 }
 
+// startDisabled returns a non-recording span for a disabled tracer. Like the
+// no-op Tracer, any span context found in ctx is propagated as is.
+func (tr *tracer) startDisabled(ctx context.Context) (context.Context, trace.Span) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() && span.SpanContext().IsValid() {
+		// Already a non-recording span, return it directly.
+		return ctx, span
+	}
+	span = nonRecordingSpan{tracer: tr, sc: span.SpanContext()}
+	return trace.ContextWithSpan(ctx, span), span
+}
+
 type runtimeTracer interface {
 	// runtimeTrace starts a "runtime/trace".Task for the span and
 	// returns a context containing the task.
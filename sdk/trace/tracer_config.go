@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "go.opentelemetry.io/otel/sdk/instrumentation"
+
+// TracerConfig is the configuration of a Tracer.
+//
+// The zero value is the default configuration: an enabled Tracer.
+//
+// This type is experimental and is based on the Tracer configurator defined
+// in the OpenTelemetry specification. It may change or be removed in a
+// future release.
+type TracerConfig struct {
+	// Disabled defines if the Tracer is disabled.
+	//
+	// A disabled Tracer behaves like a no-op Tracer: all spans it starts are
+	// non-recording and are not passed to any SpanProcessor. The span context
+	// of a parent span is still propagated.
+	Disabled bool
+}
+
+// TracerConfigurator computes the TracerConfig of the Tracer identified by an
+// instrumentation scope.
+//
+// A TracerConfigurator needs to be safe to call concurrently.
+//
+// This type is experimental and is based on the Tracer configurator defined
+// in the OpenTelemetry specification. It may change or be removed in a
+// future release.
+type TracerConfigurator func(instrumentation.Scope) TracerConfig